@@ -0,0 +1,107 @@
+package app
+
+import (
+	log "github.com/sirupsen/logrus"
+	"go.uber.org/dig"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/controller"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/tracing"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+	"github.com/EQUISEED-WEALTH/pinch/backend/repo"
+	"github.com/EQUISEED-WEALTH/pinch/backend/service"
+)
+
+// Handle Build Error
+func handleBuildError(err error) {
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Provide all build functions
+func Provide(container *dig.Container, buildFuncs ...utils.Any) {
+	for _, buildFunc := range buildFuncs {
+		err := container.Provide(buildFunc)
+		handleBuildError(err)
+	}
+}
+
+// Invoke all build functions
+func Invoke(container *dig.Container, buildFuncs ...utils.Any) {
+	for _, buildFunc := range buildFuncs {
+		err := container.Invoke(buildFunc)
+		handleBuildError(err)
+	}
+}
+
+// BuildContainer provides all the shared dependencies,
+// used by the api server and the pinchctl command
+func BuildContainer() *dig.Container {
+	domain.SetupLogger()
+
+	container := dig.New()
+
+	Provide(container,
+		domain.NewLifecycle,
+		controller.BuildGinEngine,
+		repo.NewPgDB,
+		repo.NewRedisClient,
+
+		// Repositories
+		repo.NewUserRepo,
+		repo.NewAugmontUserRepo,
+		repo.NewAugmontOrderRepo,
+		repo.NewAugmontInMemRepo,
+		repo.NewAugmontStatementRepo,
+		repo.NewAugmontInvoiceRepo,
+		repo.NewAugmontProductRepo,
+		repo.NewAugmontCartRepo,
+		repo.NewAugmontShipmentRepo,
+		repo.NewAugmontGiftRepo,
+		repo.NewNotificationRepo,
+		repo.NewOutboxRepo,
+		repo.NewUnitOfWork,
+		repo.NewRedisEventBus,
+		repo.NewRedisJobRepo,
+		repo.NewScheduleRepo,
+		repo.NewRedisLeaderLease,
+		repo.NewLocalStorage,
+
+		// Services
+		service.NewAugmontAuthService,
+		service.NewAugmondService,
+		service.NewAugmontOrderService,
+		service.NewAugmontStatementService,
+		service.NewAugmontInvoiceService,
+		service.NewAugmontProductService,
+		service.NewAugmontCartService,
+		service.NewAugmontShipmentService,
+		service.NewAugmontGiftService,
+		service.NewNotificationProviders,
+		service.NewNotificationService,
+		service.NewNotifier,
+		service.NewEventNotifier,
+		service.NewEventRelay,
+		service.NewJobQueue,
+		service.NewSchedulerService,
+		service.NewAugmontTaxService,
+		service.NewUserService,
+
+		// Job handlers
+		service.NewInvoiceJobHandler,
+		service.NewKYCJobHandler,
+
+		// Scheduled tasks
+		service.NewProductSyncTask,
+		service.NewShipmentPollTask,
+		service.NewNotificationDispatchTask,
+		service.NewGiftClaimSweepTask,
+		service.NewGiftSendSweepTask,
+	)
+
+	// Configure tracing before anything is served
+	Invoke(container, tracing.Setup)
+
+	return container
+}
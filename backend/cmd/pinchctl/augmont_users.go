@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"go.uber.org/dig"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+var augmontUsersCommand = &command{
+	usage: "look up augmont accounts and their UIDs",
+	subcommands: map[string]subcommand{
+		"list": listAugmontUsers,
+		"get":  getAugmontUser,
+	},
+}
+
+// augmontUserFlags are the flags shared by the commands
+// working on a single augmont user
+type augmontUserFlags struct {
+	userID *uint64
+	mobile *string
+	uid    *string
+}
+
+func newAugmontUserFlags(fs *flag.FlagSet) *augmontUserFlags {
+	return &augmontUserFlags{
+		userID: fs.Uint64("user-id", 0, "pinch user id"),
+		mobile: fs.String("mobile", "", "pinch user mobile number"),
+		uid:    fs.String("uid", "", "augmont unique id"),
+	}
+}
+
+// find resolves the augmont user from whichever flag is set
+func (f *augmontUserFlags) find(
+	ctx context.Context,
+	users interfaces.UserRepo,
+	augUsers interfaces.AugmontUserRepo,
+) (*models.AugmontUser, error) {
+	query := &models.AugmontUser{}
+	switch {
+	case *f.userID != 0:
+		query.UserID = f.userID
+	case *f.uid != "":
+		query.UID = f.uid
+	case *f.mobile != "":
+		user, err := users.FindOne(ctx, &models.User{Mobile: f.mobile})
+		if err != nil {
+			return nil, err
+		}
+		query.UserID = user.ID
+	default:
+		return nil, errors.New("one of -user-id, -mobile or -uid is required")
+	}
+	return augUsers.FindUser(ctx, query)
+}
+
+// none is true if no flag selects a user
+func (f *augmontUserFlags) none() bool {
+	return *f.userID == 0 && *f.mobile == "" && *f.uid == ""
+}
+
+// each calls fn with the user of the flags, or with every user a page at
+// a time if none is given, oldest first so users created meanwhile come last
+func (f *augmontUserFlags) each(
+	ctx context.Context,
+	users interfaces.UserRepo,
+	augUsers interfaces.AugmontUserRepo,
+	fn func(*models.AugmontUser) error,
+) error {
+	if !f.none() {
+		user, err := f.find(ctx, users, augUsers)
+		if err != nil {
+			return err
+		}
+		return fn(user)
+	}
+
+	q := &utils.ListQuery{Limit: utils.MaxPageLimit, Sort: "id"}
+	for {
+		found, page, err := augUsers.ListUsers(ctx, q)
+		if err != nil {
+			return err
+		}
+		for _, user := range found {
+			if err := fn(user); err != nil {
+				return err
+			}
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		q.Offset += len(found)
+	}
+}
+
+func printAugmontUsers(out *printer, users []*models.AugmontUser) error {
+	rows := make([][]string, 0, len(users))
+	for _, u := range users {
+		rows = append(rows, []string{
+			str(u.ID), str(u.UserID), str(u.UID), str(u.KYCStatus), str(u.CreatedAt),
+		})
+	}
+	return out.Print(users, []string{"ID", "USER ID", "UID", "KYC", "CREATED AT"}, rows)
+}
+
+func listAugmontUsers(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	fs := flag.NewFlagSet("augmont-users list", flag.ExitOnError)
+	kycStatus := fs.String("kyc-status", "", "filter by kyc status, pending, approved or rejected")
+	limit := fs.Int("limit", utils.MaxPageLimit, "users per page")
+	offset := fs.Int("offset", 0, "users to skip")
+	fs.Parse(args)
+
+	q := &utils.ListQuery{Limit: *limit, Offset: *offset}
+	if err := q.Normalize(); err != nil {
+		return err
+	}
+	if *kycStatus != "" {
+		q.Where("kycStatus", utils.FilterEq, *kycStatus)
+	}
+
+	return c.Invoke(func(repo interfaces.AugmontUserRepo) error {
+		users, page, err := repo.ListUsers(ctx, q)
+		if err != nil {
+			return err
+		}
+		if err := printAugmontUsers(out, users); err != nil {
+			return err
+		}
+		if page.NextCursor != "" {
+			fmt.Fprintf(os.Stderr, "%d of %d users, next page -offset %d\n",
+				len(users), page.Total, page.Offset+len(users))
+		}
+		return nil
+	})
+}
+
+func getAugmontUser(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	fs := flag.NewFlagSet("augmont-users get", flag.ExitOnError)
+	f := newAugmontUserFlags(fs)
+	fs.Parse(args)
+
+	return c.Invoke(func(
+		users interfaces.UserRepo,
+		augUsers interfaces.AugmontUserRepo,
+	) error {
+		user, err := f.find(ctx, users, augUsers)
+		if err != nil {
+			return err
+		}
+		return printAugmontUsers(out, []*models.AugmontUser{user})
+	})
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+func TestEachAugmontUser(t *testing.T) {
+	ctx := context.Background()
+	var all []*models.AugmontUser
+	for i := uint64(1); i <= utils.MaxPageLimit+5; i++ {
+		id := i
+		all = append(all, &models.AugmontUser{ID: &id})
+	}
+	one := uint64(7)
+	augUsers := &fakeAugmontUsers{user: &models.AugmontUser{ID: &one}, all: all}
+
+	flags := func(args ...string) *augmontUserFlags {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		f := newAugmontUserFlags(fs)
+		assert.NoError(t, fs.Parse(args))
+		return f
+	}
+	each := func(args ...string) ([]uint64, error) {
+		var ids []uint64
+		err := flags(args...).each(ctx, nil, augUsers, func(user *models.AugmontUser) error {
+			ids = append(ids, *user.ID)
+			return nil
+		})
+		return ids, err
+	}
+
+	t.Run("should call fn with the user of the flags", func(t *testing.T) {
+		ids, err := each("-user-id", "2")
+		assert.NoError(t, err)
+		assert.Equal(t, []uint64{7}, ids)
+	})
+
+	t.Run("should page through every user when none is given", func(t *testing.T) {
+		ids, err := each()
+		assert.NoError(t, err)
+		assert.Len(t, ids, len(all))
+		assert.Equal(t, uint64(len(all)), ids[len(ids)-1])
+	})
+
+	t.Run("should stop at the first error", func(t *testing.T) {
+		calls := 0
+		err := flags().each(ctx, nil, augUsers, func(user *models.AugmontUser) error {
+			calls++
+			return errors.New("augmont is down")
+		})
+		assert.EqualError(t, err, "augmont is down")
+		assert.Equal(t, 1, calls)
+	})
+}
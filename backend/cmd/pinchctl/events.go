@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+
+	"go.uber.org/dig"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+)
+
+var eventsCommand = &command{
+	usage: "publish the domain events of the outbox",
+	subcommands: map[string]subcommand{
+		"relay": relayEvents,
+	},
+}
+
+type eventRelay struct {
+	Relayed int `json:"relayed"`
+}
+
+func relayEvents(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	return c.Invoke(func(relay interfaces.EventRelay) error {
+		relayed, err := relay.RelayOnce(ctx)
+		if err != nil {
+			return err
+		}
+		return out.Print(&eventRelay{Relayed: relayed}, []string{"RELAYED"}, [][]string{{str(relayed)}})
+	})
+}
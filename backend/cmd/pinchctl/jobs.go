@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"time"
+
+	"go.uber.org/dig"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+var jobsCommand = &command{
+	usage: "inspect and requeue the dead letters of the job queues",
+	subcommands: map[string]subcommand{
+		"dead":    deadJobs,
+		"requeue": requeueJob,
+	},
+}
+
+func deadJobs(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	fs := flag.NewFlagSet("jobs dead", flag.ExitOnError)
+	queue := fs.String("queue", "", "queue of the jobs, e.g. invoices")
+	limit := fs.Int("limit", 20, "most jobs listed, newest first")
+	fs.Parse(args)
+	if *queue == "" {
+		return errors.New("-queue is required")
+	}
+
+	return c.Invoke(func(jobQueue interfaces.JobQueue) error {
+		dead, err := jobQueue.ListDead(ctx, *queue, *limit)
+		if err != nil {
+			return err
+		}
+		return printJobs(out, dead)
+	})
+}
+
+func requeueJob(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	fs := flag.NewFlagSet("jobs requeue", flag.ExitOnError)
+	queue := fs.String("queue", "", "queue of the job")
+	id := fs.String("id", "", "id of the dead job")
+	fs.Parse(args)
+	if *queue == "" || *id == "" {
+		return errors.New("-queue and -id are required")
+	}
+
+	return c.Invoke(func(jobQueue interfaces.JobQueue) error {
+		if err := jobQueue.Requeue(ctx, *queue, *id); err != nil {
+			return err
+		}
+		dead, err := jobQueue.ListDead(ctx, *queue, 0)
+		if err != nil {
+			return err
+		}
+		return printJobs(out, dead)
+	})
+}
+
+func printJobs(out *printer, list []*models.Job) error {
+	rows := make([][]string, 0, len(list))
+	for _, j := range list {
+		rows = append(rows, []string{
+			j.ID, j.Type, str(j.Attempts), j.RunAt.Format(time.RFC3339), j.LastError,
+		})
+	}
+	return out.Print(list, []string{"ID", "TYPE", "ATTEMPTS", "RUN AT", "ERROR"}, rows)
+}
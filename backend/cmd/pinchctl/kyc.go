@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"go.uber.org/dig"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+var kycCommand = &command{
+	usage: "check and refresh augmont KYC status",
+	subcommands: map[string]subcommand{
+		"status":  kycStatus,
+		"refresh": refreshKyc,
+	},
+}
+
+func kycStatus(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	fs := flag.NewFlagSet("kyc status", flag.ExitOnError)
+	f := newAugmontUserFlags(fs)
+	fs.Parse(args)
+
+	return c.Invoke(func(
+		users interfaces.UserRepo,
+		augUsers interfaces.AugmontUserRepo,
+	) error {
+		user, err := f.find(ctx, users, augUsers)
+		if err != nil {
+			return err
+		}
+		return printAugmontUsers(out, []*models.AugmontUser{user})
+	})
+}
+
+// refreshKyc fetches the KYC status from augmont,
+// even if the stored status is not pending
+func refreshKyc(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	fs := flag.NewFlagSet("kyc refresh", flag.ExitOnError)
+	f := newAugmontUserFlags(fs)
+	fs.Parse(args)
+
+	return c.Invoke(func(
+		users interfaces.UserRepo,
+		augUsers interfaces.AugmontUserRepo,
+		gold interfaces.AugmontService,
+	) error {
+		user, err := f.find(ctx, users, augUsers)
+		if err != nil {
+			return err
+		}
+
+		// The service skips users whose status is already final,
+		// clear it on the copy to force the refresh
+		forced := *user
+		forced.KYCStatus = nil
+		err = gold.UpdateUserKycStatus(ctx, &forced)
+		if err != nil {
+			return err
+		}
+
+		user, err = augUsers.FindUser(ctx, &models.AugmontUser{ID: user.ID})
+		if err != nil {
+			return err
+		}
+		return printAugmontUsers(out, []*models.AugmontUser{user})
+	})
+}
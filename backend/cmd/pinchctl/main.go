@@ -0,0 +1,109 @@
+// pinchctl is the operations CLI for the pinch backend.
+//
+// It is built from the same dependency container as the api server,
+// so it reads the same env config and talks to the same database,
+// redis and augmont account.
+//
+//	pinchctl [-o table|json] <command> <subcommand> [flags]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+
+	"go.uber.org/dig"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/app"
+)
+
+// command is a top level pinchctl command with its own subcommands
+type command struct {
+	usage       string
+	subcommands map[string]subcommand
+}
+
+// subcommand runs with the remaining command line arguments
+type subcommand func(ctx context.Context, c *dig.Container, out *printer, args []string) error
+
+var commands = map[string]*command{
+	"users":         usersCommand,
+	"augmont-users": augmontUsersCommand,
+	"orders":        ordersCommand,
+	"products":      productsCommand,
+	"shipments":     shipmentsCommand,
+	"notifications": notificationsCommand,
+	"events":        eventsCommand,
+	"jobs":          jobsCommand,
+	"schedules":     schedulesCommand,
+	"kyc":           kycCommand,
+	"token":         tokenCommand,
+	"migrations":    migrationsCommand,
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: pinchctl [-o table|json] <command> <subcommand> [flags]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "commands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-14s %s\n", name, commands[name].usage)
+	}
+}
+
+func main() {
+	output := flag.String("o", "table", "output format, table or json")
+	flag.Usage = usage
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", args[0])
+		usage()
+		os.Exit(2)
+	}
+	run, ok := cmd.subcommands[args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown subcommand %q for %v, want one of %v\n",
+			args[1], args[0], subcommandNames(cmd))
+		os.Exit(2)
+	}
+
+	out, err := newPrinter(*output, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	// Cancel in-flight calls on interrupt
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err = run(ctx, app.BuildContainer(), out, args[2:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func subcommandNames(cmd *command) []string {
+	names := make([]string, 0, len(cmd.subcommands))
+	for name := range cmd.subcommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"go.uber.org/dig"
+	"gorm.io/gorm"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/repo"
+)
+
+var migrationsCommand = &command{
+	usage: "check and run database migrations",
+	subcommands: map[string]subcommand{
+		"status": migrationStatus,
+		"run":    runMigrations,
+	},
+}
+
+func printMigrationStatus(ctx context.Context, out *printer, db *gorm.DB) error {
+	status, err := repo.MigrationStatus(db.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, 0, len(status))
+	for _, s := range status {
+		rows = append(rows, []string{s.Table, str(s.Exists)})
+	}
+	return out.Print(status, []string{"TABLE", "EXISTS"}, rows)
+}
+
+func migrationStatus(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	return c.Invoke(func(db *gorm.DB) error {
+		return printMigrationStatus(ctx, out, db)
+	})
+}
+
+func runMigrations(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	return c.Invoke(func(db *gorm.DB) error {
+		if err := repo.Migrate(db.WithContext(ctx)); err != nil {
+			return err
+		}
+		return printMigrationStatus(ctx, out, db)
+	})
+}
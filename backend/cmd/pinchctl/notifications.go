@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+
+	"go.uber.org/dig"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+)
+
+var notificationsCommand = &command{
+	usage: "send the notifications waiting for quiet hours or a retry",
+	subcommands: map[string]subcommand{
+		"dispatch": dispatchNotifications,
+	},
+}
+
+type notificationDispatch struct {
+	Sent int `json:"sent"`
+}
+
+func dispatchNotifications(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	return c.Invoke(func(notifications interfaces.NotificationService) error {
+		sent, err := notifications.Dispatch(ctx)
+		if err != nil {
+			return err
+		}
+		return out.Print(&notificationDispatch{Sent: sent}, []string{"SENT"}, [][]string{{str(sent)}})
+	})
+}
@@ -0,0 +1,448 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"go.uber.org/dig"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+var ordersCommand = &command{
+	usage: "list, inspect, place and rerun augmont orders",
+	subcommands: map[string]subcommand{
+		"list":     listOrders,
+		"info":     orderInfo,
+		"place":    placeOrder,
+		"rerun":    rerunOrder,
+		"invoices": storeInvoices,
+		"backfill": backfillOrders,
+	},
+}
+
+// orderRow is the common shape of buy, sell and redeem orders
+type orderRow struct {
+	ID            *uint64 `json:"id"`
+	Type          string  `json:"type"`
+	MerchantTxnID *string `json:"merchantTxnID"`
+	AugmontUserID *uint64 `json:"goldUserID"`
+	Status        *string `json:"status"`
+	MetalType     *string `json:"metalType"`
+	CreatedAt     string  `json:"createdAt"`
+}
+
+func validOrderType(orderType string) error {
+	switch orderType {
+	case "buy", "sell", "redeem":
+		return nil
+	}
+	return fmt.Errorf("unknown order type %q, want buy, sell or redeem", orderType)
+}
+
+// listOrders lists a page of the orders of a user, or of every user if
+// none is given, newest first
+func listOrders(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	fs := flag.NewFlagSet("orders list", flag.ExitOnError)
+	orderType := fs.String("type", "buy", "order type, buy, sell or redeem")
+	status := fs.String("status", "", "filter by status, e.g. failed")
+	limit := fs.Int("limit", utils.MaxPageLimit, "orders per page")
+	offset := fs.Int("offset", 0, "orders to skip")
+	f := newAugmontUserFlags(fs)
+	fs.Parse(args)
+	if err := validOrderType(*orderType); err != nil {
+		return err
+	}
+
+	q := &utils.ListQuery{Limit: *limit, Offset: *offset}
+	if err := q.Normalize(); err != nil {
+		return err
+	}
+	q.Where("type", utils.FilterEq, *orderType)
+	if *status != "" {
+		q.Where("status", utils.FilterEq, *status)
+	}
+
+	return c.Invoke(func(
+		users interfaces.UserRepo,
+		augUsers interfaces.AugmontUserRepo,
+		orders interfaces.AugmontOrderRepo,
+	) error {
+		var (
+			found []*models.AugmontOrder
+			page  *utils.Page
+			err   error
+		)
+		if f.none() {
+			found, page, err = orders.ListAllOrders(ctx, q)
+		} else {
+			user, findErr := f.find(ctx, users, augUsers)
+			if findErr != nil {
+				return findErr
+			}
+			found, page, err = orders.ListOrders(ctx, *user.ID, q)
+		}
+		if err != nil {
+			return err
+		}
+
+		rows := make([]*orderRow, 0, len(found))
+		table := make([][]string, 0, len(found))
+		for _, o := range found {
+			r := &orderRow{o.ID, str(o.Type), o.MerchantTxnID, o.AugmontUserID, o.Status, o.MetalType, str(o.CreatedAt)}
+			rows = append(rows, r)
+			table = append(table, []string{
+				str(r.ID), r.Type, str(r.MerchantTxnID), str(r.AugmontUserID), str(r.Status), str(r.MetalType), r.CreatedAt,
+			})
+		}
+		if err := out.Print(rows, []string{"ID", "TYPE", "TXN ID", "GOLD USER ID", "STATUS", "METAL", "CREATED AT"}, table); err != nil {
+			return err
+		}
+		if page.NextCursor != "" {
+			fmt.Fprintf(os.Stderr, "%d of %d orders, next page -offset %d\n",
+				len(found), page.Total, page.Offset+len(found))
+		}
+		return nil
+	})
+}
+
+// orderInfo fetches the order details from augmont
+func orderInfo(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	fs := flag.NewFlagSet("orders info", flag.ExitOnError)
+	orderType := fs.String("type", "buy", "order type, buy, sell or redeem")
+	txnID := fs.String("txn", "", "merchant transaction id")
+	f := newAugmontUserFlags(fs)
+	fs.Parse(args)
+	if err := validOrderType(*orderType); err != nil {
+		return err
+	}
+	if *txnID == "" {
+		return errors.New("-txn is required")
+	}
+
+	return c.Invoke(func(
+		users interfaces.UserRepo,
+		augUsers interfaces.AugmontUserRepo,
+		gold interfaces.AugmontService,
+	) error {
+		user, err := f.find(ctx, users, augUsers)
+		if err != nil {
+			return err
+		}
+
+		var info utils.Any
+		switch *orderType {
+		case "buy":
+			info, err = gold.BuyInfo(ctx, *user.UID, *txnID)
+		case "sell":
+			info, err = gold.SellInfo(ctx, *user.UID, *txnID)
+		case "redeem":
+			info, err = gold.RedeemInfo(ctx, *user.UID, *txnID)
+		}
+		if err != nil {
+			return err
+		}
+		return printResult(out, info)
+	})
+}
+
+// placeOrder places a new buy or sell order for the user, with a new
+// merchant transaction id, e.g. in place of one that failed, the lock
+// price and block id must come from a fresh rate quote
+func placeOrder(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	fs := flag.NewFlagSet("orders place", flag.ExitOnError)
+	orderType := fs.String("type", "buy", "order type, buy or sell")
+	metal := fs.String("metal", string(utils.Gold), "metal type, gold or silver")
+	quantity := fs.String("quantity", "", "quantity in grams")
+	amount := fs.String("amount", "", "amount in rupees")
+	lockPrice := fs.String("lock-price", "", "locked rate")
+	blockID := fs.String("block-id", "", "augmont rate block id")
+	bankID := fs.String("bank-id", "", "augmont user bank id, for sell orders")
+	f := newAugmontUserFlags(fs)
+	fs.Parse(args)
+
+	if *orderType != "buy" && *orderType != "sell" {
+		return fmt.Errorf("only buy and sell orders can be placed, got %q", *orderType)
+	}
+	if *lockPrice == "" || *blockID == "" {
+		return errors.New("-lock-price and -block-id are required")
+	}
+	if *quantity == "" && *amount == "" {
+		return errors.New("one of -quantity or -amount is required")
+	}
+
+	return c.Invoke(func(
+		users interfaces.UserRepo,
+		augUsers interfaces.AugmontUserRepo,
+		gold interfaces.AugmontService,
+	) error {
+		user, err := f.find(ctx, users, augUsers)
+		if err != nil {
+			return err
+		}
+
+		var result utils.Any
+		if *orderType == "buy" {
+			result, err = gold.Buy(ctx, user, &utils.AugmontBugInfo{
+				LockPrice: *lockPrice,
+				MetalType: *metal,
+				Quantity:  *quantity,
+				Amount:    *amount,
+				BlockID:   *blockID,
+			})
+		} else {
+			result, err = gold.Sell(ctx, user, &utils.AugmontSellInfo{
+				LockPrice:  *lockPrice,
+				MetalType:  *metal,
+				Quantity:   *quantity,
+				Amount:     *amount,
+				BlockID:    *blockID,
+				UserBankID: *bankID,
+			})
+		}
+		if err != nil {
+			return err
+		}
+		return printResult(out, result)
+	})
+}
+
+// rerunOrder places a failed buy or sell again, with its metal and
+// quantity, or its amount if it was placed by amount, at a fresh rate
+func rerunOrder(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	fs := flag.NewFlagSet("orders rerun", flag.ExitOnError)
+	txnID := fs.String("txn", "", "merchant transaction id of the failed order")
+	bankID := fs.String("bank-id", "", "augmont user bank id, for sell orders")
+	f := newAugmontUserFlags(fs)
+	fs.Parse(args)
+	if *txnID == "" {
+		return errors.New("-txn is required")
+	}
+
+	return c.Invoke(func(
+		users interfaces.UserRepo,
+		augUsers interfaces.AugmontUserRepo,
+		orders interfaces.AugmontOrderRepo,
+		gold interfaces.AugmontService,
+	) error {
+		user, err := f.find(ctx, users, augUsers)
+		if err != nil {
+			return err
+		}
+		order, err := orders.FindOrder(ctx, *user.ID, *txnID)
+		if err != nil {
+			return err
+		}
+		if *order.Type == models.OrderSell && *bankID == "" {
+			return errors.New("-bank-id is required to rerun a sell")
+		}
+		if order.Status == nil || *order.Status != models.OrderFailed {
+			return fmt.Errorf("order %v is %v, only failed orders can be rerun", *txnID, str(order.Status))
+		}
+		if order.MetalType == nil || (order.Quantity == nil && order.Amount == nil) {
+			return fmt.Errorf("order %v has no metal or quantity to rerun", *txnID)
+		}
+
+		rates, err := gold.Rates(ctx)
+		if err != nil {
+			return err
+		}
+		rate := rates.Rate(utils.Metal(*order.MetalType))
+		if rate == nil {
+			return fmt.Errorf("augmont quoted no %v rate", *order.MetalType)
+		}
+		// Placed by quantity unless only the amount was requested
+		quantity, amount := str(order.Quantity), ""
+		if order.Quantity == nil {
+			quantity, amount = "", *order.Amount
+		}
+
+		// Marked first, so the order is not rerun twice, a rerun refused
+		// by Augmont is saved as a new failed order, one that did not get
+		// an answer is checked with orders info
+		rerun, err := orders.RerunOrder(ctx, order)
+		if err != nil {
+			return err
+		}
+		if !rerun {
+			return fmt.Errorf("order %v is already rerun", *txnID)
+		}
+
+		var result utils.Any
+		if *order.Type == models.OrderBuy {
+			result, err = gold.Buy(ctx, user, &utils.AugmontBugInfo{
+				LockPrice: rate.Buy.String(),
+				MetalType: *order.MetalType,
+				Quantity:  quantity,
+				Amount:    amount,
+				BlockID:   rates.BlockID,
+			})
+		} else {
+			result, err = gold.Sell(ctx, user, &utils.AugmontSellInfo{
+				LockPrice:  rate.Sell.String(),
+				MetalType:  *order.MetalType,
+				Quantity:   quantity,
+				Amount:     amount,
+				BlockID:    rates.BlockID,
+				UserBankID: *bankID,
+			})
+		}
+		if err != nil {
+			return err
+		}
+		return printResult(out, result)
+	})
+}
+
+// invoiceRow is the result of storing the invoice of an order
+type invoiceRow struct {
+	MerchantTxnID string `json:"merchantTxnID"`
+	Type          string `json:"type"`
+	InvoiceNumber string `json:"invoiceNumber"`
+	Error         string `json:"error,omitempty"`
+}
+
+// storeInvoices stores the missing invoices of the completed buy and
+// redeem orders, of one user or of every user if none is given
+func storeInvoices(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	fs := flag.NewFlagSet("orders invoices", flag.ExitOnError)
+	f := newAugmontUserFlags(fs)
+	fs.Parse(args)
+
+	return c.Invoke(func(
+		users interfaces.UserRepo,
+		augUsers interfaces.AugmontUserRepo,
+		orders interfaces.AugmontOrderRepo,
+		invoices interfaces.AugmontInvoiceService,
+	) error {
+		var rows []*invoiceRow
+		err := f.each(ctx, users, augUsers, func(user *models.AugmontUser) error {
+			found, err := orders.FindOrdersBetween(ctx, *user.ID, time.Time{}, time.Now())
+			if err != nil {
+				return err
+			}
+			// Buys and redeems have invoices, the metals of a redeem share one
+			seen := map[string]bool{}
+			for _, o := range found {
+				invoiced := *o.Type == models.OrderBuy || *o.Type == models.OrderRedeem
+				if !invoiced || seen[*o.MerchantTxnID] || o.Status == nil || *o.Status != models.OrderCompleted {
+					continue
+				}
+				seen[*o.MerchantTxnID] = true
+				row := &invoiceRow{MerchantTxnID: *o.MerchantTxnID, Type: *o.Type}
+				// Keep going, the failed orders are listed with their error
+				invoice, err := invoices.Fetch(ctx, user, *o.MerchantTxnID)
+				if err != nil {
+					if ctx.Err() != nil {
+						return ctx.Err()
+					}
+					row.Error = err.Error()
+				} else {
+					row.InvoiceNumber = str(invoice.InvoiceNumber)
+				}
+				rows = append(rows, row)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		table := make([][]string, 0, len(rows))
+		for _, r := range rows {
+			table = append(table, []string{r.MerchantTxnID, r.Type, r.InvoiceNumber, r.Error})
+		}
+		return out.Print(rows, []string{"TXN ID", "TYPE", "INVOICE", "ERROR"}, table)
+	})
+}
+
+// backfillRow is an order filled from its augmont detail
+type backfillRow struct {
+	MerchantTxnID string  `json:"merchantTxnID"`
+	Type          string  `json:"type"`
+	MetalType     *string `json:"metalType"`
+	Quantity      *string `json:"quantity"`
+	Amount        *string `json:"amount"`
+	Rate          *string `json:"rate"`
+	Error         string  `json:"error,omitempty"`
+}
+
+// backfillOrders fills the metal, quantity, amount and rate of the
+// orders stored before the orders kept them, of one user or of every
+// user if none is given
+func backfillOrders(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	fs := flag.NewFlagSet("orders backfill", flag.ExitOnError)
+	f := newAugmontUserFlags(fs)
+	fs.Parse(args)
+
+	return c.Invoke(func(
+		users interfaces.UserRepo,
+		augUsers interfaces.AugmontUserRepo,
+		orders interfaces.AugmontOrderRepo,
+		orderService interfaces.AugmontOrderService,
+	) error {
+		var rows []*backfillRow
+		err := f.each(ctx, users, augUsers, func(user *models.AugmontUser) error {
+			found, err := orders.FindIncompleteOrders(ctx, *user.ID)
+			if err != nil {
+				return err
+			}
+			for _, o := range found {
+				// Keep going, the failed orders are listed with their error
+				if err := orderService.Backfill(ctx, user, o); err != nil {
+					if ctx.Err() != nil {
+						return ctx.Err()
+					}
+					rows = append(rows, &backfillRow{MerchantTxnID: *o.MerchantTxnID, Type: *o.Type, Error: err.Error()})
+					continue
+				}
+				rows = append(rows, &backfillRow{
+					MerchantTxnID: *o.MerchantTxnID,
+					Type:          *o.Type,
+					MetalType:     o.MetalType,
+					Quantity:      o.Quantity,
+					Amount:        o.Amount,
+					Rate:          o.Rate,
+				})
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		table := make([][]string, 0, len(rows))
+		for _, r := range rows {
+			table = append(table, []string{
+				r.MerchantTxnID, r.Type, str(r.MetalType), str(r.Quantity), str(r.Amount), str(r.Rate), r.Error,
+			})
+		}
+		return out.Print(rows, []string{"TXN ID", "TYPE", "METAL", "QUANTITY", "AMOUNT", "RATE", "ERROR"}, table)
+	})
+}
+
+// printResult prints raw augmont results,
+// they have no fixed shape so the table is key value pairs
+func printResult(out *printer, result utils.Any) error {
+	var rows [][]string
+	if dict, ok := result.(map[string]interface{}); ok {
+		keys := make([]string, 0, len(dict))
+		for k := range dict {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			rows = append(rows, []string{k, fmt.Sprint(dict[k])})
+		}
+	} else {
+		rows = append(rows, []string{"result", fmt.Sprint(result)})
+	}
+	return out.Print(result, []string{"KEY", "VALUE"}, rows)
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/dig"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+type fakeAugmontUsers struct {
+	interfaces.AugmontUserRepo
+	user *models.AugmontUser
+	all  []*models.AugmontUser
+}
+
+func (r *fakeAugmontUsers) FindUser(ctx context.Context, user *models.AugmontUser) (*models.AugmontUser, error) {
+	return r.user, nil
+}
+
+func (r *fakeAugmontUsers) ListUsers(ctx context.Context, q *utils.ListQuery) ([]*models.AugmontUser, *utils.Page, error) {
+	users := r.all[q.Offset:]
+	if len(users) > q.Limit {
+		users = users[:q.Limit]
+	}
+	return users, q.NewPage(int64(len(r.all)), len(users)), nil
+}
+
+type fakeOrders struct {
+	interfaces.AugmontOrderRepo
+	order *models.AugmontOrder
+	// listed records the user of the orders listed, 0 for every user
+	listed []uint64
+}
+
+func (r *fakeOrders) ListOrders(ctx context.Context, augmontUserID uint64, q *utils.ListQuery) ([]*models.AugmontOrder, *utils.Page, error) {
+	r.listed = append(r.listed, augmontUserID)
+	return []*models.AugmontOrder{r.order}, q.NewPage(1, 1), nil
+}
+
+func (r *fakeOrders) ListAllOrders(ctx context.Context, q *utils.ListQuery) ([]*models.AugmontOrder, *utils.Page, error) {
+	return r.ListOrders(ctx, 0, q)
+}
+
+func (r *fakeOrders) FindOrder(ctx context.Context, augmontUserID uint64, txnID string) (*models.AugmontOrder, error) {
+	return r.order, nil
+}
+
+func (r *fakeOrders) RerunOrder(ctx context.Context, order *models.AugmontOrder) (bool, error) {
+	if *order.Status != models.OrderFailed {
+		return false, nil
+	}
+	status := models.OrderRerun
+	order.Status = &status
+	return true, nil
+}
+
+// fakeGold quotes gold and records the orders placed
+type fakeGold struct {
+	interfaces.AugmontService
+	bought []*utils.AugmontBugInfo
+	sold   []*utils.AugmontSellInfo
+}
+
+func (s *fakeGold) Rates(ctx context.Context) (*utils.MetalRates, error) {
+	return &utils.MetalRates{BlockID: "B2", Rates: []*utils.MetalRate{{
+		Metal: utils.Gold,
+		Buy:   decimal.RequireFromString("5200.5"),
+		Sell:  decimal.RequireFromString("5000"),
+	}}}, nil
+}
+
+func (s *fakeGold) Buy(ctx context.Context, user *models.AugmontUser, info *utils.AugmontBugInfo) (utils.Any, error) {
+	s.bought = append(s.bought, info)
+	return map[string]interface{}{"merchantTransactionId": "T2"}, nil
+}
+
+func (s *fakeGold) Sell(ctx context.Context, user *models.AugmontUser, info *utils.AugmontSellInfo) (utils.Any, error) {
+	s.sold = append(s.sold, info)
+	return map[string]interface{}{"merchantTransactionId": "T2"}, nil
+}
+
+// ordersContainer provides the fakes to the commands
+func ordersContainer(t *testing.T, orders *fakeOrders, gold *fakeGold) *dig.Container {
+	id, uid := uint64(2), "U2"
+	c := dig.New()
+	for _, provide := range []interface{}{
+		func() interfaces.UserRepo { return nil },
+		func() interfaces.AugmontUserRepo {
+			return &fakeAugmontUsers{user: &models.AugmontUser{ID: &id, UID: &uid}}
+		},
+		func() interfaces.AugmontOrderRepo { return orders },
+		func() interfaces.AugmontService { return gold },
+	} {
+		assert.NoError(t, c.Provide(provide))
+	}
+	return c
+}
+
+func failedOrder(orderType, status, quantity, amount string) *models.AugmontOrder {
+	id, metal := uint64(7), "gold"
+	order := &models.AugmontOrder{ID: &id, Type: &orderType, MerchantTxnID: strPtr("T1")}
+	order.Status, order.MetalType = &status, &metal
+	if quantity != "" {
+		order.Quantity = &quantity
+	}
+	if amount != "" {
+		order.Amount = &amount
+	}
+	return order
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestRerunOrder(t *testing.T) {
+	ctx := context.Background()
+	rerun := func(orders *fakeOrders, gold *fakeGold, args ...string) error {
+		out, _ := newPrinter("json", &bytes.Buffer{})
+		args = append([]string{"-user-id", "2", "-txn", "T1"}, args...)
+		return rerunOrder(ctx, ordersContainer(t, orders, gold), out, args)
+	}
+
+	t.Run("should rerun a failed buy at a fresh rate", func(t *testing.T) {
+		orders := &fakeOrders{order: failedOrder(models.OrderBuy, models.OrderFailed, "", "500")}
+		gold := &fakeGold{}
+		assert.NoError(t, rerun(orders, gold))
+		if assert.Len(t, gold.bought, 1) {
+			assert.Equal(t, &utils.AugmontBugInfo{
+				LockPrice: "5200.5",
+				MetalType: "gold",
+				Amount:    "500",
+				BlockID:   "B2",
+			}, gold.bought[0])
+		}
+		assert.Equal(t, models.OrderRerun, *orders.order.Status)
+	})
+
+	t.Run("should rerun a failed sell by quantity to the bank", func(t *testing.T) {
+		orders := &fakeOrders{order: failedOrder(models.OrderSell, models.OrderFailed, "1.5000", "7500")}
+		gold := &fakeGold{}
+		assert.NoError(t, rerun(orders, gold, "-bank-id", "K1"))
+		if assert.Len(t, gold.sold, 1) {
+			assert.Equal(t, "5000", gold.sold[0].LockPrice)
+			assert.Equal(t, "1.5000", gold.sold[0].Quantity)
+			assert.Empty(t, gold.sold[0].Amount)
+			assert.Equal(t, "K1", gold.sold[0].UserBankID)
+		}
+	})
+
+	t.Run("should require the bank of a sell", func(t *testing.T) {
+		gold := &fakeGold{}
+		err := rerun(&fakeOrders{order: failedOrder(models.OrderSell, models.OrderFailed, "1", "")}, gold)
+		assert.EqualError(t, err, "-bank-id is required to rerun a sell")
+		assert.Empty(t, gold.sold)
+	})
+
+	t.Run("should not rerun orders that did not fail", func(t *testing.T) {
+		gold := &fakeGold{}
+		err := rerun(&fakeOrders{order: failedOrder(models.OrderBuy, models.OrderCompleted, "1", "")}, gold)
+		assert.EqualError(t, err, "order T1 is completed, only failed orders can be rerun")
+		assert.Empty(t, gold.bought)
+	})
+
+	t.Run("should rerun an order once", func(t *testing.T) {
+		orders := &fakeOrders{order: failedOrder(models.OrderBuy, models.OrderFailed, "1", "")}
+		gold := &fakeGold{}
+		assert.NoError(t, rerun(orders, gold))
+		assert.Error(t, rerun(orders, gold))
+		assert.Len(t, gold.bought, 1)
+	})
+}
+
+func TestListOrders(t *testing.T) {
+	ctx := context.Background()
+	list := func(orders *fakeOrders, args ...string) string {
+		var buf bytes.Buffer
+		out, _ := newPrinter("table", &buf)
+		assert.NoError(t, listOrders(ctx, ordersContainer(t, orders, &fakeGold{}), out, args))
+		return buf.String()
+	}
+
+	t.Run("should list the orders of the user", func(t *testing.T) {
+		orders := &fakeOrders{order: failedOrder(models.OrderBuy, models.OrderFailed, "1", "")}
+		assert.Contains(t, list(orders, "-user-id", "2"), "failed")
+		assert.Equal(t, []uint64{2}, orders.listed)
+	})
+
+	t.Run("should list the orders of every user when none is given", func(t *testing.T) {
+		orders := &fakeOrders{order: failedOrder(models.OrderSell, models.OrderFailed, "1", "")}
+		list(orders, "-type", "sell", "-status", "failed")
+		assert.Equal(t, []uint64{0}, orders.listed)
+	})
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+// printer writes command results as a table or as json
+type printer struct {
+	format string
+	w      io.Writer
+}
+
+func newPrinter(format string, w io.Writer) (*printer, error) {
+	if format != "table" && format != "json" {
+		return nil, fmt.Errorf("unknown output format %q, want table or json", format)
+	}
+	return &printer{format: format, w: w}, nil
+}
+
+// Print writes v as json, or the header and rows as an aligned table
+func (p *printer) Print(v utils.Any, header []string, rows [][]string) error {
+	if p.format == "json" {
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// str formats optional model fields for table cells
+func str(v interface{}) string {
+	switch v := v.(type) {
+	case *string:
+		if v == nil {
+			return "-"
+		}
+		return *v
+	case *uint64:
+		if v == nil {
+			return "-"
+		}
+		return fmt.Sprint(*v)
+	case *time.Time:
+		if v == nil {
+			return "-"
+		}
+		return v.Format(time.RFC3339)
+	}
+	return fmt.Sprint(v)
+}
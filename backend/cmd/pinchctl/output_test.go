@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrinter(t *testing.T) {
+	rows := []map[string]string{{"id": "1", "name": "gold"}}
+	table := [][]string{{"1", "gold"}}
+
+	t.Run("should align the table", func(t *testing.T) {
+		var buf bytes.Buffer
+		out, err := newPrinter("table", &buf)
+		assert.NoError(t, err)
+		assert.NoError(t, out.Print(rows, []string{"ID", "METAL NAME"}, table))
+		assert.Equal(t, "ID  METAL NAME\n1   gold\n", buf.String())
+	})
+
+	t.Run("should print the values as json", func(t *testing.T) {
+		var buf bytes.Buffer
+		out, err := newPrinter("json", &buf)
+		assert.NoError(t, err)
+		assert.NoError(t, out.Print(rows, []string{"ID", "METAL NAME"}, table))
+		assert.JSONEq(t, `[{"id": "1", "name": "gold"}]`, buf.String())
+	})
+
+	t.Run("should refuse unknown formats", func(t *testing.T) {
+		_, err := newPrinter("yaml", &bytes.Buffer{})
+		assert.Error(t, err)
+	})
+}
+
+func TestStr(t *testing.T) {
+	var missing *string
+	id := uint64(4)
+	at := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, "-", str(missing))
+	assert.Equal(t, "4", str(&id))
+	assert.Equal(t, "2024-03-01T10:00:00Z", str(&at))
+	assert.Equal(t, "buy", str("buy"))
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+
+	"go.uber.org/dig"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+)
+
+var productsCommand = &command{
+	usage: "sync the redeem product catalogue from augmont",
+	subcommands: map[string]subcommand{
+		"sync": syncProducts,
+	},
+}
+
+type productSync struct {
+	Synced  int   `json:"synced"`
+	Dropped int64 `json:"dropped"`
+}
+
+func syncProducts(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	return c.Invoke(func(products interfaces.AugmontProductService) error {
+		synced, dropped, err := products.Sync(ctx)
+		if err != nil {
+			return err
+		}
+		result := &productSync{Synced: synced, Dropped: dropped}
+		return out.Print(result, []string{"SYNCED", "DROPPED"}, [][]string{{str(synced), str(dropped)}})
+	})
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"go.uber.org/dig"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+var schedulesCommand = &command{
+	usage: "inspect the scheduled tasks and their runs, or run one now",
+	subcommands: map[string]subcommand{
+		"list": listSchedules,
+		"runs": listScheduleRuns,
+		"run":  runSchedule,
+	},
+}
+
+func listSchedules(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	return c.Invoke(func(scheduler interfaces.SchedulerService) error {
+		schedules, leader, err := scheduler.List(ctx)
+		if err != nil {
+			return err
+		}
+		rows := make([][]string, 0, len(schedules))
+		for _, s := range schedules {
+			rows = append(rows, []string{
+				str(s.Name), str(s.Spec), str(s.LastRunAt), str(s.LastOutcome), str(s.NextRunAt),
+			})
+		}
+		if err := out.Print(schedules, []string{"NAME", "SPEC", "LAST RUN", "OUTCOME", "NEXT RUN"}, rows); err != nil {
+			return err
+		}
+		if leader == "" {
+			leader = "none"
+		}
+		fmt.Fprintf(os.Stderr, "leader %v\n", leader)
+		return nil
+	})
+}
+
+func listScheduleRuns(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	fs := flag.NewFlagSet("schedules runs", flag.ExitOnError)
+	name := fs.String("name", "", "name of the task, all tasks if empty")
+	outcome := fs.String("outcome", "", "filter by outcome, running, succeeded or failed")
+	limit := fs.Int("limit", utils.DefaultPageLimit, "runs listed, newest first")
+	fs.Parse(args)
+
+	q := &utils.ListQuery{Limit: *limit}
+	if err := q.Normalize(); err != nil {
+		return err
+	}
+	if *outcome != "" {
+		q.Where("outcome", utils.FilterEq, *outcome)
+	}
+
+	return c.Invoke(func(scheduler interfaces.SchedulerService) error {
+		runs, _, err := scheduler.ListRuns(ctx, *name, q)
+		if err != nil {
+			return err
+		}
+		return printScheduleRuns(out, runs)
+	})
+}
+
+func runSchedule(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	fs := flag.NewFlagSet("schedules run", flag.ExitOnError)
+	name := fs.String("name", "", "name of the task")
+	fs.Parse(args)
+	if *name == "" {
+		return errors.New("-name is required")
+	}
+
+	return c.Invoke(func(scheduler interfaces.SchedulerService) error {
+		run, err := scheduler.Run(ctx, *name)
+		if err != nil {
+			return err
+		}
+		return printScheduleRuns(out, []*models.ScheduleRun{run})
+	})
+}
+
+func printScheduleRuns(out *printer, runs []*models.ScheduleRun) error {
+	rows := make([][]string, 0, len(runs))
+	for _, r := range runs {
+		rows = append(rows, []string{
+			str(r.ID), str(r.Name), str(r.Host), str(r.StartedAt), str(r.FinishedAt), str(r.Outcome), str(r.Error),
+		})
+	}
+	return out.Print(runs, []string{"ID", "NAME", "HOST", "STARTED AT", "FINISHED AT", "OUTCOME", "ERROR"}, rows)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+
+	"go.uber.org/dig"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+)
+
+var shipmentsCommand = &command{
+	usage: "track the shipments of redeem orders",
+	subcommands: map[string]subcommand{
+		"poll": pollShipments,
+	},
+}
+
+type shipmentPoll struct {
+	Moved int `json:"moved"`
+}
+
+func pollShipments(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	return c.Invoke(func(shipments interfaces.AugmontShipmentService) error {
+		moved, err := shipments.Poll(ctx)
+		if err != nil {
+			return err
+		}
+		return out.Print(&shipmentPoll{Moved: moved}, []string{"MOVED"}, [][]string{{str(moved)}})
+	})
+}
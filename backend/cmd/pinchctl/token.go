@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/dig"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+)
+
+var tokenCommand = &command{
+	usage: "inspect and rotate the augmont auth token",
+	subcommands: map[string]subcommand{
+		"status": tokenStatus,
+		"rotate": rotateToken,
+	},
+}
+
+type tokenInfo struct {
+	Stored    bool      `json:"stored"`
+	ExpiresIn string    `json:"expiresIn"`
+	ExpireAt  time.Time `json:"expireAt,omitempty"`
+}
+
+func printToken(out *printer, ttl time.Duration) error {
+	info := &tokenInfo{
+		Stored:    ttl > 0,
+		ExpiresIn: ttl.Round(time.Second).String(),
+	}
+	if info.Stored {
+		info.ExpireAt = time.Now().Add(ttl).Round(time.Second)
+	}
+	row := []string{str(info.Stored), info.ExpiresIn, "-"}
+	if info.Stored {
+		row[2] = info.ExpireAt.Format(time.RFC3339)
+	}
+	return out.Print(info, []string{"STORED", "EXPIRES IN", "EXPIRE AT"}, [][]string{row})
+}
+
+func tokenStatus(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	return c.Invoke(func(inMem interfaces.AugmontInMemRepo) error {
+		ttl, err := inMem.TokenTTL(ctx)
+		if err != nil {
+			return err
+		}
+		return printToken(out, ttl)
+	})
+}
+
+func rotateToken(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	return c.Invoke(func(
+		auth interfaces.AugmontAuthService,
+		inMem interfaces.AugmontInMemRepo,
+	) error {
+		_, err := auth.RotateToken(ctx)
+		if err != nil {
+			return err
+		}
+		ttl, err := inMem.TokenTTL(ctx)
+		if err != nil {
+			return err
+		}
+		return printToken(out, ttl)
+	})
+}
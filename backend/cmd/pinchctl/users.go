@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+
+	"go.uber.org/dig"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+var usersCommand = &command{
+	usage: "list and look up pinch users",
+	subcommands: map[string]subcommand{
+		"list": listUsers,
+		"get":  getUser,
+	},
+}
+
+func printUsers(out *printer, users []*models.User) error {
+	rows := make([][]string, 0, len(users))
+	for _, u := range users {
+		rows = append(rows, []string{str(u.ID), str(u.Mobile), str(u.Name)})
+	}
+	return out.Print(users, []string{"ID", "MOBILE", "NAME"}, rows)
+}
+
+func listUsers(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	return c.Invoke(func(repo interfaces.UserRepo) error {
+		users, err := repo.FindMany(ctx, nil)
+		if err != nil {
+			return err
+		}
+		return printUsers(out, users)
+	})
+}
+
+func getUser(ctx context.Context, c *dig.Container, out *printer, args []string) error {
+	fs := flag.NewFlagSet("users get", flag.ExitOnError)
+	id := fs.Uint64("id", 0, "pinch user id")
+	mobile := fs.String("mobile", "", "pinch user mobile number")
+	fs.Parse(args)
+
+	query := &models.User{}
+	switch {
+	case *id != 0:
+		query.ID = id
+	case *mobile != "":
+		query.Mobile = mobile
+	default:
+		return errors.New("one of -id or -mobile is required")
+	}
+
+	return c.Invoke(func(repo interfaces.UserRepo) error {
+		user, err := repo.FindOne(ctx, query)
+		if err != nil {
+			return err
+		}
+		return printUsers(out, []*models.User{user})
+	})
+}
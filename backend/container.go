@@ -1,63 +1,30 @@
 package main
 
 import (
-	"log"
-
 	"go.uber.org/dig"
 
+	"github.com/EQUISEED-WEALTH/pinch/backend/app"
 	"github.com/EQUISEED-WEALTH/pinch/backend/controller"
-	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
-	"github.com/EQUISEED-WEALTH/pinch/backend/repo"
-	"github.com/EQUISEED-WEALTH/pinch/backend/service"
 )
 
-// Handle Build Error
-func handleBuildError(err error) {
-	if err != nil {
-		log.Fatal(err)
-	}
-}
-
-// Provide all build functions
-func provide(container *dig.Container, buildFuncs ...utils.Any) {
-	for _, buildFunc := range buildFuncs {
-		err := container.Provide(buildFunc)
-		handleBuildError(err)
-	}
-}
-
-// Invoke all build functions
-func invoke(container *dig.Container, buildFuncs ...utils.Any) {
-	for _, buildFunc := range buildFuncs {
-		err := container.Invoke(buildFunc)
-		handleBuildError(err)
-	}
-}
-
 // Build all dependencies
 func buildContainer() *dig.Container {
-	container := dig.New()
-
-	provide(container,
-		controller.BuildGinEngine,
-		repo.NewPgDB,
-		repo.NewRedisClient,
-
-		// Repositories
-		repo.NewUserRepo,
-		repo.NewAugmontUserRepo,
-		repo.NewAugmontOrderRepo,
-		repo.NewAugmontInMemRepo,
-
-		// Services
-		service.NewAugmondService,
-		service.NewUserService,
-	)
+	container := app.BuildContainer()
 
-	invoke(container,
+	app.Invoke(container,
 		// Controllers
 		controller.NewUserController,
 		controller.NewGoldController,
+		controller.NewStatementController,
+		controller.NewFilesController,
+		controller.NewTaxController,
+		controller.NewRedeemController,
+		controller.NewGiftController,
+		controller.NewNotificationController,
+		controller.NewScheduleController,
+		controller.NewHealthController,
+		controller.NewMetricsController,
+		controller.NewDocsController,
 	)
 
 	return container
@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/i18n"
+)
+
+// ErrorResponse is the error envelope of every failed request
+type ErrorResponse struct {
+	Status string    `json:"status"`
+	Error  ErrorBody `json:"error"`
+}
+
+type ErrorBody struct {
+	// Machine readable error code
+	Code string `json:"code"`
+	// User friendly message, safe to show in the app
+	Message   string `json:"message"`
+	RequestID string `json:"requestId,omitempty"`
+	// Per field validation errors
+	Fields []domain.FieldError `json:"fields,omitempty"`
+}
+
+// httpStatusFromType maps the domain error type to the HTTP status
+func httpStatusFromType(errType int) int {
+	switch errType {
+	case domain.ErrInvalidArgument:
+		return http.StatusUnprocessableEntity
+	case domain.ErrNotFound:
+		return http.StatusNotFound
+	case domain.ErrBadRequest:
+		return http.StatusBadRequest
+	case domain.ErrUnauthorized:
+		return http.StatusUnauthorized
+	case domain.ErrForbidden:
+		return http.StatusForbidden
+	case domain.ErrConflict:
+		return http.StatusConflict
+	case domain.ErrUnavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// toDomainError classifies errors that are not domain errors yet,
+// anything unknown is an internal error
+func toDomainError(err error) *domain.Error {
+	if e, ok := domain.AsError(err); ok {
+		return e
+	}
+
+	var errType int
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		errType = domain.ErrNotFound
+	default:
+		errType = domain.ErrInternalError
+	}
+	e, _ := domain.AsError(domain.NewError(err, errType))
+	return e
+}
+
+// bindError classifies errors of binding the request body
+func bindError(err error) error {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return validationError(err, validationErrs)
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+		return domain.NewError(err, domain.ErrBadRequest, "malformed request body")
+	}
+	return domain.NewError(err, domain.ErrBadRequest, "invalid request body")
+}
+
+// ErrorHandler writes the error response for the last error added
+// by the handler with ctx.Error, the internal error and its stack
+// are only logged, clients get the code and the user message
+func ErrorHandler(ctx *gin.Context) {
+	ctx.Next()
+
+	if len(ctx.Errors) == 0 {
+		return
+	}
+	err := ctx.Errors.Last().Err
+	e := toDomainError(err)
+	status := httpStatusFromType(e.Type())
+
+	entry := domain.Logger(ctx.Request.Context()).
+		WithField("code", e.Code()).
+		WithField("status", status)
+	if status >= http.StatusInternalServerError {
+		entry.WithField("stack", fmt.Sprintf("%+v", err)).Error(err.Error())
+	} else {
+		entry.Warn(err.Error())
+	}
+
+	// The handler already responded
+	if ctx.Writer.Written() {
+		return
+	}
+	msg, fields := translateError(i18n.Locale(ctx.Request.Context()), e)
+	ctx.AbortWithStatusJSON(status, ErrorResponse{
+		Status: "error",
+		Error: ErrorBody{
+			Code:      e.Code(),
+			Message:   msg,
+			RequestID: domain.RequestID(ctx.Request.Context()),
+			Fields:    fields,
+		},
+	})
+}
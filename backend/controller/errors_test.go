@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+)
+
+func serveError(err error) (*httptest.ResponseRecorder, *ErrorResponse) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ErrorHandler)
+	router.GET("/", func(ctx *gin.Context) {
+		ctx.Error(err)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	resp := &ErrorResponse{}
+	json.Unmarshal(w.Body.Bytes(), resp)
+	return w, resp
+}
+
+func TestErrorHandler(t *testing.T) {
+	t.Run("should map domain error types to http status", func(t *testing.T) {
+		cases := map[int]int{
+			domain.ErrInvalidArgument: http.StatusUnprocessableEntity,
+			domain.ErrNotFound:        http.StatusNotFound,
+			domain.ErrInternalError:   http.StatusInternalServerError,
+			domain.ErrBadRequest:      http.StatusBadRequest,
+			domain.ErrUnauthorized:    http.StatusUnauthorized,
+			domain.ErrConflict:        http.StatusConflict,
+		}
+		for errType, status := range cases {
+			w, resp := serveError(domain.NewError(errors.New("boom"), errType))
+			assert.Equal(t, status, w.Code)
+			assert.Equal(t, "error", resp.Status)
+		}
+	})
+
+	t.Run("should not leak internal error messages", func(t *testing.T) {
+		w, resp := serveError(errors.New("pq: connection refused"))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, "internal_error", resp.Error.Code)
+		assert.NotContains(t, w.Body.String(), "connection refused")
+	})
+
+	t.Run("should return the code and ui message", func(t *testing.T) {
+		_, resp := serveError(domain.NewError(errors.New("no rows"), domain.ErrNotFound))
+		assert.Equal(t, "not_found", resp.Error.Code)
+		assert.Equal(t, "Not found", resp.Error.Message)
+	})
+
+	t.Run("should map record not found to 404", func(t *testing.T) {
+		w, resp := serveError(gorm.ErrRecordNotFound)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "not_found", resp.Error.Code)
+	})
+}
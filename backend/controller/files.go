@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+type FilesController struct {
+	storage interfaces.FileStorage
+}
+
+// NewFilesController serves the downloads of signed URLs,
+// the signature is the only access check
+func NewFilesController(router *gin.Engine, storage interfaces.FileStorage) {
+	c := &FilesController{
+		storage: storage,
+	}
+	router.GET(utils.FilesPath+"*key", c.Download)
+}
+
+func (c *FilesController) Download(ctx *gin.Context) {
+	err := utils.VerifyPath(
+		domain.Config().SigningKey(),
+		ctx.Request.URL.Path,
+		ctx.Request.URL.Query(),
+		time.Now(),
+	)
+	if err != nil {
+		ctx.Error(domain.NewError(err, domain.ErrForbidden, "The download link is invalid or expired"))
+		return
+	}
+
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+	file, err := c.storage.Open(ctx.Request.Context(), key)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	defer file.Close()
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	ctx.DataFromReader(http.StatusOK, -1, contentType, file, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{
+			"filename": path.Base(key),
+		}),
+		"Cache-Control": "private, no-store",
+	})
+}
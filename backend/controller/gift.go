@@ -0,0 +1,190 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+type GiftController struct {
+	gifts       interfaces.AugmontGiftService
+	augmontUser interfaces.AugmontUserRepo
+}
+
+// NewGiftController creates the gifting and ledger endpoints
+func NewGiftController(router *gin.Engine, gifts interfaces.AugmontGiftService, au interfaces.AugmontUserRepo) {
+	c := &GiftController{
+		gifts:       gifts,
+		augmontUser: au,
+	}
+	router.GET("/gold/gifts/recipient", c.FindRecipient)
+	router.POST("/gold/gifts", c.Send)
+	router.GET("/gold/gifts", c.List)
+	router.POST("/gold/gifts/claim", c.Claim)
+	router.GET("/gold/ledger", c.Ledger)
+}
+
+// findUser returns the Augmont user of the request
+func (c *GiftController) findUser(ctx *gin.Context) (*models.AugmontUser, error) {
+	user, err := getPinchUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.augmontUser.FindUser(ctx.Request.Context(), &models.AugmontUser{
+		UserID: user.ID,
+	})
+}
+
+// giftRecipientQuery is the mobile number a gift is for
+type giftRecipientQuery struct {
+	MobileNo string `form:"mobileNumber" binding:"required,mobile"`
+}
+
+func (c *GiftController) FindRecipient(ctx *gin.Context) {
+	agUser, err := c.findUser(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	q := &giftRecipientQuery{}
+	if err := ctx.ShouldBindQuery(q); err != nil {
+		ctx.Error(bindError(err))
+		return
+	}
+
+	recipient, err := c.gifts.FindRecipient(ctx.Request.Context(), agUser, q.MobileNo)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"status":    "ok",
+		"recipient": recipient,
+	})
+}
+
+func (c *GiftController) Send(ctx *gin.Context) {
+	agUser, err := c.findUser(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	info := &utils.AugmontGiftInfo{}
+	if err := ctx.ShouldBindJSON(info); err != nil {
+		ctx.Error(bindError(err))
+		return
+	}
+
+	gift, err := c.gifts.Send(ctx.Request.Context(), agUser, info)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"status": "ok",
+		"gift":   gift,
+	})
+}
+
+// giftFilters are the filters of the gifts
+type giftFilters struct {
+	Status    string `form:"status" binding:"omitempty,oneof=sending failed completed escrowed claiming claimed"`
+	MetalType string `form:"metalType" binding:"omitempty,metal"`
+}
+
+func (c *GiftController) List(ctx *gin.Context) {
+	agUser, err := c.findUser(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	filters := &giftFilters{}
+	q, err := bindListQuery(ctx, filters)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	if filters.Status != "" {
+		q.Where("status", utils.FilterEq, filters.Status)
+	}
+	if filters.MetalType != "" {
+		q.Where("metalType", utils.FilterEq, filters.MetalType)
+	}
+
+	gifts, page, err := c.gifts.List(ctx.Request.Context(), agUser, q)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"status": "ok",
+		"gifts":  gifts,
+		"page":   page,
+	})
+}
+
+func (c *GiftController) Claim(ctx *gin.Context) {
+	agUser, err := c.findUser(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	gifts, err := c.gifts.Claim(ctx.Request.Context(), agUser)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"status": "ok",
+		"gifts":  gifts,
+	})
+}
+
+// ledgerFilters are the filters of the ledger
+type ledgerFilters struct {
+	Type      string `form:"type" binding:"omitempty,oneof=gift_sent gift_received"`
+	MetalType string `form:"metalType" binding:"omitempty,metal"`
+}
+
+func (c *GiftController) Ledger(ctx *gin.Context) {
+	agUser, err := c.findUser(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	filters := &ledgerFilters{}
+	q, err := bindListQuery(ctx, filters)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	if filters.Type != "" {
+		q.Where("type", utils.FilterEq, filters.Type)
+	}
+	if filters.MetalType != "" {
+		q.Where("metalType", utils.FilterEq, filters.MetalType)
+	}
+
+	entries, page, err := c.gifts.Ledger(ctx.Request.Context(), agUser, q)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"status":  "ok",
+		"entries": entries,
+		"page":    page,
+	})
+}
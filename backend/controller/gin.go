@@ -1,20 +1,23 @@
 package controller
 
 import (
+	"crypto/subtle"
+	"strings"
+
 	"github.com/cockroachdb/errors"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
 	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/i18n"
 	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
 	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
 )
 
 // Build Gin Engile with CORS
-func BuildGinEngine() *gin.Engine {
-	// Build Gin Engine
-	router := gin.Default()
-
+func BuildGinEngine(user interfaces.UserRepo) *gin.Engine {
 	// Set Gin Log Mode
 	if domain.Config().Server.Env == "dev" {
 		gin.SetMode(gin.DebugMode)
@@ -22,6 +25,17 @@ func BuildGinEngine() *gin.Engine {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	RegisterValidators()
+
+	// Build Gin Engine, access logs are written by RequestLogger
+	router := gin.New()
+	router.Use(gin.Recovery())
+	router.Use(otelgin.Middleware(domain.Config().Tracing.ServiceName))
+	router.Use(RequestLogger)
+	router.Use(RequestMetrics)
+	router.Use(Localize)
+	router.Use(ErrorHandler)
+
 	// Enable CORS with Default Configurations
 	config := cors.DefaultConfig()
 	config.AllowHeaders = []string{
@@ -29,6 +43,13 @@ func BuildGinEngine() *gin.Engine {
 		"Content-Length",
 		"Content-Type",
 		"Authorization",
+		"Accept-Language",
+		domain.RequestIDHeader,
+		adminSecretHeader,
+	}
+	config.ExposeHeaders = []string{
+		"Content-Language",
+		domain.RequestIDHeader,
 	}
 	config.AllowOrigins = []string{
 		domain.Config().Url.FrontEndUrl,
@@ -36,7 +57,7 @@ func BuildGinEngine() *gin.Engine {
 		domain.Config().Url.AdminUrl,
 	}
 	router.Use(cors.New(config))
-	ginMid := &Gin{}
+	ginMid := &Gin{user: user}
 	router.Use(ginMid.DecodeToken)
 	return router
 }
@@ -45,21 +66,80 @@ type Gin struct {
 	user interfaces.UserRepo
 }
 
-func (Gin) DecodeToken(ctx *gin.Context) {
+// userPrefixes are the paths of the API routes of the users, the other
+// routes, such as the health checks and the callbacks, have no user
+var userPrefixes = []string{"/user", "/gold/", "/notifications"}
+
+func userRoute(path string) bool {
+	for _, prefix := range userPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g Gin) DecodeToken(ctx *gin.Context) {
 	id := uint64(2)
-	ctx.Set("user", &models.User{
+	user := &models.User{
 		ID: &id,
+	}
+	ctx.Set("user", user)
+	if userRoute(ctx.Request.URL.Path) {
+		// The language of the user is stored as a value, the gin context
+		// is reused for other requests once this one is served
+		setLocale(ctx, g.userLocale(ctx, id, i18n.Locale(ctx.Request.Context())))
+	}
+
+	// Tag the request logs with the user
+	reqCtx := domain.ContextWithLogFields(ctx.Request.Context(), log.Fields{
+		"userId": id,
 	})
+	ctx.Request = ctx.Request.WithContext(reqCtx)
+}
+
+// userLocale returns the language preferred by the user, or the locale
+// negotiated for the request
+func (g Gin) userLocale(ctx *gin.Context, id uint64, negotiated string) string {
+	user, err := g.user.FindOne(ctx.Request.Context(), &models.User{ID: &id})
+	if err != nil {
+		domain.Logger(ctx.Request.Context()).WithError(err).Debug("user profile not loaded")
+		return negotiated
+	}
+	if user.Locale == nil || !i18n.IsSupported(*user.Locale) {
+		return negotiated
+	}
+	return *user.Locale
+}
+
+// adminSecretHeader carries the secret of the admins
+const adminSecretHeader = "X-Admin-Secret"
+
+// secretMatches reports if the secret sent is the one set, nothing
+// matches a secret that is not set
+func secretMatches(secret, sent string) bool {
+	return secret != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(sent)) == 1
+}
+
+// AdminOnly refuses the requests without the secret of the admins
+func AdminOnly(ctx *gin.Context) {
+	if !secretMatches(domain.Config().Server.AdminSecret, ctx.GetHeader(adminSecretHeader)) {
+		ctx.Error(domain.NewError(errors.New("invalid admin secret"), domain.ErrForbidden))
+		ctx.Abort()
+		return
+	}
 }
 
 func getPinchUserFromContext(ctx *gin.Context) (*models.User, error) {
 	userVal, ok := ctx.Get("user")
 	if !ok {
-		return nil, errors.New("user not found in context")
+		err := errors.New("user not found in context")
+		return nil, domain.NewError(err, domain.ErrUnauthorized)
 	}
 	user, ok := userVal.(*models.User)
 	if !ok {
-		return nil, errors.New("user not found in context")
+		err := errors.New("user not found in context")
+		return nil, domain.NewError(err, domain.ErrUnauthorized)
 	}
 	return user, nil
 }
@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/configtest"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/i18n"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+// fakeProfiles counts the profiles loaded
+type fakeProfiles struct {
+	interfaces.UserRepo
+	loads int
+}
+
+func (r *fakeProfiles) FindOne(ctx context.Context, user *models.User) (*models.User, error) {
+	r.loads++
+	locale := i18n.Hindi
+	return &models.User{ID: user.ID, Locale: &locale}, nil
+}
+
+func TestDecodeToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	serve := func(path string, fail bool) (*httptest.ResponseRecorder, *fakeProfiles) {
+		profiles := &fakeProfiles{}
+		router := gin.New()
+		router.Use(Localize, ErrorHandler, (&Gin{user: profiles}).DecodeToken)
+		router.GET(path, func(ctx *gin.Context) {
+			if fail {
+				ctx.Error(domain.NewError(errors.New("no rows"), domain.ErrNotFound))
+				return
+			}
+			ctx.JSON(200, gin.H{"status": "ok"})
+		})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w, profiles
+	}
+
+	t.Run("should load the language of the user once", func(t *testing.T) {
+		w, profiles := serve("/gold/portfolio", false)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 1, profiles.loads)
+		assert.Equal(t, i18n.Hindi, w.Header().Get("Content-Language"))
+	})
+
+	t.Run("should translate errors in the language of the user", func(t *testing.T) {
+		w, profiles := serve("/gold/portfolio", true)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, 1, profiles.loads)
+		assert.Equal(t, i18n.Hindi, w.Header().Get("Content-Language"))
+	})
+
+	t.Run("should not load profiles on routes without users", func(t *testing.T) {
+		w, profiles := serve("/readyz", true)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, 0, profiles.loads)
+		assert.Equal(t, i18n.DefaultLocale, w.Header().Get("Content-Language"))
+	})
+}
+
+func TestAdminOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	configtest.Use(t)
+	conf := &domain.Config().Server
+
+	serve := func(sent string) int {
+		router := gin.New()
+		router.Use(ErrorHandler)
+		router.GET("/admin/schedules", AdminOnly, func(ctx *gin.Context) {
+			ctx.JSON(200, gin.H{"status": "ok"})
+		})
+		req := httptest.NewRequest(http.MethodGet, "/admin/schedules", nil)
+		req.Header.Set(adminSecretHeader, sent)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	t.Run("should refuse every request without a secret set", func(t *testing.T) {
+		conf.AdminSecret = ""
+		assert.Equal(t, http.StatusForbidden, serve(""))
+	})
+
+	t.Run("should serve the admins only", func(t *testing.T) {
+		conf.AdminSecret = "s3cret"
+		assert.Equal(t, http.StatusForbidden, serve("wrong"))
+		assert.Equal(t, http.StatusOK, serve("s3cret"))
+	})
+}
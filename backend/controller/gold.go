@@ -1,7 +1,7 @@
 package controller
 
 import (
-	"log"
+	"mime/multipart"
 	"net/http"
 	"path/filepath"
 	"strings"
@@ -19,12 +19,22 @@ import (
 type GoldController struct {
 	gold        interfaces.AugmontService
 	augmontUser interfaces.AugmontUserRepo
+	orders      interfaces.AugmontOrderService
+	invoices    interfaces.AugmontInvoiceService
 }
 
-func NewGoldController(router *gin.Engine, gold interfaces.AugmontService, au interfaces.AugmontUserRepo) {
+func NewGoldController(
+	router *gin.Engine,
+	gold interfaces.AugmontService,
+	au interfaces.AugmontUserRepo,
+	orders interfaces.AugmontOrderService,
+	invoices interfaces.AugmontInvoiceService,
+) {
 	c := &GoldController{
 		gold:        gold,
 		augmontUser: au,
+		orders:      orders,
+		invoices:    invoices,
 	}
 
 	// Profile Endpoints
@@ -75,42 +85,62 @@ func NewGoldController(router *gin.Engine, gold interfaces.AugmontService, au in
 	{
 		group := router.Group("/gold/buy")
 		group.POST("", c.BuyOrder)
-		group.GET("/order/:taxID", c.GetBuyInfo)
+		group.GET("/order/:txnID", c.GetBuyInfo)
 		group.GET("/order", c.GetBuyList)
 	}
 
+	// Live rates and holdings of each metal
+	{
+		router.GET("/gold/rates", c.GetRates)
+		router.GET("/gold/portfolio", c.GetPortfolio)
+	}
+
+	// Order history of all types, served without augmont
+	{
+		group := router.Group("/gold/orders")
+		group.GET("", c.ListOrders)
+		group.GET("/:txnID", c.GetOrder)
+		// GST invoice of buy and redeem orders
+		group.GET("/:txnID/invoice", c.GetInvoice)
+	}
+
 }
 
 // CreateProfile handle create profile request
 func (c *GoldController) CreateProfile(ctx *gin.Context) {
 	// Get Pinch User from Contex
-	var user *models.User
-	{
-		userVal, ok := ctx.Get("user")
-		if !ok {
-			return
-		}
-		user = userVal.(*models.User)
+	user, err := getPinchUserFromContext(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
 	}
 
-	log.Println("Create profile")
+	domain.Logger(ctx.Request.Context()).Info("create profile")
 
 	augUser := &models.AugmontUser{
 		UserID: user.ID,
 	}
 
 	userinfo := &utils.AugmontUserInfo{}
-	err := ctx.Bind(userinfo)
+	err = ctx.ShouldBind(userinfo)
+	if err != nil {
+		ctx.Error(bindError(err))
+		return
+	}
+	// Augmont needs these to open the account
+	err = requireFields(map[string]string{
+		"mobileNumber": userinfo.MobileNo,
+		"userName":     userinfo.Name,
+		"userPincode":  userinfo.Pincode,
+	})
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
-	err = c.gold.CreateUser(userinfo, augUser)
+	err = c.gold.CreateUser(ctx.Request.Context(), userinfo, augUser)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
@@ -123,35 +153,28 @@ func (c *GoldController) CreateProfile(ctx *gin.Context) {
 func (c *GoldController) GetProfile(ctx *gin.Context) {
 
 	// Get Pinch User from Context
-	var user *models.User
-	{
-		var ok bool
-		user, ok = ctx.Keys["user"].(*models.User)
-		if !ok {
-			err := errors.New("user not found in context")
-			domain.ErrLog(err)
-			domain.ErrFailedGinReq(ctx, err)
-			return
-		}
+	user, err := getPinchUserFromContext(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
 	}
 
 	// Fetch augmont user from database
 	augUser, err := c.augmontUser.FindUser(
+		ctx.Request.Context(),
 		&models.AugmontUser{
 			UserID: user.ID,
 		})
 
 	// handle error
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
-	info, err := c.gold.GetUserInfo(*augUser.UID)
+	info, err := c.gold.GetUserInfo(ctx.Request.Context(), *augUser.UID)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
@@ -168,13 +191,13 @@ func (c *GoldController) UpdateProfile(ctx *gin.Context) {
 	// Get Pinch User from Context
 	user, err := getPinchUserFromContext(ctx)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
 	// Fetch augmont user from database
 	augUser, err := c.augmontUser.FindUser(
+		ctx.Request.Context(),
 		&models.AugmontUser{
 			UserID: user.ID,
 		})
@@ -182,25 +205,22 @@ func (c *GoldController) UpdateProfile(ctx *gin.Context) {
 	// handle error
 	if err != nil {
 		err := errors.Wrap(err, "failed to fetch augmont user")
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
 	userinfo := &utils.AugmontUserInfo{}
-	err = ctx.Bind(userinfo)
+	err = ctx.ShouldBind(userinfo)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(bindError(err))
 		return
 	}
 	userinfo.UniqueID = *augUser.UID
 
-	err = c.gold.UpdateUser(userinfo)
+	err = c.gold.UpdateUser(ctx.Request.Context(), userinfo)
 	if err != nil {
 		err := errors.Wrap(err, "failed to update augmont user info")
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
@@ -213,31 +233,27 @@ func (c *GoldController) UpdateProfile(ctx *gin.Context) {
 func (c *GoldController) CreateBank(ctx *gin.Context) {
 	user, err := getPinchUserFromContext(ctx)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
 	bankInfo := &utils.AugmontUserBankInfo{}
-	if err := ctx.Bind(bankInfo); err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+	if err := ctx.ShouldBind(bankInfo); err != nil {
+		ctx.Error(bindError(err))
 		return
 	}
 
 	agUser := &models.AugmontUser{
 		UserID: user.ID,
 	}
-	agUser, err = c.augmontUser.FindUser(agUser)
+	agUser, err = c.augmontUser.FindUser(ctx.Request.Context(), agUser)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
-	err = c.gold.CreateUserBank(agUser, bankInfo)
+	err = c.gold.CreateUserBank(ctx.Request.Context(), agUser, bankInfo)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
@@ -250,25 +266,22 @@ func (c *GoldController) CreateBank(ctx *gin.Context) {
 func (c *GoldController) GetUserBank(ctx *gin.Context) {
 	user, err := getPinchUserFromContext(ctx)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
 	agUser := &models.AugmontUser{
 		UserID: user.ID,
 	}
-	agUser, err = c.augmontUser.FindUser(agUser)
+	agUser, err = c.augmontUser.FindUser(ctx.Request.Context(), agUser)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
-	banks, err := c.gold.GetUserBanks(agUser)
+	banks, err := c.gold.GetUserBanks(ctx.Request.Context(), agUser)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
@@ -281,31 +294,31 @@ func (c *GoldController) GetUserBank(ctx *gin.Context) {
 func (c *GoldController) UpdateBank(ctx *gin.Context) {
 	user, err := getPinchUserFromContext(ctx)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
 	agUser := &models.AugmontUser{
 		UserID: user.ID,
 	}
-	agUser, err = c.augmontUser.FindUser(agUser)
+	agUser, err = c.augmontUser.FindUser(ctx.Request.Context(), agUser)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
 	bank := &utils.AugmontUserBankInfo{}
-	if err := ctx.Bind(bank); err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+	if err := ctx.ShouldBind(bank); err != nil {
+		ctx.Error(bindError(err))
 		return
 	}
-	err = c.gold.UpdateUserBank(agUser, bank)
+	if err := requireFields(map[string]string{"userBankId": bank.UserBankID}); err != nil {
+		ctx.Error(err)
+		return
+	}
+	err = c.gold.UpdateUserBank(ctx.Request.Context(), agUser, bank)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
@@ -318,35 +331,31 @@ func (c *GoldController) UpdateBank(ctx *gin.Context) {
 func (c *GoldController) DeleteBank(ctx *gin.Context) {
 	user, err := getPinchUserFromContext(ctx)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
 	agUser := &models.AugmontUser{
 		UserID: user.ID,
 	}
-	agUser, err = c.augmontUser.FindUser(agUser)
+	agUser, err = c.augmontUser.FindUser(ctx.Request.Context(), agUser)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
 	userBankID := ctx.Param("userBankID")
 	if userBankID == "" {
 		err = errors.New("userBankID not vaild")
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(domain.NewError(err, domain.ErrInvalidArgument))
 		return
 	}
 	bank := &utils.AugmontUserBankInfo{
 		UserBankID: userBankID,
 	}
-	err = c.gold.DeleteUserBank(agUser, bank)
+	err = c.gold.DeleteUserBank(ctx.Request.Context(), agUser, bank)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
@@ -359,33 +368,28 @@ func (c *GoldController) DeleteBank(ctx *gin.Context) {
 func (c *GoldController) CreateAddress(ctx *gin.Context) {
 	user, err := getPinchUserFromContext(ctx)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
 	agUser := &models.AugmontUser{
 		UserID: user.ID,
 	}
-	agUser, err = c.augmontUser.FindUser(agUser)
+	agUser, err = c.augmontUser.FindUser(ctx.Request.Context(), agUser)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
 	addr := &utils.AugmontUserAddressInfo{}
-	if err := ctx.Bind(addr); err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+	if err := ctx.ShouldBind(addr); err != nil {
+		ctx.Error(bindError(err))
 		return
 	}
 
-	err = c.gold.CreateUserAddress(agUser, addr)
+	err = c.gold.CreateUserAddress(ctx.Request.Context(), agUser, addr)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
@@ -398,29 +402,26 @@ func (c *GoldController) CreateAddress(ctx *gin.Context) {
 func (c *GoldController) GetUserAddress(ctx *gin.Context) {
 	user, err := getPinchUserFromContext(ctx)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
 	agUser := &models.AugmontUser{
 		UserID: user.ID,
 	}
-	agUser, err = c.augmontUser.FindUser(agUser)
+	agUser, err = c.augmontUser.FindUser(ctx.Request.Context(), agUser)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
-	addr, err := c.gold.GetUserAddresses(agUser)
+	addr, err := c.gold.GetUserAddresses(ctx.Request.Context(), agUser)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
 	ctx.JSON(200, gin.H{
-		"ststus":  "ok",
+		"status":  "ok",
 		"address": addr,
 	})
 }
@@ -428,73 +429,72 @@ func (c *GoldController) GetUserAddress(ctx *gin.Context) {
 func (c *GoldController) DeleteuserAddress(ctx *gin.Context) {
 	user, err := getPinchUserFromContext(ctx)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
 	agUser := &models.AugmontUser{
 		UserID: user.ID,
 	}
-	agUser, err = c.augmontUser.FindUser(agUser)
+	agUser, err = c.augmontUser.FindUser(ctx.Request.Context(), agUser)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
 	userAddressID := ctx.Param("userAddressID")
 	if userAddressID == "" {
 		err = errors.New("userAddressID not vaild")
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(domain.NewError(err, domain.ErrInvalidArgument))
 		return
 	}
 
 	addr := &utils.AugmontUserAddressInfo{
 		UserAddressID: userAddressID,
 	}
-	err = c.gold.DeleteUserAddress(agUser, addr)
+	err = c.gold.DeleteUserAddress(ctx.Request.Context(), agUser, addr)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
 	ctx.JSON(200, gin.H{
-		"ststus":  "ok",
+		"status":  "ok",
 		"message": "user address deleted",
 	})
 }
 
+// kycRequest is the multipart form of the KYC submission
+type kycRequest struct {
+	Name          string                `form:"nameAsPerPan" binding:"required,max=100"`
+	PanNo         string                `form:"panNumber" binding:"required,pan"`
+	DOB           string                `form:"dateOfBirth" binding:"required,date"`
+	PanAttachment *multipart.FileHeader `form:"panAttachment" binding:"required"`
+}
+
 func (c *GoldController) CreateKYC(ctx *gin.Context) {
 	user, err := getPinchUserFromContext(ctx)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
 	agUser := &models.AugmontUser{
 		UserID: user.ID,
 	}
-	agUser, err = c.augmontUser.FindUser(agUser)
+	agUser, err = c.augmontUser.FindUser(ctx.Request.Context(), agUser)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
-	kyc := &struct {
-		Name  string `form:"nameAsPerPan"`
-		PanNo string `form:"panNumber"`
-		DOB   string `form:"dateOfBirth"`
-	}{}
-	if err := ctx.Bind(kyc); err != nil {
+	kyc := &kycRequest{}
+	if err := ctx.ShouldBind(kyc); err != nil {
+		ctx.Error(bindError(err))
 		return
 	}
-	file, _ := ctx.FormFile("panAttachment")
-	format := strings.TrimSuffix(file.Filename, filepath.Ext(file.Filename))
+	file := kyc.PanAttachment
+	format := strings.TrimPrefix(filepath.Ext(file.Filename), ".")
 	localFile := &utils.File{
 		Key:         uuid.NewString(),
 		ContentType: file.Header.Get("Content-Type"),
@@ -504,12 +504,14 @@ func (c *GoldController) CreateKYC(ctx *gin.Context) {
 	defer localFile.Close()
 
 	data, err := c.gold.PostUserKyc(
+		ctx.Request.Context(),
 		kyc.Name, kyc.PanNo, kyc.DOB,
 		agUser,
 		localFile,
 	)
 
 	if err != nil {
+		ctx.Error(err)
 		return
 	}
 
@@ -522,18 +524,16 @@ func (c *GoldController) CreateKYC(ctx *gin.Context) {
 func (c *GoldController) GetKycStatus(ctx *gin.Context) {
 	user, err := getPinchUserFromContext(ctx)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
 	agUser := &models.AugmontUser{
 		UserID: user.ID,
 	}
-	agUser, err = c.augmontUser.FindUser(agUser)
+	agUser, err = c.augmontUser.FindUser(ctx.Request.Context(), agUser)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
@@ -542,19 +542,18 @@ func (c *GoldController) GetKycStatus(ctx *gin.Context) {
 			"status":    "ok",
 			"kycStatus": agUser.KYCStatus,
 		})
+		return
 	}
 
-	err = c.gold.UpdateUserKycStatus(agUser)
+	err = c.gold.UpdateUserKycStatus(ctx.Request.Context(), agUser)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
-	agUser, err = c.augmontUser.FindUser(agUser)
+	agUser, err = c.augmontUser.FindUser(ctx.Request.Context(), agUser)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 	ctx.JSON(200, gin.H{
@@ -566,8 +565,7 @@ func (c *GoldController) GetKycStatus(ctx *gin.Context) {
 func (c *GoldController) BuyOrder(ctx *gin.Context) {
 	user, err := getPinchUserFromContext(ctx)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
@@ -575,24 +573,21 @@ func (c *GoldController) BuyOrder(ctx *gin.Context) {
 		UserID: user.ID,
 	}
 
-	agUser, err = c.augmontUser.FindUser(agUser)
+	agUser, err = c.augmontUser.FindUser(ctx.Request.Context(), agUser)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
 	info := &utils.AugmontBugInfo{}
-	if err := ctx.Bind(info); err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+	if err := ctx.ShouldBind(info); err != nil {
+		ctx.Error(bindError(err))
 		return
 	}
 
-	data, err := c.gold.Buy(agUser, info)
+	data, err := c.gold.Buy(ctx.Request.Context(), agUser, info)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
@@ -606,8 +601,7 @@ func (c *GoldController) GetBuyInfo(ctx *gin.Context) {
 
 	user, err := getPinchUserFromContext(ctx)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
@@ -615,19 +609,17 @@ func (c *GoldController) GetBuyInfo(ctx *gin.Context) {
 		UserID: user.ID,
 	}
 
-	agUser, err = c.augmontUser.FindUser(agUser)
+	agUser, err = c.augmontUser.FindUser(ctx.Request.Context(), agUser)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
 	txnID := ctx.Param("txnID")
 
-	data, err := c.gold.BuyInfo(*agUser.UID, txnID)
+	data, err := c.gold.BuyInfo(ctx.Request.Context(), *agUser.UID, txnID)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
@@ -637,12 +629,14 @@ func (c *GoldController) GetBuyInfo(ctx *gin.Context) {
 	})
 }
 
+// GetBuyList proxies the buy list of augmont
+//
+// Deprecated: use ListOrders with type=buy
 func (c *GoldController) GetBuyList(ctx *gin.Context) {
 
 	user, err := getPinchUserFromContext(ctx)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
@@ -650,17 +644,15 @@ func (c *GoldController) GetBuyList(ctx *gin.Context) {
 		UserID: user.ID,
 	}
 
-	agUser, err = c.augmontUser.FindUser(agUser)
+	agUser, err = c.augmontUser.FindUser(ctx.Request.Context(), agUser)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
-	data, err := c.gold.BuyList(*agUser.UID)
+	data, err := c.gold.BuyList(ctx.Request.Context(), *agUser.UID)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
@@ -673,8 +665,7 @@ func (c *GoldController) GetBuyList(ctx *gin.Context) {
 func (c *GoldController) SellOrder(ctx *gin.Context) {
 	user, err := getPinchUserFromContext(ctx)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
@@ -682,24 +673,21 @@ func (c *GoldController) SellOrder(ctx *gin.Context) {
 		UserID: user.ID,
 	}
 
-	agUser, err = c.augmontUser.FindUser(agUser)
+	agUser, err = c.augmontUser.FindUser(ctx.Request.Context(), agUser)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
 	info := &utils.AugmontSellInfo{}
-	if err := ctx.Bind(info); err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+	if err := ctx.ShouldBind(info); err != nil {
+		ctx.Error(bindError(err))
 		return
 	}
 
-	data, err := c.gold.Sell(agUser, info)
+	data, err := c.gold.Sell(ctx.Request.Context(), agUser, info)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
@@ -713,8 +701,7 @@ func (c *GoldController) GetSellInfo(ctx *gin.Context) {
 
 	user, err := getPinchUserFromContext(ctx)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
@@ -722,19 +709,17 @@ func (c *GoldController) GetSellInfo(ctx *gin.Context) {
 		UserID: user.ID,
 	}
 
-	agUser, err = c.augmontUser.FindUser(agUser)
+	agUser, err = c.augmontUser.FindUser(ctx.Request.Context(), agUser)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
 	txnID := ctx.Param("txnID")
 
-	data, err := c.gold.SellInfo(*agUser.UID, txnID)
+	data, err := c.gold.SellInfo(ctx.Request.Context(), *agUser.UID, txnID)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
@@ -748,8 +733,7 @@ func (c *GoldController) GetSellList(ctx *gin.Context) {
 
 	user, err := getPinchUserFromContext(ctx)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
@@ -757,17 +741,15 @@ func (c *GoldController) GetSellList(ctx *gin.Context) {
 		UserID: user.ID,
 	}
 
-	agUser, err = c.augmontUser.FindUser(agUser)
+	agUser, err = c.augmontUser.FindUser(ctx.Request.Context(), agUser)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
-	data, err := c.gold.SellList(*agUser.UID)
+	data, err := c.gold.SellList(ctx.Request.Context(), *agUser.UID)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 
@@ -776,3 +758,170 @@ func (c *GoldController) GetSellList(ctx *gin.Context) {
 		"order":  data,
 	})
 }
+
+// orderFilters are the filters of the order history
+type orderFilters struct {
+	Type      string `form:"type" binding:"omitempty,oneof=buy sell redeem"`
+	Status    string `form:"status" binding:"omitempty,oneof=pending completed failed rerun"`
+	MetalType string `form:"metalType" binding:"omitempty,metal"`
+}
+
+func (c *GoldController) ListOrders(ctx *gin.Context) {
+	user, err := getPinchUserFromContext(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	agUser, err := c.augmontUser.FindUser(ctx.Request.Context(), &models.AugmontUser{
+		UserID: user.ID,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	filters := &orderFilters{}
+	q, err := bindListQuery(ctx, filters)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	if filters.Type != "" {
+		q.Where("type", utils.FilterEq, filters.Type)
+	}
+	if filters.Status != "" {
+		q.Where("status", utils.FilterEq, filters.Status)
+	}
+	if filters.MetalType != "" {
+		q.Where("metalType", utils.FilterEq, filters.MetalType)
+	}
+
+	orders, page, err := c.orders.List(ctx.Request.Context(), agUser, q)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"status": "ok",
+		"orders": orders,
+		"page":   page,
+	})
+}
+
+func (c *GoldController) GetOrder(ctx *gin.Context) {
+	user, err := getPinchUserFromContext(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	agUser, err := c.augmontUser.FindUser(ctx.Request.Context(), &models.AugmontUser{
+		UserID: user.ID,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	order, detail, err := c.orders.Find(ctx.Request.Context(), agUser, ctx.Param("txnID"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"status": "ok",
+		"order":  order,
+		// null while augmont is unavailable
+		"detail": detail,
+	})
+}
+
+func (c *GoldController) GetInvoice(ctx *gin.Context) {
+	user, err := getPinchUserFromContext(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	agUser, err := c.augmontUser.FindUser(ctx.Request.Context(), &models.AugmontUser{
+		UserID: user.ID,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	invoice, url, err := c.invoices.Find(ctx.Request.Context(), agUser, ctx.Param("txnID"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"status":  "ok",
+		"invoice": invoice,
+		// Signed and short lived, request the invoice again to renew it
+		"downloadUrl": url,
+	})
+}
+
+// ratesQuery selects the rate of a single metal
+type ratesQuery struct {
+	Metal string `form:"metal" binding:"omitempty,metal"`
+}
+
+func (c *GoldController) GetRates(ctx *gin.Context) {
+	q := &ratesQuery{}
+	if err := ctx.ShouldBindQuery(q); err != nil {
+		ctx.Error(bindError(err))
+		return
+	}
+
+	rates, err := c.gold.Rates(ctx.Request.Context())
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	if q.Metal != "" {
+		rates.Rates = []*utils.MetalRate{rates.Rate(utils.Metal(q.Metal))}
+		if rates.Rates[0] == nil {
+			ctx.Error(domain.NewError(errors.Newf("no %v rate", q.Metal), domain.ErrUnavailable, "the rate of this metal is not available"))
+			return
+		}
+	}
+
+	ctx.JSON(200, gin.H{
+		"status": "ok",
+		"rates":  rates,
+	})
+}
+
+func (c *GoldController) GetPortfolio(ctx *gin.Context) {
+	user, err := getPinchUserFromContext(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	agUser, err := c.augmontUser.FindUser(ctx.Request.Context(), &models.AugmontUser{
+		UserID: user.ID,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	holdings, err := c.orders.Portfolio(ctx.Request.Context(), agUser)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"status":   "ok",
+		"holdings": holdings,
+	})
+}
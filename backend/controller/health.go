@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"gorm.io/gorm"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+)
+
+// Dependency check status
+const (
+	healthOK       = "ok"
+	healthDegraded = "degraded"
+	healthDown     = "down"
+)
+
+type HealthController struct {
+	checks []*dependencyCheck
+}
+
+// dependencyCheck probes a single dependency
+type dependencyCheck struct {
+	name     string
+	critical bool
+	check    func(ctx context.Context) error
+}
+
+// Result of a single dependency check
+type dependencyStatus struct {
+	Status    string  `json:"status"`
+	Critical  bool    `json:"critical"`
+	LatencyMs float64 `json:"latencyMs"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// NewHealthController registers the liveness and readiness probes
+func NewHealthController(
+	router *gin.Engine,
+	db *gorm.DB,
+	rdb *redis.Client,
+	inMem interfaces.AugmontInMemRepo,
+	auth interfaces.AugmontAuthService,
+) {
+	critical := map[string]bool{}
+	for _, name := range domain.Config().Health.Critical {
+		critical[name] = true
+	}
+
+	c := &HealthController{}
+	c.addCheck("postgres", critical, func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	})
+	c.addCheck("redis", critical, func(ctx context.Context) error {
+		return rdb.Ping(ctx).Err()
+	})
+	token := &tokenCheck{inMem: inMem, auth: auth}
+	c.addCheck("augmont", critical, token.check)
+
+	// Process is alive
+	router.GET("/healthz", c.Live)
+	// Dependencies are reachable
+	router.GET("/readyz", c.Ready)
+}
+
+func (c *HealthController) addCheck(
+	name string,
+	critical map[string]bool,
+	check func(ctx context.Context) error,
+) {
+	c.checks = append(c.checks, &dependencyCheck{
+		name:     name,
+		critical: critical[name],
+		check:    check,
+	})
+}
+
+func (c *HealthController) Live(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": healthOK,
+	})
+}
+
+// Ready runs all dependency checks concurrently, it fails only
+// if a critical dependency is down, others mark it degraded
+func (c *HealthController) Ready(ctx *gin.Context) {
+	timeout := domain.Config().Health.Timeout
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	deps := make(map[string]*dependencyStatus, len(c.checks))
+	for _, dep := range c.checks {
+		wg.Add(1)
+		go func(dep *dependencyCheck) {
+			defer wg.Done()
+			status := runCheck(ctx.Request.Context(), dep, timeout)
+			mu.Lock()
+			deps[dep.name] = status
+			mu.Unlock()
+		}(dep)
+	}
+	wg.Wait()
+
+	overall, code := healthOK, http.StatusOK
+	for _, dep := range deps {
+		if dep.Status == healthOK {
+			continue
+		}
+		if dep.Critical {
+			overall, code = healthDown, http.StatusServiceUnavailable
+			break
+		}
+		overall = healthDegraded
+	}
+
+	ctx.JSON(code, gin.H{
+		"status":       overall,
+		"dependencies": deps,
+	})
+}
+
+func runCheck(
+	parent context.Context,
+	dep *dependencyCheck,
+	timeout time.Duration,
+) *dependencyStatus {
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := dep.check(ctx)
+	status := &dependencyStatus{
+		Status:    healthOK,
+		Critical:  dep.critical,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		status.Status = healthDown
+		status.Error = err.Error()
+	}
+	return status
+}
+
+// tokenCheck passes while an Augmont token is stored, without one it logs
+// in at most once per refresh interval, so probes do not flood the login
+// endpoint while Augmont is down
+type tokenCheck struct {
+	inMem interfaces.AugmontInMemRepo
+	auth  interfaces.AugmontAuthService
+
+	mu        sync.Mutex
+	lastLogin time.Time
+	lastErr   error
+}
+
+func (c *tokenCheck) check(ctx context.Context) error {
+	ttl, err := c.inMem.TokenTTL(ctx)
+	if err != nil {
+		return err
+	}
+	if ttl > 0 {
+		return nil
+	}
+
+	// Probes wait for the login in progress instead of starting their own
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if time.Since(c.lastLogin) < domain.Config().Health.TokenRefresh {
+		return c.lastErr
+	}
+	c.lastLogin = time.Now()
+	_, c.lastErr = c.auth.AuthToken(ctx)
+	return c.lastErr
+}
@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/configtest"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+)
+
+func TestReady(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	configtest.Use(t)
+	down := func(ctx context.Context) error { return errors.New("refused") }
+	up := func(ctx context.Context) error { return nil }
+
+	serve := func(checks ...*dependencyCheck) (int, map[string]interface{}) {
+		router := gin.New()
+		router.GET("/readyz", (&HealthController{checks: checks}).Ready)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		var body map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return w.Code, body
+	}
+
+	t.Run("should be ready when every dependency is up", func(t *testing.T) {
+		code, body := serve(
+			&dependencyCheck{name: "postgres", critical: true, check: up},
+			&dependencyCheck{name: "augmont", check: up},
+		)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, healthOK, body["status"])
+	})
+
+	t.Run("should be degraded when a dependency that is not critical is down", func(t *testing.T) {
+		code, body := serve(
+			&dependencyCheck{name: "postgres", critical: true, check: up},
+			&dependencyCheck{name: "augmont", check: down},
+		)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, healthDegraded, body["status"])
+		augmont := body["dependencies"].(map[string]interface{})["augmont"].(map[string]interface{})
+		assert.Equal(t, healthDown, augmont["status"])
+		assert.Equal(t, "refused", augmont["error"])
+	})
+
+	t.Run("should not be ready when a critical dependency is down", func(t *testing.T) {
+		code, body := serve(
+			&dependencyCheck{name: "postgres", critical: true, check: down},
+			&dependencyCheck{name: "augmont", check: down},
+		)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, healthDown, body["status"])
+	})
+
+	t.Run("should fail checks that time out", func(t *testing.T) {
+		domain.Config().Health.Timeout = time.Millisecond
+		code, body := serve(&dependencyCheck{name: "redis", critical: true, check: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}})
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, healthDown, body["status"])
+	})
+}
+
+// fakeTokenStore stores the token for the ttl
+type fakeTokenStore struct {
+	interfaces.AugmontInMemRepo
+	ttl time.Duration
+}
+
+func (r *fakeTokenStore) TokenTTL(ctx context.Context) (time.Duration, error) {
+	return r.ttl, nil
+}
+
+// fakeLogin counts the logins to Augmont
+type fakeLogin struct {
+	interfaces.AugmontAuthService
+	logins int
+	err    error
+}
+
+func (s *fakeLogin) AuthToken(ctx context.Context) (string, error) {
+	s.logins++
+	return "token", s.err
+}
+
+func TestTokenCheck(t *testing.T) {
+	configtest.Use(t)
+	ctx := context.Background()
+
+	t.Run("should pass without login while a token is stored", func(t *testing.T) {
+		login := &fakeLogin{}
+		c := &tokenCheck{inMem: &fakeTokenStore{ttl: time.Hour}, auth: login}
+		assert.NoError(t, c.check(ctx))
+		assert.Equal(t, 0, login.logins)
+	})
+
+	t.Run("should log in once per refresh interval", func(t *testing.T) {
+		domain.Config().Health.TokenRefresh = time.Hour
+		login := &fakeLogin{err: errors.New("augmont is down")}
+		c := &tokenCheck{inMem: &fakeTokenStore{}, auth: login}
+		for i := 0; i < 3; i++ {
+			assert.EqualError(t, c.check(ctx), "augmont is down")
+		}
+		assert.Equal(t, 1, login.logins)
+
+		c.lastLogin = time.Now().Add(-time.Hour)
+		login.err = nil
+		assert.NoError(t, c.check(ctx))
+		assert.Equal(t, 2, login.logins)
+	})
+}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/i18n"
+)
+
+// Localize negotiates the locale of the request from Accept-Language,
+// DecodeToken overrides it on the API routes with the language
+// preferred by the user
+func Localize(ctx *gin.Context) {
+	setLocale(ctx, i18n.Negotiate(ctx.GetHeader("Accept-Language")))
+}
+
+// setLocale stores the locale in the request context
+func setLocale(ctx *gin.Context, locale string) {
+	ctx.Header("Content-Language", locale)
+	reqCtx := i18n.ContextWithLocale(ctx.Request.Context(), locale)
+	ctx.Request = ctx.Request.WithContext(reqCtx)
+}
+
+// translateError returns the message and the field errors of the error
+// in the locale, untranslated messages are kept in English
+func translateError(locale string, e *domain.Error) (string, []domain.FieldError) {
+	msg := i18n.Translate(locale, e.Code(), e.UIMsg(), nil)
+	if len(e.Fields()) == 0 {
+		return msg, nil
+	}
+
+	fields := make([]domain.FieldError, len(e.Fields()))
+	for i, f := range e.Fields() {
+		f.Message = translateField(locale, f)
+		fields[i] = f
+	}
+	return msg, fields
+}
+
+// translateField looks the field error up by its code, its validation
+// tag and at last by the field name
+func translateField(locale string, f domain.FieldError) string {
+	if msg, ok := i18n.T(locale, f.Code, nil); ok {
+		return msg
+	}
+	args := map[string]string{"field": f.Field, "param": f.Param}
+	if msg, ok := i18n.T(locale, "validation."+f.Code, args); ok {
+		return msg
+	}
+	return i18n.Translate(locale, "field."+f.Field, f.Message, nil)
+}
@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+)
+
+// Longest incoming request id accepted, longer ones are replaced
+const maxRequestIDLen = 128
+
+// RequestLogger assigns the request id, honouring an incoming one,
+// stores the request scoped log entry in the request context
+// and writes the access log once the request is served
+func RequestLogger(ctx *gin.Context) {
+	start := time.Now()
+
+	id := ctx.GetHeader(domain.RequestIDHeader)
+	if id == "" || len(id) > maxRequestIDLen {
+		id = uuid.NewString()
+	}
+	ctx.Header(domain.RequestIDHeader, id)
+
+	fields := log.Fields{
+		"requestId": id,
+		"method":    ctx.Request.Method,
+		"route":     ctx.FullPath(),
+	}
+	spanCtx := trace.SpanContextFromContext(ctx.Request.Context())
+	if spanCtx.IsValid() {
+		fields["traceId"] = spanCtx.TraceID().String()
+	}
+	reqCtx := domain.ContextWithRequestID(ctx.Request.Context(), id)
+	reqCtx = domain.ContextWithLogFields(reqCtx, fields)
+	ctx.Request = ctx.Request.WithContext(reqCtx)
+
+	ctx.Next()
+
+	// Later middlewares may have added fields, like the user id
+	entry := domain.Logger(ctx.Request.Context()).WithFields(log.Fields{
+		"path":      ctx.Request.URL.Path,
+		"status":    ctx.Writer.Status(),
+		"latencyMs": float64(time.Since(start).Microseconds()) / 1000,
+		"clientIp":  ctx.ClientIP(),
+		"bytes":     ctx.Writer.Size(),
+	})
+	if len(ctx.Errors) > 0 {
+		entry = entry.WithField("errors", ctx.Errors.String())
+	}
+
+	status := ctx.Writer.Status()
+	switch {
+	case status >= 500:
+		entry.Error("request")
+	case status >= 400:
+		entry.Warn("request")
+	default:
+		entry.Info("request")
+	}
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/metrics"
+)
+
+// NewMetricsController serves the prometheus metrics on the internal
+// listener of METRICS_ADDR, away from the public API
+func NewMetricsController(lifecycle *domain.Lifecycle, rdb *redis.Client) error {
+	prometheus.MustRegister(metrics.NewRedisPoolCollector(rdb))
+
+	addr := domain.Config().Server.MetricsAddr
+	if addr == "" {
+		return nil
+	}
+	// Listen now, a port in use fails the start
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return errors.Wrap(err, "listen for metrics")
+	}
+	srv := newMetricsServer()
+	go func() {
+		log.WithField("addr", listener.Addr().String()).Info("metrics server started")
+		if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.WithError(err).Error("metrics server failed")
+		}
+	}()
+	// Stopped last, the metrics of the shutdown can still be scraped
+	lifecycle.Append(domain.Hook{
+		Name:   "metrics server",
+		OnStop: srv.Shutdown,
+	})
+	return nil
+}
+
+func newMetricsServer() *http.Server {
+	cfg := domain.Config().Server
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	return &http.Server{
+		Handler:      mux,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+	}
+}
+
+// RequestMetrics observes the request duration per route and status
+func RequestMetrics(ctx *gin.Context) {
+	start := time.Now()
+	ctx.Next()
+
+	// Use the route template to keep the label cardinality low
+	route := ctx.FullPath()
+	if route == "" {
+		route = "unmatched"
+	}
+	metrics.HTTPRequestDuration.
+		WithLabelValues(
+			ctx.Request.Method,
+			route,
+			strconv.Itoa(ctx.Writer.Status()),
+		).
+		Observe(time.Since(start).Seconds())
+}
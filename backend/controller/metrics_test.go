@@ -0,0 +1,24 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/configtest"
+)
+
+func TestMetricsServer(t *testing.T) {
+	configtest.Use(t)
+	srv := newMetricsServer()
+
+	serve := func(path string) int {
+		w := httptest.NewRecorder()
+		srv.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w.Code
+	}
+	assert.Equal(t, http.StatusOK, serve("/metrics"))
+	assert.Equal(t, http.StatusNotFound, serve("/readyz"))
+}
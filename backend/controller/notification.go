@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+type NotificationController struct {
+	notifications interfaces.NotificationService
+}
+
+// NewNotificationController creates the notification preference and history endpoints
+func NewNotificationController(router *gin.Engine, notifications interfaces.NotificationService) {
+	c := &NotificationController{
+		notifications: notifications,
+	}
+	router.GET("/notifications/preferences", c.Preferences)
+	router.PUT("/notifications/preferences", c.SavePreferences)
+	router.GET("/notifications", c.List)
+}
+
+func (c *NotificationController) Preferences(ctx *gin.Context) {
+	user, err := getPinchUserFromContext(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	pref, err := c.notifications.Preferences(ctx.Request.Context(), *user.ID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"status":      "ok",
+		"preferences": pref,
+	})
+}
+
+func (c *NotificationController) SavePreferences(ctx *gin.Context) {
+	user, err := getPinchUserFromContext(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	info := &utils.NotificationPreferenceInfo{}
+	if err := ctx.ShouldBindJSON(info); err != nil {
+		ctx.Error(bindError(err))
+		return
+	}
+
+	pref, err := c.notifications.SavePreferences(ctx.Request.Context(), *user.ID, info)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"status":      "ok",
+		"preferences": pref,
+	})
+}
+
+// notificationFilters are the filters of the notifications
+type notificationFilters struct {
+	Status  string `form:"status" binding:"omitempty,oneof=pending sending sent failed"`
+	Channel string `form:"channel" binding:"omitempty,oneof=sms email push"`
+}
+
+func (c *NotificationController) List(ctx *gin.Context) {
+	user, err := getPinchUserFromContext(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	filters := &notificationFilters{}
+	q, err := bindListQuery(ctx, filters)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	if filters.Status != "" {
+		q.Where("status", utils.FilterEq, filters.Status)
+	}
+	if filters.Channel != "" {
+		q.Where("channel", utils.FilterEq, filters.Channel)
+	}
+
+	notifications, page, err := c.notifications.List(ctx.Request.Context(), *user.ID, q)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"status":        "ok",
+		"notifications": notifications,
+		"page":          page,
+	})
+}
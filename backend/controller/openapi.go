@@ -0,0 +1,296 @@
+package controller
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/openapi"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/tax"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+// apiVersion is the version of the documented API
+const apiVersion = "1.0.0"
+
+// apiRoute documents a route, every route registered on the
+// engine must be listed here or in undocumentedRoutes
+type apiRoute struct {
+	method  string
+	path    string
+	tag     string
+	summary string
+
+	// Request body, JSON unless form is set
+	body interface{}
+	form bool
+	// Structs of the query parameters, named by form tags
+	query []interface{}
+	// Fields of the success response next to "status"
+	resp gin.H
+}
+
+var apiRoutes = []apiRoute{
+	// Users
+	{method: http.MethodPost, path: "/user", tag: "user", summary: "Create a user",
+		body: models.User{}, resp: gin.H{"user": models.User{}}},
+	{method: http.MethodGet, path: "/user/:id", tag: "user", summary: "Get a user",
+		resp: gin.H{"user": models.User{}}},
+	{method: http.MethodGet, path: "/user", tag: "user", summary: "List users",
+		query: []interface{}{utils.ListQuery{}, userFilters{}},
+		resp:  gin.H{"users": []models.User{}, "page": utils.Page{}}},
+	{method: http.MethodPut, path: "/user/:id", tag: "user", summary: "Update a user",
+		body: models.User{}, resp: gin.H{"user": models.User{}}},
+	{method: http.MethodDelete, path: "/user/:id", tag: "user", summary: "Delete a user",
+		resp: gin.H{"user": models.User{}}},
+
+	// Gold profile
+	{method: http.MethodPost, path: "/gold/profile", tag: "gold profile", summary: "Open an Augmont account",
+		body: utils.AugmontUserInfo{}, resp: gin.H{"message": ""}},
+	{method: http.MethodGet, path: "/gold/profile", tag: "gold profile", summary: "Get the Augmont profile",
+		resp: gin.H{"message": "", "profile": utils.AugmontUserInfo{}}},
+	{method: http.MethodPut, path: "/gold/profile", tag: "gold profile", summary: "Update the Augmont profile",
+		body: utils.AugmontUserInfo{}, resp: gin.H{"message": ""}},
+	{method: http.MethodPost, path: "/gold/profile/bank", tag: "gold profile", summary: "Add a bank account",
+		body: utils.AugmontUserBankInfo{}, resp: gin.H{"userBankID": ""}},
+	{method: http.MethodGet, path: "/gold/profile/bank", tag: "gold profile", summary: "List bank accounts",
+		resp: gin.H{"banks": []utils.AugmontUserBankInfo{}}},
+	{method: http.MethodPut, path: "/gold/profile/bank", tag: "gold profile", summary: "Update a bank account",
+		body: utils.AugmontUserBankInfo{}, resp: gin.H{"message": ""}},
+	{method: http.MethodDelete, path: "/gold/profile/bank/:userBankID", tag: "gold profile", summary: "Delete a bank account",
+		resp: gin.H{"userBankID": ""}},
+	{method: http.MethodPost, path: "/gold/profile/address", tag: "gold profile", summary: "Add an address",
+		body: utils.AugmontUserAddressInfo{}, resp: gin.H{"userAddressID": ""}},
+	{method: http.MethodGet, path: "/gold/profile/address", tag: "gold profile", summary: "List addresses",
+		resp: gin.H{"address": []utils.AugmontUserAddressInfo{}}},
+	{method: http.MethodDelete, path: "/gold/profile/address/:userAddressID", tag: "gold profile", summary: "Delete an address",
+		resp: gin.H{"message": ""}},
+	{method: http.MethodPost, path: "/gold/profile/kyc", tag: "gold profile", summary: "Submit the PAN for KYC",
+		body: kycRequest{}, form: true, resp: gin.H{"message": utils.Any(nil)}},
+	{method: http.MethodGet, path: "/gold/profile/kyc", tag: "gold profile", summary: "Get the KYC status",
+		resp: gin.H{"kycStatus": ""}},
+
+	// Gold orders
+	{method: http.MethodPost, path: "/gold/buy", tag: "gold orders", summary: "Buy gold or silver at a locked rate",
+		body: utils.AugmontBugInfo{}, resp: gin.H{"order": utils.Any(nil)}},
+	{method: http.MethodGet, path: "/gold/buy/order/:txnID", tag: "gold orders", summary: "Get a buy order",
+		resp: gin.H{"order": utils.Any(nil)}},
+	{method: http.MethodGet, path: "/gold/buy/order", tag: "gold orders", summary: "List buy orders from Augmont, use /gold/orders",
+		resp: gin.H{"order": utils.Any(nil)}},
+	{method: http.MethodGet, path: "/gold/orders", tag: "gold orders", summary: "List orders of all types",
+		query: []interface{}{utils.ListQuery{}, orderFilters{}},
+		resp:  gin.H{"orders": []models.AugmontOrder{}, "page": utils.Page{}}},
+	{method: http.MethodGet, path: "/gold/orders/:txnID", tag: "gold orders", summary: "Get an order with its Augmont detail",
+		resp: gin.H{"order": models.AugmontOrder{}, "detail": utils.Any(nil)}},
+	{method: http.MethodGet, path: "/gold/orders/:txnID/invoice", tag: "gold orders", summary: "Get the GST invoice of a buy or redeem order with its download URL",
+		resp: gin.H{"invoice": models.AugmontInvoice{}, "downloadUrl": ""}},
+
+	// Rates and holdings
+	{method: http.MethodGet, path: "/gold/rates", tag: "gold rates", summary: "Live buy and sell rates of the metals, locked by the block id",
+		query: []interface{}{ratesQuery{}}, resp: gin.H{"rates": utils.MetalRates{}}},
+	{method: http.MethodGet, path: "/gold/portfolio", tag: "gold rates", summary: "Holdings of each metal with their value at the sell rate",
+		resp: gin.H{"holdings": []utils.MetalHolding{}}},
+
+	// Redeem
+	{method: http.MethodGet, path: "/gold/products", tag: "gold redeem", summary: "List the coins and bars that can be delivered",
+		query: []interface{}{utils.ListQuery{}, productFilters{}},
+		resp:  gin.H{"products": []models.AugmontProduct{}, "page": utils.Page{}}},
+	{method: http.MethodGet, path: "/gold/products/:sku", tag: "gold redeem", summary: "Get a product of the catalogue",
+		resp: gin.H{"product": models.AugmontProduct{}}},
+	{method: http.MethodGet, path: "/gold/cart", tag: "gold redeem", summary: "Get the delivery cart with the grams it takes from the balance",
+		resp: gin.H{"cart": models.Cart{}}},
+	{method: http.MethodPut, path: "/gold/cart/items/:sku", tag: "gold redeem", summary: "Set the pieces of a product in the cart, zero removes it",
+		body: cartItemRequest{}, resp: gin.H{"cart": models.Cart{}}},
+	{method: http.MethodDelete, path: "/gold/cart", tag: "gold redeem", summary: "Empty the delivery cart"},
+	{method: http.MethodPost, path: "/gold/cart/checkout", tag: "gold redeem", summary: "Redeem the cart to an address after checking the stock, balance and pincode",
+		body: utils.AugmontCheckoutInfo{}, resp: gin.H{"order": utils.Any(nil)}},
+	{method: http.MethodGet, path: "/gold/orders/:txnID/shipment", tag: "gold redeem", summary: "Tracking timeline of the shipment of a redeem order",
+		resp: gin.H{"shipment": models.ShipmentTimeline{}}},
+
+	// Gifts
+	{method: http.MethodGet, path: "/gold/gifts/recipient", tag: "gold gifts", summary: "Look up who a gift to a mobile number goes to",
+		query: []interface{}{giftRecipientQuery{}}, resp: gin.H{"recipient": models.GiftRecipient{}}},
+	{method: http.MethodPost, path: "/gold/gifts", tag: "gold gifts", summary: "Gift metal, held in escrow until recipients without KYC claim it",
+		body: utils.AugmontGiftInfo{}, resp: gin.H{"gift": models.AugmontGift{}}},
+	{method: http.MethodGet, path: "/gold/gifts", tag: "gold gifts", summary: "List the gifts sent, received and waiting to be claimed",
+		query: []interface{}{utils.ListQuery{}, giftFilters{}},
+		resp:  gin.H{"gifts": []models.AugmontGift{}, "page": utils.Page{}}},
+	{method: http.MethodPost, path: "/gold/gifts/claim", tag: "gold gifts", summary: "Claim the gifts held in escrow for the mobile number, needs KYC",
+		resp: gin.H{"gifts": []models.AugmontGift{}}},
+	{method: http.MethodGet, path: "/gold/ledger", tag: "gold gifts", summary: "Metal moved in or out of the account other than by orders",
+		query: []interface{}{utils.ListQuery{}, ledgerFilters{}},
+		resp:  gin.H{"entries": []models.AugmontLedgerEntry{}, "page": utils.Page{}}},
+
+	// Notifications
+	{method: http.MethodGet, path: "/notifications/preferences", tag: "notifications", summary: "Get the channels, addresses and quiet hours of the notifications",
+		resp: gin.H{"preferences": models.NotificationPreference{}}},
+	{method: http.MethodPut, path: "/notifications/preferences", tag: "notifications", summary: "Change the notification preferences, fields left out are kept",
+		body: utils.NotificationPreferenceInfo{}, resp: gin.H{"preferences": models.NotificationPreference{}}},
+	{method: http.MethodGet, path: "/notifications", tag: "notifications", summary: "List the notifications sent or waiting to be sent with their delivery",
+		query: []interface{}{utils.ListQuery{}, notificationFilters{}},
+		resp:  gin.H{"notifications": []models.Notification{}, "page": utils.Page{}}},
+
+	// Schedules
+	{method: http.MethodGet, path: "/admin/schedules", tag: "admin", summary: "List the scheduled tasks with their last and next run and the replica leading",
+		resp: gin.H{"schedules": []models.Schedule{}, "leader": ""}},
+	{method: http.MethodGet, path: "/admin/schedules/:name/runs", tag: "admin", summary: "List the runs of a scheduled task with their outcome",
+		query: []interface{}{utils.ListQuery{}, scheduleRunFilters{}},
+		resp:  gin.H{"runs": []models.ScheduleRun{}, "page": utils.Page{}}},
+
+	// Statements
+	{method: http.MethodPost, path: "/gold/statements", tag: "gold statements", summary: "Request a statement, generated in the background",
+		body: statementRequest{}, resp: gin.H{"statement": models.AugmontStatement{}}},
+	{method: http.MethodGet, path: "/gold/statements/:id", tag: "gold statements", summary: "Get a statement with its download URL once ready",
+		resp: gin.H{"statement": models.AugmontStatement{}, "downloadUrl": ""}},
+
+	// Tax
+	{method: http.MethodGet, path: "/gold/tax-report", tag: "gold tax", summary: "Capital gains of a financial year, as JSON or a CSV or PDF download",
+		query: []interface{}{taxReportQuery{}}, resp: gin.H{"report": tax.Report{}}},
+
+	// Health
+	{method: http.MethodGet, path: "/healthz", tag: "health", summary: "Liveness probe"},
+	{method: http.MethodGet, path: "/readyz", tag: "health", summary: "Readiness probe with the status of the dependencies",
+		resp: gin.H{"dependencies": map[string]dependencyStatus{}}},
+}
+
+// undocumentedRoutes are served for operators and tools, not for the apps
+var undocumentedRoutes = map[string]bool{
+	"GET /openapi.json": true,
+	"GET /docs":         true,
+	// Signed download URLs are opaque to the apps
+	"GET /files/*key": true,
+	// Called by Augmont with the shared secret
+	"POST /augmont/callbacks/shipment": true,
+}
+
+var (
+	specOnce sync.Once
+	spec     *openapi.Document
+)
+
+// OpenAPISpec returns the OpenAPI document of the apiRoutes
+func OpenAPISpec() *openapi.Document {
+	specOnce.Do(func() {
+		spec = buildOpenAPISpec()
+	})
+	return spec
+}
+
+func buildOpenAPISpec() *openapi.Document {
+	doc := openapi.New(
+		"Pinch API",
+		apiVersion,
+		"Digital gold and silver through Augmont. Errors share the "+
+			"ErrorResponse envelope, messages follow Accept-Language.",
+	)
+	errResp := openapi.Response{
+		Description: "Error",
+		Content: map[string]openapi.MediaType{
+			"application/json": {Schema: doc.Schema(ErrorResponse{})},
+		},
+	}
+
+	for _, route := range apiRoutes {
+		op := &openapi.Operation{
+			OperationID: operationID(route.method, route.path),
+			Summary:     route.summary,
+			Tags:        []string{route.tag},
+			Responses: map[string]openapi.Response{
+				"200": {
+					Description: "OK",
+					Content: map[string]openapi.MediaType{
+						"application/json": {Schema: responseSchema(doc, route.resp)},
+					},
+				},
+				"default": errResp,
+			},
+		}
+		for _, query := range route.query {
+			op.Parameters = append(op.Parameters, doc.QueryParameters(query)...)
+		}
+		if route.body != nil {
+			contentType := "application/json"
+			if route.form {
+				contentType = "multipart/form-data"
+			}
+			op.RequestBody = &openapi.RequestBody{
+				Required: true,
+				Content: map[string]openapi.MediaType{
+					contentType: {Schema: doc.Schema(route.body)},
+				},
+			}
+		}
+		doc.Add(route.method, route.path, op)
+	}
+	return doc
+}
+
+// responseSchema is the schema of the success envelope
+func responseSchema(doc *openapi.Document, fields gin.H) *openapi.Schema {
+	schema := &openapi.Schema{
+		Type: "object",
+		Properties: map[string]*openapi.Schema{
+			"status": {Type: "string"},
+		},
+		Required: []string{"status"},
+	}
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		schema.Properties[key] = doc.Schema(fields[key])
+	}
+	return schema
+}
+
+// operationID names the operation after the method and the path,
+// e.g. GET /gold/buy/order/:txnID -> getGoldBuyOrderByTxnID
+func operationID(method, path string) string {
+	id := strings.ToLower(method)
+	for _, seg := range strings.Split(path, "/") {
+		if seg == "" {
+			continue
+		}
+		if strings.HasPrefix(seg, ":") {
+			seg = "By" + strings.Title(seg[1:])
+		}
+		id += strings.Title(seg)
+	}
+	return id
+}
+
+// swaggerUI loads the Swagger UI from the CDN, only served in dev
+const swaggerUI = `<!DOCTYPE html>
+<html>
+<head>
+  <title>Pinch API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@4/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@4/swagger-ui-bundle.js"></script>
+  <script>
+    SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>`
+
+// NewDocsController serves the OpenAPI document and the Swagger UI in dev
+func NewDocsController(router *gin.Engine) {
+	router.GET("/openapi.json", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, OpenAPISpec())
+	})
+
+	if domain.Config().Server.Env == "dev" {
+		router.GET("/docs", func(ctx *gin.Context) {
+			ctx.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUI))
+		})
+	}
+}
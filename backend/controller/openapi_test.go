@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/configtest"
+)
+
+// routesEngine registers the controllers of the server without dependencies
+func routesEngine(t *testing.T) *gin.Engine {
+	configtest.Use(t)
+	domain.Config().Server.Env = "dev"
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	NewUserController(router, nil)
+	NewGoldController(router, nil, nil, nil, nil)
+	NewStatementController(router, nil, nil)
+	NewFilesController(router, nil)
+	NewTaxController(router, nil, nil, nil)
+	NewRedeemController(router, nil, nil, nil, nil)
+	NewGiftController(router, nil, nil)
+	NewNotificationController(router, nil)
+	NewScheduleController(router, nil)
+	NewHealthController(router, nil, nil, nil, nil)
+	NewDocsController(router)
+	return router
+}
+
+func TestOpenAPISpec(t *testing.T) {
+	router := routesEngine(t)
+	spec := OpenAPISpec()
+
+	t.Run("should document every registered route", func(t *testing.T) {
+		for _, route := range router.Routes() {
+			if undocumentedRoutes[route.Method+" "+route.Path] {
+				continue
+			}
+			assert.True(t, spec.Has(route.Method, route.Path),
+				"%s %s is missing from apiRoutes", route.Method, route.Path)
+		}
+	})
+
+	t.Run("should only document registered routes", func(t *testing.T) {
+		registered := map[string]bool{}
+		for _, route := range router.Routes() {
+			registered[route.Method+" "+route.Path] = true
+		}
+		for _, route := range apiRoutes {
+			assert.True(t, registered[route.method+" "+route.path],
+				"%s %s is documented but not registered", route.method, route.path)
+		}
+	})
+
+	t.Run("should derive schemas from the request structs", func(t *testing.T) {
+		bank := spec.Components.Schemas["AugmontUserBankInfo"]
+		assert.NotNil(t, bank)
+		assert.ElementsMatch(t, []string{"accountNumber", "accountName", "ifscCode"}, bank.Required)
+		assert.Equal(t, `^[A-Z]{4}0[A-Z0-9]{6}$`, bank.Properties["ifscCode"].Pattern)
+
+		op := (*spec.Paths["/gold/profile/bank/{userBankID}"])["delete"]
+		assert.Equal(t, "userBankID", op.Parameters[0].Name)
+		assert.Equal(t, "path", op.Parameters[0].In)
+	})
+
+	t.Run("should encode as JSON", func(t *testing.T) {
+		b, err := json.Marshal(spec)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(b), `{"openapi":"3.0.3"`))
+	})
+
+	t.Run("should serve the spec", func(t *testing.T) {
+		assert.True(t, hasRoute(router, http.MethodGet, "/openapi.json"))
+		assert.True(t, hasRoute(router, http.MethodGet, "/docs"))
+	})
+
+	t.Run("should keep the metrics off the API", func(t *testing.T) {
+		assert.False(t, hasRoute(router, http.MethodGet, "/metrics"))
+	})
+}
+
+func hasRoute(router *gin.Engine, method, path string) bool {
+	for _, route := range router.Routes() {
+		if route.Method == method && route.Path == path {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+// bindListQuery binds the pagination, sorting and creation range of a
+// list endpoint, and the resource specific filters if given
+func bindListQuery(ctx *gin.Context, filters interface{}) (*utils.ListQuery, error) {
+	q := &utils.ListQuery{}
+	if err := ctx.ShouldBindQuery(q); err != nil {
+		return nil, bindError(err)
+	}
+	if filters != nil {
+		if err := ctx.ShouldBindQuery(filters); err != nil {
+			return nil, bindError(err)
+		}
+	}
+	if err := q.Normalize(); err != nil {
+		return nil, invalidFields(err, fieldError("cursor", "cursor", ""))
+	}
+	return q, nil
+}
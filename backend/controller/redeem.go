@@ -0,0 +1,233 @@
+package controller
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+type RedeemController struct {
+	products    interfaces.AugmontProductService
+	cart        interfaces.AugmontCartService
+	shipments   interfaces.AugmontShipmentService
+	augmontUser interfaces.AugmontUserRepo
+}
+
+// NewRedeemController creates the product catalogue, delivery cart
+// and shipment tracking endpoints
+func NewRedeemController(
+	router *gin.Engine,
+	products interfaces.AugmontProductService,
+	cart interfaces.AugmontCartService,
+	shipments interfaces.AugmontShipmentService,
+	au interfaces.AugmontUserRepo,
+) {
+	c := &RedeemController{
+		products:    products,
+		cart:        cart,
+		shipments:   shipments,
+		augmontUser: au,
+	}
+	router.GET("/gold/products", c.ListProducts)
+	router.GET("/gold/products/:sku", c.GetProduct)
+
+	router.GET("/gold/cart", c.GetCart)
+	router.PUT("/gold/cart/items/:sku", c.SetCartItem)
+	router.DELETE("/gold/cart", c.ClearCart)
+	router.POST("/gold/cart/checkout", c.Checkout)
+
+	router.GET("/gold/orders/:txnID/shipment", c.GetShipment)
+	router.POST("/augmont/callbacks/shipment", c.ShipmentCallback)
+}
+
+// productFilters are the filters of the product catalogue
+type productFilters struct {
+	MetalType string `form:"metalType" binding:"omitempty,metal"`
+}
+
+func (c *RedeemController) ListProducts(ctx *gin.Context) {
+	filters := &productFilters{}
+	q, err := bindListQuery(ctx, filters)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	if filters.MetalType != "" {
+		q.Where("metalType", utils.FilterEq, filters.MetalType)
+	}
+
+	products, page, err := c.products.List(ctx.Request.Context(), q)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"status":   "ok",
+		"products": products,
+		"page":     page,
+	})
+}
+
+func (c *RedeemController) GetProduct(ctx *gin.Context) {
+	product, err := c.products.Find(ctx.Request.Context(), ctx.Param("sku"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"status":  "ok",
+		"product": product,
+	})
+}
+
+// findUser returns the Augmont user of the request
+func (c *RedeemController) findUser(ctx *gin.Context) (*models.AugmontUser, error) {
+	user, err := getPinchUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.augmontUser.FindUser(ctx.Request.Context(), &models.AugmontUser{
+		UserID: user.ID,
+	})
+}
+
+func (c *RedeemController) GetCart(ctx *gin.Context) {
+	agUser, err := c.findUser(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	cart, err := c.cart.Get(ctx.Request.Context(), agUser)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"status": "ok",
+		"cart":   cart,
+	})
+}
+
+// cartItemRequest sets the pieces of a product in the cart, zero removes it
+type cartItemRequest struct {
+	Quantity *int64 `json:"quantity" binding:"required,min=0,max=100"`
+}
+
+func (c *RedeemController) SetCartItem(ctx *gin.Context) {
+	agUser, err := c.findUser(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	req := &cartItemRequest{}
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		ctx.Error(bindError(err))
+		return
+	}
+
+	cart, err := c.cart.SetItem(ctx.Request.Context(), agUser, ctx.Param("sku"), *req.Quantity)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"status": "ok",
+		"cart":   cart,
+	})
+}
+
+func (c *RedeemController) ClearCart(ctx *gin.Context) {
+	agUser, err := c.findUser(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	if err := c.cart.Clear(ctx.Request.Context(), agUser); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"status": "ok",
+	})
+}
+
+func (c *RedeemController) Checkout(ctx *gin.Context) {
+	agUser, err := c.findUser(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	info := &utils.AugmontCheckoutInfo{}
+	if err := ctx.ShouldBindJSON(info); err != nil {
+		ctx.Error(bindError(err))
+		return
+	}
+
+	order, err := c.cart.Checkout(ctx.Request.Context(), agUser, info)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"status": "ok",
+		"order":  order,
+	})
+}
+
+func (c *RedeemController) GetShipment(ctx *gin.Context) {
+	agUser, err := c.findUser(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	timeline, err := c.shipments.Timeline(ctx.Request.Context(), agUser, ctx.Param("txnID"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"status":   "ok",
+		"shipment": timeline,
+	})
+}
+
+// callbackSecretHeader carries the secret shared with Augmont
+const callbackSecretHeader = "X-Callback-Secret"
+
+func (c *RedeemController) ShipmentCallback(ctx *gin.Context) {
+	if !secretMatches(domain.Config().Augmont.CallbackSecret, ctx.GetHeader(callbackSecretHeader)) {
+		ctx.Error(domain.NewError(errors.New("invalid callback secret"), domain.ErrForbidden))
+		return
+	}
+
+	info := &utils.AugmontShipmentCallback{}
+	if err := ctx.ShouldBindJSON(info); err != nil {
+		ctx.Error(bindError(err))
+		return
+	}
+
+	if err := c.shipments.Callback(ctx.Request.Context(), info); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"status": "ok",
+	})
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+type ScheduleController struct {
+	scheduler interfaces.SchedulerService
+}
+
+// NewScheduleController creates the scheduled task endpoints of the admins
+func NewScheduleController(router *gin.Engine, scheduler interfaces.SchedulerService) {
+	c := &ScheduleController{
+		scheduler: scheduler,
+	}
+
+	group := router.Group("/admin/schedules", AdminOnly)
+	// Scheduled tasks with their last and next run
+	// access -> admin
+	group.GET("", c.List)
+	// Run history of a task
+	// access -> admin
+	group.GET(":name/runs", c.ListRuns)
+}
+
+func (c *ScheduleController) List(ctx *gin.Context) {
+	schedules, leader, err := c.scheduler.List(ctx.Request.Context())
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"status":    "ok",
+		"schedules": schedules,
+		"leader":    leader,
+	})
+}
+
+// scheduleRunFilters are the filters of the runs
+type scheduleRunFilters struct {
+	Outcome string `form:"outcome" binding:"omitempty,oneof=running succeeded failed"`
+}
+
+func (c *ScheduleController) ListRuns(ctx *gin.Context) {
+	filters := &scheduleRunFilters{}
+	q, err := bindListQuery(ctx, filters)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	if filters.Outcome != "" {
+		q.Where("outcome", utils.FilterEq, filters.Outcome)
+	}
+
+	runs, page, err := c.scheduler.ListRuns(ctx.Request.Context(), ctx.Param("name"), q)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"status": "ok",
+		"runs":   runs,
+		"page":   page,
+	})
+}
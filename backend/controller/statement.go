@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+type StatementController struct {
+	statements  interfaces.AugmontStatementService
+	augmontUser interfaces.AugmontUserRepo
+}
+
+// NewStatementController creates the statement endpoints,
+// statements are generated in the background and polled
+func NewStatementController(
+	router *gin.Engine,
+	statements interfaces.AugmontStatementService,
+	au interfaces.AugmontUserRepo,
+) {
+	c := &StatementController{
+		statements:  statements,
+		augmontUser: au,
+	}
+
+	group := router.Group("/gold/statements")
+	{
+		group.POST("", c.Create)
+		group.GET("/:id", c.FindByID)
+	}
+}
+
+// statementRequest is the range and the format of a statement
+type statementRequest struct {
+	From   string `json:"from" binding:"required,date"`
+	To     string `json:"to" binding:"required,date"`
+	Format string `json:"format" binding:"required,oneof=csv pdf"`
+}
+
+func (c *StatementController) Create(ctx *gin.Context) {
+	user, err := getPinchUserFromContext(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	agUser, err := c.augmontUser.FindUser(ctx.Request.Context(), &models.AugmontUser{
+		UserID: user.ID,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	req := &statementRequest{}
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		ctx.Error(bindError(err))
+		return
+	}
+	// Both are valid dates after binding
+	from, _ := utils.ParseDate(req.From)
+	to, _ := utils.ParseDate(req.To)
+	if to.Before(from) {
+		ctx.Error(invalidFields(errors.New("statement ends before it starts"),
+			fieldError("to", "date_range", "from")))
+		return
+	}
+
+	statement, err := c.statements.Request(ctx.Request.Context(), agUser, from, to, req.Format)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{
+		"status":    "ok",
+		"statement": statement,
+	})
+}
+
+func (c *StatementController) FindByID(ctx *gin.Context) {
+	id, err := ParseUint64(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(domain.NewError(err, domain.ErrInvalidArgument, "invalid statement id"))
+		return
+	}
+
+	user, err := getPinchUserFromContext(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	agUser, err := c.augmontUser.FindUser(ctx.Request.Context(), &models.AugmontUser{
+		UserID: user.ID,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	statement, url, err := c.statements.Find(ctx.Request.Context(), agUser, id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{
+		"status":    "ok",
+		"statement": statement,
+		// Signed and short lived, empty until the statement is ready
+		"downloadUrl": url,
+	})
+}
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"bytes"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/document"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/tax"
+)
+
+type TaxController struct {
+	tax         interfaces.AugmontTaxService
+	augmontUser interfaces.AugmontUserRepo
+	user        interfaces.UserRepo
+}
+
+// NewTaxController creates the capital gains endpoints
+func NewTaxController(
+	router *gin.Engine,
+	taxService interfaces.AugmontTaxService,
+	au interfaces.AugmontUserRepo,
+	user interfaces.UserRepo,
+) {
+	c := &TaxController{
+		tax:         taxService,
+		augmontUser: au,
+		user:        user,
+	}
+	router.GET("/gold/tax-report", c.Report)
+}
+
+// taxReportQuery is the financial year of the report, json
+// returns the report, csv and pdf download the summary
+type taxReportQuery struct {
+	FY     string `form:"fy" binding:"required,fy"`
+	Format string `form:"format" binding:"omitempty,oneof=json csv pdf"`
+}
+
+func (c *TaxController) Report(ctx *gin.Context) {
+	user, err := getPinchUserFromContext(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	agUser, err := c.augmontUser.FindUser(ctx.Request.Context(), &models.AugmontUser{
+		UserID: user.ID,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	q := &taxReportQuery{}
+	if err := ctx.ShouldBindQuery(q); err != nil {
+		ctx.Error(bindError(err))
+		return
+	}
+
+	report, err := c.tax.Report(ctx.Request.Context(), agUser, q.FY)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	var (
+		buf         bytes.Buffer
+		contentType string
+	)
+	switch q.Format {
+	case "", "json":
+		ctx.JSON(200, gin.H{
+			"status": "ok",
+			"report": report,
+		})
+		return
+	case "csv":
+		contentType = "text/csv"
+		err = tax.WriteCSV(&buf, report)
+	case "pdf":
+		contentType = "application/pdf"
+		// The holder is named on the report
+		var profile *models.User
+		profile, err = c.user.FindOne(ctx.Request.Context(), &models.User{ID: user.ID})
+		if err != nil {
+			break
+		}
+		holder := document.Holder{
+			Name:      deref(profile.Name),
+			Mobile:    deref(profile.Mobile),
+			AccountID: deref(agUser.UID),
+		}
+		err = tax.WritePDF(&buf, holder, report, time.Now())
+	}
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": "pinch-capital-gains-" + report.FY + "." + q.Format,
+	}))
+	ctx.Data(http.StatusOK, contentType, buf.Bytes())
+}
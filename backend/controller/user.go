@@ -6,6 +6,7 @@ import (
 	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
 	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
 	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
 )
 
 type UserController struct {
@@ -33,18 +34,16 @@ func (c *UserController) Create(ctx *gin.Context) {
 	user := &models.User{}
 
 	// Bind user data to user struct
-	err := ctx.BindJSON(user)
+	err := ctx.ShouldBindJSON(user)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(bindError(err))
 		return
 	}
 
 	// Create User
-	err = c.user.Create(user)
+	err = c.user.Create(ctx.Request.Context(), user)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 	ctx.JSON(200, gin.H{
@@ -57,16 +56,14 @@ func (c *UserController) FindByID(ctx *gin.Context) {
 	id := ctx.Param("id")
 	userID, err := ParseUint64(id)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(domain.NewError(err, domain.ErrInvalidArgument, "invalid user id"))
 		return
 	}
 
 	// Find User
-	user, err := c.user.FindOne(&models.User{ID: &userID})
+	user, err := c.user.FindOne(ctx.Request.Context(), &models.User{ID: &userID})
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 	ctx.JSON(200, gin.H{
@@ -75,16 +72,39 @@ func (c *UserController) FindByID(ctx *gin.Context) {
 	})
 }
 
+// userFilters are the filters of the user list
+type userFilters struct {
+	KYCStatus    string `form:"kycStatus" binding:"omitempty,oneof=none pending approved rejected"`
+	MobilePrefix string `form:"mobilePrefix" binding:"omitempty,number,max=10"`
+}
+
 func (c *UserController) FindAll(ctx *gin.Context) {
-	users, err := c.user.FindAll()
+	filters := &userFilters{}
+	q, err := bindListQuery(ctx, filters)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	switch filters.KYCStatus {
+	case "":
+	case "none":
+		q.Where("kycStatus", utils.FilterNull, nil)
+	default:
+		q.Where("kycStatus", utils.FilterEq, filters.KYCStatus)
+	}
+	if filters.MobilePrefix != "" {
+		q.Where("mobile", utils.FilterPrefix, filters.MobilePrefix)
+	}
+
+	users, page, err := c.user.List(ctx.Request.Context(), q)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 	ctx.JSON(200, gin.H{
 		"status": "ok",
 		"users":  users,
+		"page":   page,
 	})
 }
 
@@ -92,26 +112,23 @@ func (c *UserController) UpdateByID(ctx *gin.Context) {
 	id := ctx.Param("id")
 	userID, err := ParseUint64(id)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(domain.NewError(err, domain.ErrInvalidArgument, "invalid user id"))
 		return
 	}
 
 	user := &models.User{}
 	// Bind user data to user struct
-	err = ctx.BindJSON(user)
+	err = ctx.ShouldBindJSON(user)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(bindError(err))
 		return
 	}
 	user.ID = &userID
 
 	// Update User
-	err = c.user.Update(user)
+	err = c.user.Update(ctx.Request.Context(), user)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 	ctx.JSON(200, gin.H{
@@ -124,8 +141,7 @@ func (c *UserController) DeleteByID(ctx *gin.Context) {
 	id := ctx.Param("id")
 	userID, err := ParseUint64(id)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(domain.NewError(err, domain.ErrInvalidArgument, "invalid user id"))
 		return
 	}
 
@@ -134,10 +150,9 @@ func (c *UserController) DeleteByID(ctx *gin.Context) {
 	}
 
 	// Delete User
-	err = c.user.Delete(user)
+	err = c.user.Delete(ctx.Request.Context(), user)
 	if err != nil {
-		domain.ErrLog(err)
-		domain.ErrFailedGinReq(ctx, err)
+		ctx.Error(err)
 		return
 	}
 	ctx.JSON(200, gin.H{
@@ -6,3 +6,11 @@ func ParseUint64(str string) (uint64, error) {
 	id, err := strconv.ParseUint(str, 10, 64)
 	return id, err
 }
+
+// deref returns the value of an optional string, empty if unset
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/i18n"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+// validators are the custom binding tags, e.g. `binding:"required,mobile"`
+var validators = map[string]func(string) bool{
+	"mobile":  utils.IsIndianMobile,
+	"ifsc":    utils.IsIFSC,
+	"pan":     utils.IsPAN,
+	"pincode": utils.IsPincode,
+	"date":    utils.IsDate,
+	"grams":   utils.IsGrams,
+	"amount":  utils.IsAmount,
+	"fy":      utils.IsFY,
+	"metal":   utils.IsMetal,
+	"clock":   utils.IsClock,
+}
+
+var registerOnce sync.Once
+
+// RegisterValidators adds the custom validators to the gin binding
+// validator and names fields in errors by their json or form name
+func RegisterValidators() {
+	registerOnce.Do(func() {
+		v, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			return
+		}
+		v.RegisterTagNameFunc(fieldName)
+		for tag, fn := range validators {
+			fn := fn
+			v.RegisterValidation(tag, func(fl validator.FieldLevel) bool {
+				return fn(fl.Field().String())
+			})
+		}
+	})
+}
+
+// fieldName returns the name of the field as sent by the client
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// validationError renders validator errors as field errors
+func validationError(err error, validationErrs validator.ValidationErrors) error {
+	fields := make([]domain.FieldError, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		// Drop the struct name, keep the path of nested fields, e.g. product[0].sku
+		field := fe.Namespace()
+		if i := strings.Index(field, "."); i >= 0 {
+			field = field[i+1:]
+		}
+		tag, param := fe.Tag(), fe.Param()
+		if strings.HasPrefix(tag, "required_") {
+			tag, param = "required", ""
+		}
+		fields = append(fields, fieldError(field, tag, param))
+	}
+	return invalidFields(err, fields...)
+}
+
+// requireFields returns field errors for the empty fields, for rules
+// that differ between endpoints sharing a request struct
+func requireFields(fields map[string]string) error {
+	var missing []domain.FieldError
+	for name, value := range fields {
+		if value == "" {
+			missing = append(missing, fieldError(name, "required", ""))
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		return missing[i].Field < missing[j].Field
+	})
+	return invalidFields(errors.New("required fields missing"), missing...)
+}
+
+// fieldError builds the field error of a failed rule with the English message
+func fieldError(field, tag, param string) domain.FieldError {
+	msg := i18n.Translate(i18n.English, "validation."+tag, field+" is invalid", map[string]string{
+		"field": field,
+		"param": param,
+	})
+	return domain.FieldError{
+		Field:   field,
+		Code:    tag,
+		Param:   param,
+		Message: msg,
+	}
+}
+
+func invalidFields(err error, fields ...domain.FieldError) error {
+	e, _ := domain.AsError(domain.NewError(err, domain.ErrInvalidArgument, "invalid request"))
+	return e.WithCode("validation_failed", "Please correct the highlighted fields").
+		WithFields(fields...)
+}
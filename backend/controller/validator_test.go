@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+func TestBindValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	RegisterValidators()
+
+	bind := func(body string, obj interface{}) error {
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		ctx.Request.Header.Set("Content-Type", "application/json")
+		if err := ctx.ShouldBind(obj); err != nil {
+			return bindError(err)
+		}
+		return nil
+	}
+
+	t.Run("should accept valid requests", func(t *testing.T) {
+		err := bind(
+			`{"accountNumber":"123456789012","accountName":"A Kumar","ifscCode":"HDFC0001234"}`,
+			&utils.AugmontUserBankInfo{},
+		)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return field errors by json name", func(t *testing.T) {
+		err := bind(
+			`{"accountNumber":"12ab","ifscCode":"HDFC1234"}`,
+			&utils.AugmontUserBankInfo{},
+		)
+		e, ok := domain.AsError(err)
+		assert.True(t, ok)
+		assert.Equal(t, domain.ErrInvalidArgument, e.Type())
+		assert.Equal(t, "validation_failed", e.Code())
+		assert.Equal(t, []domain.FieldError{
+			{Field: "accountNumber", Code: "numeric", Message: "accountNumber must be a number"},
+			{Field: "accountName", Code: "required", Message: "accountName is required"},
+			{Field: "ifscCode", Code: "ifsc", Message: "Enter a valid IFSC code"},
+		}, e.Fields())
+	})
+
+	t.Run("should validate nested fields and either rules", func(t *testing.T) {
+		err := bind(
+			`{"lockPrice":"5000.00","metalType":"gold","blockId":"b1","quantity":"-1"}`,
+			&utils.AugmontBugInfo{},
+		)
+		e, _ := domain.AsError(err)
+		assert.Equal(t, []domain.FieldError{
+			{Field: "quantity", Code: "grams", Message: "Enter a quantity in grams with up to 4 decimals"},
+		}, e.Fields())
+
+		err = bind(`{"userAddressId":"a1","product":[{"quantity":"1"}]}`, &utils.AugmontRedeemInfo{})
+		e, _ = domain.AsError(err)
+		assert.Equal(t, "product[0].sku", e.Fields()[0].Field)
+	})
+
+	t.Run("should require one of quantity and amount", func(t *testing.T) {
+		err := bind(`{"lockPrice":"5000","metalType":"gold","blockId":"b1"}`, &utils.AugmontBugInfo{})
+		e, _ := domain.AsError(err)
+		assert.Len(t, e.Fields(), 2)
+		assert.Equal(t, "required", e.Fields()[0].Code)
+	})
+}
@@ -1,10 +1,12 @@
 package domain
 
 import (
-	"log"
+	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
+	log "github.com/sirupsen/logrus"
 )
 
 // config is to store env variables
@@ -12,11 +14,28 @@ type config struct {
 	// Server configurations
 	Server struct {
 		Port string `envconfig:"SERVER_PORT" default:"8080"`
-		Host string `envconfig:"SERVER_HOST" default:"localhost"`
+		// Empty host listens on all interfaces
+		Host string `envconfig:"SERVER_HOST" default:""`
+
+		// HTTP server timeouts
+		ReadTimeout  time.Duration `envconfig:"SERVER_READ_TIMEOUT" default:"15s"`
+		WriteTimeout time.Duration `envconfig:"SERVER_WRITE_TIMEOUT" default:"30s"`
+		IdleTimeout  time.Duration `envconfig:"SERVER_IDLE_TIMEOUT" default:"60s"`
+
+		// Internal listener of the prometheus metrics, not to be exposed
+		// with the API, empty turns it off
+		MetricsAddr string `envconfig:"METRICS_ADDR" default:":9090"`
+
+		// Time given to in-flight requests and workers on shutdown
+		ShutdownTimeout time.Duration `envconfig:"SERVER_SHUTDOWN_TIMEOUT" default:"30s"`
 
 		// Server environment dev/prod
 		Env     string `envconfig:"SERVER_ENV" default:"dev"`
 		GormLog string `envconfig:"GORM_LOG" default:"error"`
+		// Log level debug/info/warn/error
+		LogLevel string `envconfig:"LOG_LEVEL" default:"info"`
+		// Shared secret of the admin endpoints, they are refused without it
+		AdminSecret string `envconfig:"ADMIN_SECRET"`
 	}
 
 	Url struct {
@@ -32,11 +51,105 @@ type config struct {
 		RedisPassword string `envconfig:"REDIS_PASSWORD"`
 	}
 
+	Tracing struct {
+		// Span exporter, none/stdout/otlp
+		Exporter string `envconfig:"TRACING_EXPORTER" default:"none"`
+		// OTLP HTTP collector host:port
+		OtlpEndpoint string `envconfig:"TRACING_OTLP_ENDPOINT" default:"localhost:4318"`
+		OtlpInsecure bool   `envconfig:"TRACING_OTLP_INSECURE" default:"true"`
+
+		ServiceName string  `envconfig:"TRACING_SERVICE_NAME" default:"pinch-backend"`
+		SampleRatio float64 `envconfig:"TRACING_SAMPLE_RATIO" default:"1"`
+	}
+
+	Health struct {
+		// Dependencies whose failure marks the pod as not ready,
+		// others only report degraded
+		Critical []string `envconfig:"HEALTH_CRITICAL" default:"postgres,redis"`
+		// Timeout of each dependency check
+		Timeout time.Duration `envconfig:"HEALTH_CHECK_TIMEOUT" default:"2s"`
+		// Least time between two logins to Augmont by the readiness
+		// probe, when no token is stored
+		TokenRefresh time.Duration `envconfig:"HEALTH_TOKEN_REFRESH" default:"1m"`
+	}
+
+	Storage struct {
+		// Directory of the local file storage
+		Dir string `envconfig:"STORAGE_DIR" default:"./data/files"`
+		// HMAC key of the download URLs, required outside dev
+		SigningKey string `envconfig:"STORAGE_SIGNING_KEY"`
+		// Life of the download URLs
+		URLExpiry time.Duration `envconfig:"STORAGE_URL_EXPIRY" default:"15m"`
+	}
+
+	Tax struct {
+		// Cost inflation indices of years missing from the tax
+		// package, by financial year, e.g. 2026-27:390
+		CII map[string]int64 `envconfig:"TAX_CII"`
+	}
+
+	Limits struct {
+		// Per order limits by metal, e.g. gold:10,silver:10,
+		// metals left out have no limit
+		MinAmount   map[string]float64 `envconfig:"ORDER_MIN_AMOUNT" default:"gold:10,silver:10"`
+		MaxAmount   map[string]float64 `envconfig:"ORDER_MAX_AMOUNT" default:"gold:200000,silver:200000"`
+		MaxQuantity map[string]float64 `envconfig:"ORDER_MAX_QUANTITY" default:"gold:30,silver:2000"`
+	}
+
+	Notify struct {
+		// Provider of each channel, log or file, channels left out are not sent
+		Providers map[string]string `envconfig:"NOTIFY_PROVIDERS" default:"sms:log,email:log,push:log"`
+		// Directory the file providers write to
+		Dir string `envconfig:"NOTIFY_DIR" default:"./data/notifications"`
+		// Quiet hours of users without their own, HH:MM-HH:MM in IST
+		QuietHours  string `envconfig:"NOTIFY_QUIET_HOURS" default:"22:00-08:00"`
+		MaxAttempts int    `envconfig:"NOTIFY_MAX_ATTEMPTS" default:"5"`
+	}
+
+	Events struct {
+		// Redis stream the outbox relay publishes the events to
+		Stream string `envconfig:"EVENTS_STREAM" default:"pinch:events"`
+		// Approximate length the stream is trimmed to
+		MaxLen int64 `envconfig:"EVENTS_MAX_LEN" default:"100000"`
+		// How often the relay looks for new events in the outbox
+		RelayInterval time.Duration `envconfig:"EVENTS_RELAY_INTERVAL" default:"1s"`
+		// Events not acknowledged for longer are delivered to another consumer
+		ClaimIdle time.Duration `envconfig:"EVENTS_CLAIM_IDLE" default:"1m"`
+		// Redis stream the events no consumer can decode or handle are moved to
+		DeadStream string `envconfig:"EVENTS_DEAD_STREAM" default:"pinch:events:dead"`
+		// Deliveries of an event to a group before it is moved to the dead stream
+		MaxDeliveries int64 `envconfig:"EVENTS_MAX_DELIVERIES" default:"10"`
+	}
+
+	Jobs struct {
+		// Workers of each queue, queues left out get one
+		Concurrency map[string]int `envconfig:"JOBS_CONCURRENCY" default:"invoices:4,kyc:2"`
+		MaxAttempts int            `envconfig:"JOBS_MAX_ATTEMPTS" default:"8"`
+		// How long a job may run before it is given to another worker
+		Lease time.Duration `envconfig:"JOBS_LEASE" default:"5m"`
+		// Wait of idle workers before looking for jobs again
+		PollInterval time.Duration `envconfig:"JOBS_POLL_INTERVAL" default:"1s"`
+	}
+
+	Scheduler struct {
+		// Cron expressions replacing the defaults of the tasks, in IST,
+		// e.g. products-sync:0 4 * * *, off turns a task off
+		Specs map[string]string `envconfig:"SCHEDULER_SPECS"`
+		// The leader runs the tasks until it fails to renew the lease
+		Lease time.Duration `envconfig:"SCHEDULER_LEASE" default:"30s"`
+	}
+
 	Augmont struct {
 		// Augmont API Host
 		Host     string `envconfig:"AUGMONT_HOST" required:"true"`
 		Email    string `envconfig:"AUGMONT_EMAIL" required:"true"`
 		Password string `envconfig:"AUGMONT_PASSWORD" required:"true"`
+		// Shared secret of the order callbacks, callbacks are refused without it
+		CallbackSecret string `envconfig:"AUGMONT_CALLBACK_SECRET"`
+		// Account holding the gifts to users without KYC until they claim them
+		EscrowUID string `envconfig:"AUGMONT_ESCROW_UID"`
+		// Wait before checking a submitted KYC, then it is retried until decided
+		KYCCheckDelay time.Duration `envconfig:"AUGMONT_KYC_CHECK_DELAY" default:"2m"`
 	}
 }
 
@@ -47,9 +160,9 @@ var cfg *config
 // and  returns the config struct
 func Config() *config {
 	if cfg == nil {
-		// Load env variables from .env file
+		// Load env variables from .env file, if there is one
 		err := godotenv.Load()
-		if err != nil {
+		if err != nil && !os.IsNotExist(err) {
 			log.Fatal(err)
 		}
 
@@ -62,3 +175,18 @@ func Config() *config {
 	}
 	return cfg
 }
+
+// devSigningKey signs the download URLs in dev, when no key is set
+const devSigningKey = "pinch-dev-signing-key"
+
+// SigningKey returns the key of the download URLs,
+// nil outside dev if STORAGE_SIGNING_KEY is not set
+func (c *config) SigningKey() []byte {
+	if c.Storage.SigningKey != "" {
+		return []byte(c.Storage.SigningKey)
+	}
+	if c.Server.Env == "dev" {
+		return []byte(devSigningKey)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+// Package configtest loads the config in tests, without the env of a
+// deployment
+package configtest
+
+import (
+	"testing"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+)
+
+// Use sets the env the config requires and loads the config, the changes
+// the test makes to it are undone when the test ends
+func Use(t testing.TB) {
+	t.Helper()
+	t.Setenv("POSTGRES_URL", "postgres://localhost/pinch")
+	t.Setenv("REDIS_URL", "localhost:6379")
+	t.Setenv("AUGMONT_HOST", "http://localhost")
+	t.Setenv("AUGMONT_EMAIL", "test@example.com")
+	t.Setenv("AUGMONT_PASSWORD", "test")
+
+	conf := domain.Config()
+	saved := *conf
+	t.Cleanup(func() { *conf = saved })
+}
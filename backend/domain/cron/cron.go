@@ -0,0 +1,172 @@
+// Package cron parses cron expressions and finds their next run.
+//
+// Expressions have the five standard fields, minute hour day-of-month
+// month day-of-week, each a *, a value, a range a-b or a list of them,
+// with an optional step /n. Days of the week are 0-6 from Sunday, 7 is
+// also Sunday. Like cron, when both day fields are restricted a day
+// matching either runs. @hourly, @daily, @weekly and @monthly are
+// shorthands.
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+var shorthands = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+}
+
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = []field{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// Schedule is a parsed cron expression
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// Day fields left as *, matching every day
+	anyDOM, anyDOW bool
+}
+
+// Parse parses the cron expression
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if expanded, ok := shorthands[spec]; ok {
+		spec = expanded
+	}
+	parts := strings.Fields(spec)
+	if len(parts) != len(fields) {
+		return nil, errors.Newf("cron %q has %d fields, want %d", spec, len(parts), len(fields))
+	}
+
+	sets := make([]uint64, len(fields))
+	for i, f := range fields {
+		set, err := parseField(parts[i], f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cron %q", spec)
+		}
+		sets[i] = set
+	}
+	// 7 is Sunday too
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+	return &Schedule{
+		minute: sets[0],
+		hour:   sets[1],
+		dom:    sets[2],
+		month:  sets[3],
+		dow:    sets[4],
+		anyDOM: parts[2] == "*",
+		anyDOW: parts[4] == "*",
+	}, nil
+}
+
+// parseField returns the values of the field as a bit set
+func parseField(s string, f field) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(s, ",") {
+		lo, hi, step := f.min, f.max, 1
+		rng := item
+		if i := strings.Index(item, "/"); i >= 0 {
+			n, err := strconv.Atoi(item[i+1:])
+			if err != nil || n < 1 {
+				return 0, errors.Newf("invalid step in %v %q", f.name, item)
+			}
+			rng, step = item[:i], n
+		}
+		if rng != "*" {
+			var err error
+			if i := strings.Index(rng, "-"); i >= 0 {
+				lo, err = parseValue(rng[:i], f)
+				if err == nil {
+					hi, err = parseValue(rng[i+1:], f)
+				}
+			} else {
+				lo, err = parseValue(rng, f)
+				// A value with a step runs from it to the max, e.g. 5/15
+				hi = lo
+				if step > 1 {
+					hi = f.max
+				}
+			}
+			if err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, errors.Newf("invalid range in %v %q", f.name, item)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+func parseValue(s string, f field) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, errors.Newf("invalid %v %q, want %d-%d", f.name, s, f.min, f.max)
+	}
+	return v, nil
+}
+
+func has(set uint64, v int) bool {
+	return set&(1<<uint(v)) != 0
+}
+
+// matchDay returns if the schedule runs on the day of t
+func (s *Schedule) matchDay(t time.Time) bool {
+	dom, dow := has(s.dom, t.Day()), has(s.dow, int(t.Weekday()))
+	if s.anyDOM || s.anyDOW {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// Next returns the first run after t, in the location of t, or the
+// zero time if the schedule never runs, e.g. on February 30
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	// Every schedule that runs does so within 4 years, leap days included,
+	// so the search stops after 5
+	end := t.AddDate(5, 0, 0)
+	for t.Before(end) {
+		if !has(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !has(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if !has(s.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
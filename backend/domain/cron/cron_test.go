@@ -0,0 +1,85 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+func at(s string) time.Time {
+	t, err := time.ParseInLocation("2006-01-02 15:04", s, utils.IST)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestParse(t *testing.T) {
+	t.Run("should reject invalid expressions", func(t *testing.T) {
+		for _, spec := range []string{
+			"", "* * * *", "* * * * * *", "60 * * * *", "* 24 * * *",
+			"* * 0 * *", "* * * 13 *", "* * * * 8", "5-1 * * * *",
+			"*/0 * * * *", "a * * * *", "@yearly",
+		} {
+			_, err := Parse(spec)
+			assert.Error(t, err, spec)
+		}
+	})
+
+	t.Run("should parse lists, ranges and steps", func(t *testing.T) {
+		s, err := Parse("0,30 9-17/4 * * 1-5")
+		assert.NoError(t, err)
+		assert.True(t, has(s.minute, 0) && has(s.minute, 30) && !has(s.minute, 15))
+		assert.True(t, has(s.hour, 9) && has(s.hour, 13) && has(s.hour, 17) && !has(s.hour, 10))
+		assert.True(t, has(s.dow, 1) && has(s.dow, 5) && !has(s.dow, 0))
+	})
+}
+
+func TestNext(t *testing.T) {
+	next := func(spec, from string) string {
+		s, err := Parse(spec)
+		if !assert.NoError(t, err, spec) {
+			return ""
+		}
+		return s.Next(at(from)).Format("2006-01-02 15:04")
+	}
+
+	t.Run("should find the next minute after the time", func(t *testing.T) {
+		assert.Equal(t, "2026-03-10 10:01", next("* * * * *", "2026-03-10 10:00"))
+		assert.Equal(t, "2026-03-10 10:15", next("*/15 * * * *", "2026-03-10 10:00"))
+		assert.Equal(t, "2026-03-10 10:05", next("5/15 * * * *", "2026-03-10 10:00"))
+		assert.Equal(t, "2026-03-11 03:30", next("30 3 * * *", "2026-03-10 03:30"))
+		assert.Equal(t, "2026-03-10 11:00", next("@hourly", "2026-03-10 10:59"))
+	})
+
+	t.Run("should roll over months and years", func(t *testing.T) {
+		assert.Equal(t, "2026-04-01 00:00", next("@monthly", "2026-03-10 10:00"))
+		assert.Equal(t, "2027-01-01 00:00", next("0 0 1 1 *", "2026-03-10 10:00"))
+		assert.Equal(t, "2028-02-29 00:00", next("0 0 29 2 *", "2026-03-10 10:00"))
+	})
+
+	t.Run("should match either day when both are set", func(t *testing.T) {
+		// 2026-03-10 is a Tuesday
+		assert.Equal(t, "2026-03-14 00:00", next("0 0 * * 6", "2026-03-10 10:00"))
+		assert.Equal(t, "2026-03-15 00:00", next("0 0 * * 7", "2026-03-10 10:00"))
+		assert.Equal(t, "2026-03-14 00:00", next("0 0 15 * 6", "2026-03-10 10:00"))
+		assert.Equal(t, "2026-03-15 00:00", next("0 0 15 * 0", "2026-03-14 10:00"))
+	})
+
+	t.Run("should keep the location of the time", func(t *testing.T) {
+		s, err := Parse("30 3 * * *")
+		assert.NoError(t, err)
+		n := s.Next(at("2026-03-10 10:00").UTC())
+		assert.Equal(t, time.UTC, n.Location())
+		assert.Equal(t, "2026-03-11 03:30", n.Format("2006-01-02 15:04"))
+	})
+
+	t.Run("should never run impossible dates", func(t *testing.T) {
+		s, err := Parse("0 0 30 2 *")
+		assert.NoError(t, err)
+		assert.True(t, s.Next(at("2026-03-10 10:00")).IsZero())
+	})
+}
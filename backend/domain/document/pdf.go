@@ -0,0 +1,164 @@
+// Package document renders the branded documents sent to the users
+package document
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// Branding of the documents
+const (
+	BrandName    = "Pinch"
+	BrandTagline = "Digital gold and silver, vaulted by Augmont"
+	BrandFooter  = "Equiseed Wealth Pvt. Ltd. This is a computer generated document and needs no signature."
+)
+
+// Colours of the brand as RGB
+var (
+	brandColor = [3]int{201, 154, 46}
+	inkColor   = [3]int{33, 33, 33}
+	mutedColor = [3]int{117, 117, 117}
+	rowColor   = [3]int{250, 246, 235}
+)
+
+// Holder is the account holder printed on the documents
+type Holder struct {
+	Name   string
+	Mobile string
+	// Augmont unique id of the user
+	AccountID string
+}
+
+// pageWidth is the printable width of an A4 page in mm
+const pageWidth = 190
+
+// Column is a column of a table, widths are in mm
+// and add up to the page width of 190
+type Column struct {
+	Title string
+	Width float64
+	// L or R
+	Align string
+}
+
+// PDF is an A4 document with the brand header and footer, the core
+// fonts only cover latin text so other scripts are not printed correctly
+type PDF struct {
+	pdf *fpdf.Fpdf
+	tr  func(string) string
+}
+
+// NewPDF returns a document with its first page
+func NewPDF() *PDF {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(10, 10, 10)
+	pdf.SetAutoPageBreak(true, 20)
+	pdf.AliasNbPages("")
+
+	pdf.SetHeaderFunc(func() {
+		pdf.SetFillColor(brandColor[0], brandColor[1], brandColor[2])
+		pdf.Rect(0, 0, 210, 18, "F")
+		pdf.SetXY(10, 5)
+		pdf.SetTextColor(255, 255, 255)
+		pdf.SetFont("Helvetica", "B", 16)
+		pdf.CellFormat(60, 8, BrandName, "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.CellFormat(pageWidth-60, 8, BrandTagline, "", 1, "R", false, 0, "")
+		pdf.SetY(24)
+	})
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetTextColor(mutedColor[0], mutedColor[1], mutedColor[2])
+		pdf.SetFont("Helvetica", "", 7)
+		pdf.CellFormat(pageWidth-30, 5, BrandFooter, "T", 0, "L", false, 0, "")
+		pdf.CellFormat(30, 5, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()), "T", 0, "R", false, 0, "")
+	})
+	pdf.AddPage()
+
+	return &PDF{
+		pdf: pdf,
+		tr:  pdf.UnicodeTranslatorFromDescriptor(""),
+	}
+}
+
+// Title prints the title of the document
+func (p *PDF) Title(title string) {
+	p.pdf.SetTextColor(inkColor[0], inkColor[1], inkColor[2])
+	p.pdf.SetFont("Helvetica", "B", 13)
+	p.pdf.CellFormat(pageWidth, 8, p.tr(title), "", 1, "L", false, 0, "")
+}
+
+// Details prints label and value pairs, like the account holder
+func (p *PDF) Details(details [][2]string) {
+	for _, d := range details {
+		p.pdf.SetFont("Helvetica", "", 9)
+		p.pdf.SetTextColor(mutedColor[0], mutedColor[1], mutedColor[2])
+		p.pdf.CellFormat(35, 5, p.tr(d[0]), "", 0, "L", false, 0, "")
+		p.pdf.SetTextColor(inkColor[0], inkColor[1], inkColor[2])
+		p.pdf.CellFormat(pageWidth-35, 5, p.tr(d[1]), "", 1, "L", false, 0, "")
+	}
+	p.pdf.Ln(4)
+}
+
+// Section prints the heading of a section
+func (p *PDF) Section(name string) {
+	p.pdf.SetFont("Helvetica", "B", 11)
+	p.pdf.SetTextColor(brandColor[0], brandColor[1], brandColor[2])
+	p.pdf.CellFormat(pageWidth, 7, p.tr(name), "", 1, "L", false, 0, "")
+}
+
+// Note prints a paragraph of small muted text
+func (p *PDF) Note(text string) {
+	p.pdf.SetFont("Helvetica", "", 8)
+	p.pdf.SetTextColor(mutedColor[0], mutedColor[1], mutedColor[2])
+	p.pdf.MultiCell(pageWidth, 4, p.tr(text), "", "L", false)
+	p.pdf.Ln(2)
+}
+
+// Table prints the rows with the header repeated on every page,
+// the empty text is printed if there are no rows
+func (p *PDF) Table(columns []Column, rows [][]string, empty string) {
+	p.tableHeader(columns)
+	if len(rows) == 0 && empty != "" {
+		p.pdf.SetFont("Helvetica", "", 9)
+		p.pdf.SetTextColor(mutedColor[0], mutedColor[1], mutedColor[2])
+		p.pdf.CellFormat(pageWidth, 8, p.tr(empty), "", 1, "C", false, 0, "")
+	}
+
+	_, pageHeight := p.pdf.GetPageSize()
+	for i, row := range rows {
+		if p.pdf.GetY()+6 > pageHeight-20 {
+			p.pdf.AddPage()
+			p.tableHeader(columns)
+		}
+		p.pdf.SetFont("Helvetica", "", 7.5)
+		p.pdf.SetTextColor(inkColor[0], inkColor[1], inkColor[2])
+		p.pdf.SetFillColor(rowColor[0], rowColor[1], rowColor[2])
+		for j, column := range columns {
+			value := ""
+			if j < len(row) {
+				value = row[j]
+			}
+			p.pdf.CellFormat(column.Width, 6, p.tr(value), "", 0, column.Align, i%2 == 1, 0, "")
+		}
+		p.pdf.Ln(-1)
+	}
+	p.pdf.Ln(4)
+}
+
+func (p *PDF) tableHeader(columns []Column) {
+	p.pdf.SetFont("Helvetica", "B", 8)
+	p.pdf.SetTextColor(255, 255, 255)
+	p.pdf.SetFillColor(inkColor[0], inkColor[1], inkColor[2])
+	for _, column := range columns {
+		p.pdf.CellFormat(column.Width, 7, p.tr(column.Title), "", 0, column.Align, true, 0, "")
+	}
+	p.pdf.Ln(-1)
+}
+
+// Write writes the document
+func (p *PDF) Write(w io.Writer) error {
+	return p.pdf.Output(w)
+}
@@ -1,10 +1,9 @@
 package domain
 
 import (
-	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/errors"
-	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -14,6 +13,10 @@ const (
 	ErrNotFound               // ErrNotFound is returned when a resource is not found.
 	ErrInternalError          // ErrInternalError is returned when an internal error occurs.
 	ErrBadRequest             // ErrBadRequest is returned when a bad request is made.
+	ErrUnauthorized           // ErrUnauthorized is returned when the caller is not authenticated.
+	ErrForbidden              // ErrForbidden is returned when the caller may not access a resource.
+	ErrConflict               // ErrConflict is returned when a resource already exists or changed.
+	ErrUnavailable            // ErrUnavailable is returned when a dependency is down.
 )
 
 // Custom Error Type
@@ -23,8 +26,24 @@ type Error struct {
 	// Orginal Error
 	err error
 
+	// Machine readable error code for clients
+	code string
 	// User Friendly Message for UI
 	uiMsg string
+	// Per field validation errors, rendered next to form fields
+	fields []FieldError
+}
+
+// FieldError is a validation error of a single request field
+type FieldError struct {
+	// Field name as sent by the client
+	Field string `json:"field"`
+	// Machine readable error code
+	Code string `json:"code"`
+	// Parameter of the failed rule, e.g. the maximum length
+	Param string `json:"param,omitempty"`
+	// User Friendly Message for UI
+	Message string `json:"message"`
 }
 
 // Error interface implementation
@@ -33,6 +52,44 @@ func (e *Error) Error() string {
 	return e.err.Error()
 }
 
+// Unwrap returns the original error
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Type returns the error type, one of the ErrTypes constants
+func (e *Error) Type() int {
+	return e.errType
+}
+
+// Code returns the machine readable error code
+func (e *Error) Code() string {
+	return e.code
+}
+
+// UIMsg returns the user friendly message
+func (e *Error) UIMsg() string {
+	return e.uiMsg
+}
+
+// WithCode overrides the error code and the user friendly message
+func (e *Error) WithCode(code, uiMsg string) *Error {
+	e.code = code
+	e.uiMsg = uiMsg
+	return e
+}
+
+// Fields returns the per field validation errors
+func (e *Error) Fields() []FieldError {
+	return e.fields
+}
+
+// WithFields adds per field validation errors
+func (e *Error) WithFields(fields ...FieldError) *Error {
+	e.fields = append(e.fields, fields...)
+	return e
+}
+
 // uiMsgFromType returns the User Friendly message from error type
 func uiMsgFromType(errType int) string {
 	switch errType {
@@ -44,29 +101,76 @@ func uiMsgFromType(errType int) string {
 		return "Internal server error, Try again later"
 	case ErrBadRequest:
 		return "Bad request"
+	case ErrUnauthorized:
+		return "Please log in again"
+	case ErrForbidden:
+		return "You are not allowed to do this"
+	case ErrConflict:
+		return "Already exists"
+	case ErrUnavailable:
+		return "Service unavailable, Try again later"
 	}
 	return "Internal server error, Try again later"
 }
 
+// codeFromType returns the default error code of the error type
+func codeFromType(errType int) string {
+	switch errType {
+	case ErrInvalidArgument:
+		return "invalid_argument"
+	case ErrNotFound:
+		return "not_found"
+	case ErrBadRequest:
+		return "bad_request"
+	case ErrUnauthorized:
+		return "unauthorized"
+	case ErrForbidden:
+		return "forbidden"
+	case ErrConflict:
+		return "conflict"
+	case ErrUnavailable:
+		return "unavailable"
+	}
+	return "internal_error"
+}
+
 // Create New Custom Error with Error Type and Error Message
 func NewError(err error, errType int, hint ...string) error {
-	// To avoid current NewError Function from stack
-	depthOne := 1
-	errors.WithStackDepth(err, depthOne)
+	return newError(err, errType, hint...)
+}
+
+func newError(err error, errType int, hint ...string) *Error {
+	msg := strings.Join(hint, ": ")
+	if err == nil {
+		err = errors.NewWithDepth(2, msg)
+	} else {
+		// To avoid current NewError Function from stack
+		err = errors.WithStackDepth(err, 2)
+		if msg != "" {
+			err = errors.WithMessage(err, msg)
+		}
+	}
 
 	return &Error{
 		errType: errType,
-		err:     fmt.Errorf("%s: %s", hint, err),
+		err:     err,
+		code:    codeFromType(errType),
 		uiMsg:   uiMsgFromType(errType),
 	}
 }
 
+// AsError finds the domain error in the error chain
+func AsError(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
 // Checks if the error is a domain error with type
 func ErrIs(err error, errorType int) bool {
-	if err == nil {
-		return false
-	}
-	e, ok := err.(*Error)
+	e, ok := AsError(err)
 	if !ok {
 		return false
 	}
@@ -79,7 +183,7 @@ func ErrToWrap(err error) error {
 		return nil
 	}
 
-	e, ok := err.(*Error)
+	e, ok := AsError(err)
 	if !ok {
 		log.Warn("Error is not a domain error")
 		return err
@@ -92,7 +196,7 @@ func ErrLog(err error) {
 	if err == nil {
 		return
 	}
-	e, ok := err.(*Error)
+	e, ok := AsError(err)
 	if !ok {
 		// Not a domain error
 		log.Errorf("%+v", err)
@@ -100,19 +204,3 @@ func ErrLog(err error) {
 	}
 	log.Error(e.err)
 }
-
-// ErrFailedGinReq handles error from gin.Context
-func ErrFailedGinReq(c *gin.Context, err error) {
-	// If the err is nil, log warn
-	if err == nil {
-		log.Warn("FailedGinReq: Error is nil")
-		return
-	}
-
-	c.JSON(400, gin.H{
-		"status": "error",
-		"error": gin.H{
-			"message": err.Error(),
-		},
-	})
-}
@@ -0,0 +1,160 @@
+// Package events defines the domain events, saved to the outbox in the
+// transaction of the write that raised them and relayed to the event
+// bus, consumers may see an event more than once
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Event types
+const (
+	TypeBuyCompleted    = "buy_completed"
+	TypeSellCompleted   = "sell_completed"
+	TypeRedeemCompleted = "redeem_completed"
+	TypeKYCApproved     = "kyc_approved"
+	TypeKYCRejected     = "kyc_rejected"
+	TypeGiftSent        = "gift_sent"
+	TypeGiftClaimed     = "gift_claimed"
+)
+
+// Event is a domain event
+type Event interface {
+	EventType() string
+}
+
+// Envelope is an event read from the bus
+type Envelope struct {
+	// ID of the outbox row, the same on every delivery of the event
+	ID        uint64
+	Type      string
+	CreatedAt time.Time
+	Event     Event
+}
+
+// Order is the order of the order events
+type Order struct {
+	UserID        uint64 `json:"userId"`
+	AugmontUserID uint64 `json:"goldUserId"`
+	MerchantTxnID string `json:"merchantTxnID"`
+	MetalType     string `json:"metalType"`
+	// Grams
+	Quantity string `json:"quantity"`
+	// Rupees paid or received, including taxes
+	Amount string `json:"amount"`
+}
+
+// BuyCompleted is a buy order placed with Augmont
+type BuyCompleted struct {
+	Order
+}
+
+func (BuyCompleted) EventType() string { return TypeBuyCompleted }
+
+// SellCompleted is a sell order placed with Augmont
+type SellCompleted struct {
+	Order
+}
+
+func (SellCompleted) EventType() string { return TypeSellCompleted }
+
+// Metal is the grams of a metal of an order
+type Metal struct {
+	MetalType string `json:"metalType"`
+	Quantity  string `json:"quantity"`
+}
+
+// RedeemCompleted is a redeem order placed with Augmont, the order has
+// the first metal delivered
+type RedeemCompleted struct {
+	Order
+	Metals []Metal `json:"metals"`
+}
+
+func (RedeemCompleted) EventType() string { return TypeRedeemCompleted }
+
+// KYC is the user of the KYC events
+type KYC struct {
+	UserID        uint64 `json:"userId"`
+	AugmontUserID uint64 `json:"goldUserId"`
+}
+
+// KYCApproved is a KYC verified by Augmont
+type KYCApproved struct {
+	KYC
+}
+
+func (KYCApproved) EventType() string { return TypeKYCApproved }
+
+// KYCRejected is a KYC Augmont could not verify
+type KYCRejected struct {
+	KYC
+}
+
+func (KYCRejected) EventType() string { return TypeKYCRejected }
+
+// Gift is the gift of the gift events
+type Gift struct {
+	GiftID uint64 `json:"giftId"`
+	// Augmont users, no recipient while the gift is in escrow
+	SenderID        uint64 `json:"senderGoldUserId"`
+	RecipientID     uint64 `json:"recipientGoldUserId"`
+	RecipientMobile string `json:"recipientMobile"`
+	// Transfer of the event
+	MerchantTxnID string `json:"merchantTxnID"`
+	MetalType     string `json:"metalType"`
+	// Grams
+	Quantity string `json:"quantity"`
+}
+
+// GiftSent is metal transferred from the sender to the recipient or to
+// the escrow
+type GiftSent struct {
+	Gift
+	Escrowed bool `json:"escrowed"`
+}
+
+func (GiftSent) EventType() string { return TypeGiftSent }
+
+// GiftClaimed is a gift transferred from the escrow to the recipient
+type GiftClaimed struct {
+	Gift
+}
+
+func (GiftClaimed) EventType() string { return TypeGiftClaimed }
+
+// registry creates an empty event of each type to decode into
+var registry = map[string]func() Event{
+	TypeBuyCompleted:    func() Event { return &BuyCompleted{} },
+	TypeSellCompleted:   func() Event { return &SellCompleted{} },
+	TypeRedeemCompleted: func() Event { return &RedeemCompleted{} },
+	TypeKYCApproved:     func() Event { return &KYCApproved{} },
+	TypeKYCRejected:     func() Event { return &KYCRejected{} },
+	TypeGiftSent:        func() Event { return &GiftSent{} },
+	TypeGiftClaimed:     func() Event { return &GiftClaimed{} },
+}
+
+// ErrUnknownType is returned when decoding an event of a type not in
+// this build, e.g. one added by a newer release
+var ErrUnknownType = errors.New("unknown event type")
+
+// Encode returns the JSON payload of the event
+func Encode(e Event) ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// Decode returns the event of the type from its JSON payload
+func Decode(eventType string, payload []byte) (Event, error) {
+	newEvent, ok := registry[eventType]
+	if !ok {
+		return nil, errors.Wrapf(ErrUnknownType, "%q", eventType)
+	}
+	e := newEvent()
+	if err := json.Unmarshal(payload, e); err != nil {
+		return nil, errors.Wrapf(err, "decode %v event", eventType)
+	}
+	return e, nil
+}
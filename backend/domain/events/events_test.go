@@ -0,0 +1,35 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode(t *testing.T) {
+	t.Run("should decode what was encoded", func(t *testing.T) {
+		buy := &BuyCompleted{Order: Order{UserID: 2, MerchantTxnID: "B1", MetalType: "gold", Quantity: "1.5", Amount: "9000"}}
+		payload, err := Encode(buy)
+		assert.NoError(t, err)
+
+		e, err := Decode(buy.EventType(), payload)
+		assert.NoError(t, err)
+		assert.Equal(t, buy, e)
+	})
+
+	t.Run("should tell unknown types apart", func(t *testing.T) {
+		_, err := Decode("order_refunded", []byte(`{}`))
+		assert.True(t, errors.Is(err, ErrUnknownType))
+
+		_, err = Decode(TypeKYCApproved, []byte(`not json`))
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, ErrUnknownType))
+	})
+
+	t.Run("should register every type", func(t *testing.T) {
+		for eventType, newEvent := range registry {
+			assert.Equal(t, eventType, newEvent().EventType())
+		}
+	})
+}
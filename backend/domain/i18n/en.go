@@ -0,0 +1,65 @@
+package i18n
+
+// en is the English catalogue, English error messages come from the
+// errors themselves so only validation and notification messages are here
+var en = map[string]string{
+	// Validation
+	"validation.required":     "{field} is required",
+	"validation.email":        "Enter a valid email address",
+	"validation.min":          "{field} must be at least {param}",
+	"validation.max":          "{field} must be at most {param}",
+	"validation.len":          "{field} must be {param} characters long",
+	"validation.oneof":        "{field} must be one of {param}",
+	"validation.numeric":      "{field} must be a number",
+	"validation.locale":       "Choose a supported language",
+	"validation.number":       "{field} must be a whole number",
+	"validation.ne":           "{field} can not be {param}",
+	"validation.mobile":       "Enter a valid 10 digit mobile number",
+	"validation.ifsc":         "Enter a valid IFSC code",
+	"validation.pan":          "Enter a valid PAN",
+	"validation.pincode":      "Enter a valid 6 digit pincode",
+	"validation.date":         "{field} must be a date as DD-MM-YYYY",
+	"validation.grams":        "Enter a quantity in grams with up to 4 decimals",
+	"validation.amount":       "Enter an amount in rupees with up to 2 decimals",
+	"validation.fy":           "Enter a financial year like 2025-26",
+	"validation.clock":        "Enter a time as HH:MM",
+	"validation.cursor":       "The page cursor is invalid, load the list again",
+	"validation.date_range":   "{field} must be on or after {param}",
+	"validation.metal":        "Choose gold or silver",
+	"validation.min_amount":   "Orders must be at least ₹{param}",
+	"validation.max_amount":   "Orders can be at most ₹{param}",
+	"validation.max_quantity": "Orders can be at most {param} g",
+	"validation.product":      "This product is no longer available",
+	"validation.stock":        "Only {param} left in stock",
+	"validation.balance":      "You hold only {param} g to deliver",
+	"validation.address":      "Choose one of your saved addresses",
+	"validation.serviceable":  "Delivery is not available to pincode {param}",
+	"validation.cart_empty":   "Add products to your cart first",
+	"validation.self_gift":    "You can not gift to your own mobile number",
+
+	// Metals
+	"metal.gold":   "gold",
+	"metal.silver": "silver",
+
+	// Notifications
+	"notification.kyc_approved.title":        "KYC approved",
+	"notification.kyc_approved.body":         "Your KYC is approved, you can now buy, sell and redeem.",
+	"notification.kyc_rejected.title":        "KYC rejected",
+	"notification.kyc_rejected.body":         "We could not verify your KYC, please submit your PAN again.",
+	"notification.buy_completed.title":       "Purchase successful",
+	"notification.buy_completed.body":        "You bought {quantity} g of {metal} for ₹{amount}.",
+	"notification.sell_completed.title":      "Sale successful",
+	"notification.sell_completed.body":       "You sold {quantity} g of {metal} for ₹{amount}.",
+	"notification.shipment_dispatched.title": "Order dispatched",
+	"notification.shipment_dispatched.body":  "Your order {txnID} has been dispatched.",
+	"notification.shipment_in_transit.title": "Order on its way",
+	"notification.shipment_in_transit.body":  "Your order {txnID} is on its way to you.",
+	"notification.shipment_delivered.title":  "Order delivered",
+	"notification.shipment_delivered.body":   "Your order {txnID} has been delivered.",
+	"notification.shipment_returned.title":   "Order returned",
+	"notification.shipment_returned.body":    "Your order {txnID} could not be delivered and is being returned, we will contact you.",
+	"notification.gift_received.title":       "You received a gift",
+	"notification.gift_received.body":        "{sender} gifted you {quantity} g of {metal}.",
+	"notification.gift_claimed.title":        "Your gift was claimed",
+	"notification.gift_claimed.body":         "{recipient} claimed the {quantity} g of {metal} you gifted.",
+}
@@ -0,0 +1,94 @@
+package i18n
+
+// hi is the Hindi catalogue
+var hi = map[string]string{
+	// Errors
+	"invalid_argument":             "अमान्य जानकारी",
+	"not_found":                    "नहीं मिला",
+	"internal_error":               "सर्वर में त्रुटि, कृपया बाद में पुनः प्रयास करें",
+	"bad_request":                  "अमान्य अनुरोध",
+	"unauthorized":                 "कृपया फिर से लॉग इन करें",
+	"forbidden":                    "आपको यह करने की अनुमति नहीं है",
+	"conflict":                     "यह पहले से मौजूद है",
+	"unavailable":                  "सेवा उपलब्ध नहीं है, कृपया बाद में पुनः प्रयास करें",
+	"validation_failed":            "कृपया चिह्नित फ़ील्ड ठीक करें",
+	"augmont.duplicate_mobile":     "यह मोबाइल नंबर पहले से पंजीकृत है",
+	"augmont.invalid_ifsc":         "मान्य IFSC कोड दर्ज करें",
+	"augmont.kyc_required":         "आगे बढ़ने के लिए अपना KYC पूरा करें",
+	"augmont.insufficient_balance": "इस लेनदेन के लिए आपके पास पर्याप्त बैलेंस नहीं है",
+	"augmont.rate_expired":         "दर की समय सीमा समाप्त हो गई है, कृपया पुनः प्रयास करें",
+
+	// Fields
+	"field.mobileNumber":  "मान्य मोबाइल नंबर दर्ज करें",
+	"field.emailId":       "मान्य ईमेल पता दर्ज करें",
+	"field.userPincode":   "मान्य पिनकोड दर्ज करें",
+	"field.pincode":       "मान्य पिनकोड दर्ज करें",
+	"field.dateOfBirth":   "अपनी जन्म तिथि DD-MM-YYYY के रूप में दर्ज करें",
+	"field.panNumber":     "मान्य PAN दर्ज करें",
+	"field.panAttachment": "अपने PAN कार्ड की साफ़ तस्वीर अपलोड करें",
+	"field.nameAsPerPan":  "अपना नाम PAN कार्ड के अनुसार दर्ज करें",
+	"field.accountNumber": "मान्य खाता संख्या दर्ज करें",
+	"field.ifscCode":      "मान्य IFSC कोड दर्ज करें",
+	"field.quantity":      "मान्य मात्रा दर्ज करें",
+	"field.amount":        "मान्य राशि दर्ज करें",
+
+	// Validation
+	"validation.required":     "{field} आवश्यक है",
+	"validation.email":        "मान्य ईमेल पता दर्ज करें",
+	"validation.min":          "{field} कम से कम {param} होना चाहिए",
+	"validation.max":          "{field} अधिकतम {param} होना चाहिए",
+	"validation.len":          "{field} {param} अक्षरों का होना चाहिए",
+	"validation.oneof":        "{field} इनमें से एक होना चाहिए: {param}",
+	"validation.numeric":      "{field} एक संख्या होनी चाहिए",
+	"validation.locale":       "कोई समर्थित भाषा चुनें",
+	"validation.number":       "{field} एक पूर्ण संख्या होनी चाहिए",
+	"validation.ne":           "{field} {param} नहीं हो सकता",
+	"validation.mobile":       "मान्य 10 अंकों का मोबाइल नंबर दर्ज करें",
+	"validation.ifsc":         "मान्य IFSC कोड दर्ज करें",
+	"validation.pan":          "मान्य PAN दर्ज करें",
+	"validation.pincode":      "मान्य 6 अंकों का पिनकोड दर्ज करें",
+	"validation.date":         "{field} DD-MM-YYYY के रूप में तारीख होनी चाहिए",
+	"validation.grams":        "ग्राम में मात्रा अधिकतम 4 दशमलव तक दर्ज करें",
+	"validation.amount":       "रुपये में राशि अधिकतम 2 दशमलव तक दर्ज करें",
+	"validation.fy":           "2025-26 जैसा वित्तीय वर्ष दर्ज करें",
+	"validation.clock":        "समय HH:MM के रूप में दर्ज करें",
+	"validation.cursor":       "पेज कर्सर अमान्य है, सूची फिर से लोड करें",
+	"validation.date_range":   "{field} {param} के बाद या उसी दिन की होनी चाहिए",
+	"validation.metal":        "सोना या चांदी चुनें",
+	"validation.min_amount":   "ऑर्डर कम से कम ₹{param} का होना चाहिए",
+	"validation.max_amount":   "ऑर्डर अधिकतम ₹{param} का हो सकता है",
+	"validation.max_quantity": "ऑर्डर अधिकतम {param} ग्राम का हो सकता है",
+	"validation.product":      "यह उत्पाद अब उपलब्ध नहीं है",
+	"validation.stock":        "स्टॉक में केवल {param} बचे हैं",
+	"validation.balance":      "डिलीवरी के लिए आपके पास केवल {param} ग्राम है",
+	"validation.address":      "अपने सहेजे गए पतों में से एक चुनें",
+	"validation.serviceable":  "पिनकोड {param} पर डिलीवरी उपलब्ध नहीं है",
+	"validation.cart_empty":   "पहले अपनी कार्ट में उत्पाद जोड़ें",
+	"validation.self_gift":    "आप अपने ही मोबाइल नंबर पर उपहार नहीं भेज सकते",
+
+	// Metals
+	"metal.gold":   "सोना",
+	"metal.silver": "चांदी",
+
+	// Notifications
+	"notification.kyc_approved.title":        "KYC स्वीकृत",
+	"notification.kyc_approved.body":         "आपका KYC स्वीकृत हो गया है, अब आप खरीद, बिक्री और रिडीम कर सकते हैं।",
+	"notification.kyc_rejected.title":        "KYC अस्वीकृत",
+	"notification.kyc_rejected.body":         "हम आपका KYC सत्यापित नहीं कर सके, कृपया अपना PAN फिर से जमा करें।",
+	"notification.buy_completed.title":       "खरीद सफल",
+	"notification.buy_completed.body":        "आपने ₹{amount} में {quantity} ग्राम {metal} खरीदा।",
+	"notification.sell_completed.title":      "बिक्री सफल",
+	"notification.sell_completed.body":       "आपने ₹{amount} में {quantity} ग्राम {metal} बेचा।",
+	"notification.shipment_dispatched.title": "ऑर्डर भेजा गया",
+	"notification.shipment_dispatched.body":  "आपका ऑर्डर {txnID} भेज दिया गया है।",
+	"notification.shipment_in_transit.title": "ऑर्डर रास्ते में है",
+	"notification.shipment_in_transit.body":  "आपका ऑर्डर {txnID} आपके पास आ रहा है।",
+	"notification.shipment_delivered.title":  "ऑर्डर डिलीवर हुआ",
+	"notification.shipment_delivered.body":   "आपका ऑर्डर {txnID} डिलीवर हो गया है।",
+	"notification.shipment_returned.title":   "ऑर्डर वापस लौटा",
+	"notification.shipment_returned.body":    "आपका ऑर्डर {txnID} डिलीवर नहीं हो सका और वापस भेजा जा रहा है, हम आपसे संपर्क करेंगे।",
+	"notification.gift_received.title":       "आपको उपहार मिला",
+	"notification.gift_received.body":        "{sender} ने आपको {quantity} ग्राम {metal} उपहार में दिया।",
+	"notification.gift_claimed.title":        "आपका उपहार स्वीकार किया गया",
+	"notification.gift_claimed.body":         "{recipient} ने आपके उपहार में दिया {quantity} ग्राम {metal} स्वीकार किया।",
+}
@@ -0,0 +1,113 @@
+package i18n
+
+import (
+	"context"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Supported Locales
+const (
+	English = "en"
+	Hindi   = "hi"
+	Tamil   = "ta"
+	Marathi = "mr"
+
+	// DefaultLocale is used when the client prefers none of the supported locales
+	DefaultLocale = English
+)
+
+// catalogues of messages by locale, message keys are error codes,
+// "validation.<tag>", "field.<name>" and "notification.<name>.<part>"
+var catalogues = map[string]map[string]string{
+	English: en,
+	Hindi:   hi,
+	Tamil:   ta,
+	Marathi: mr,
+}
+
+// supported is in the order of preference when the client has none
+var supported = []language.Tag{
+	language.English,
+	language.Hindi,
+	language.Tamil,
+	language.Marathi,
+}
+
+var matcher = language.NewMatcher(supported)
+
+// Locales returns the supported locales
+func Locales() []string {
+	locales := make([]string, 0, len(supported))
+	for _, tag := range supported {
+		locales = append(locales, tag.String())
+	}
+	return locales
+}
+
+// IsSupported checks if the locale has a message catalogue
+func IsSupported(locale string) bool {
+	_, ok := catalogues[locale]
+	return ok
+}
+
+// Negotiate picks the best supported locale for an Accept-Language header
+func Negotiate(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DefaultLocale
+	}
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLocale
+	}
+	return supported[index].String()
+}
+
+// T returns the message of the key in the locale with the {placeholders}
+// replaced by args, English is used when the locale misses the key
+func T(locale, key string, args map[string]string) (string, bool) {
+	msg, ok := catalogues[locale][key]
+	if !ok {
+		msg, ok = catalogues[DefaultLocale][key]
+	}
+	if !ok {
+		return "", false
+	}
+	if len(args) == 0 {
+		return msg, true
+	}
+
+	pairs := make([]string, 0, len(args)*2)
+	for k, v := range args {
+		pairs = append(pairs, "{"+k+"}", v)
+	}
+	return strings.NewReplacer(pairs...).Replace(msg), true
+}
+
+// Translate returns the message of the key in the locale, or the
+// fallback when no catalogue has the key
+func Translate(locale, key, fallback string, args map[string]string) string {
+	if msg, ok := T(locale, key, args); ok {
+		return msg
+	}
+	return fallback
+}
+
+type ctxKey int
+
+const localeKey ctxKey = iota
+
+// ContextWithLocale returns a copy of the context with the locale of the request
+func ContextWithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey, locale)
+}
+
+// Locale returns the locale of the request, or the default locale
+func Locale(ctx context.Context) string {
+	if locale, ok := ctx.Value(localeKey).(string); ok {
+		return locale
+	}
+	return DefaultLocale
+}
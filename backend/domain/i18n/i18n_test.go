@@ -0,0 +1,60 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	assert.Equal(t, Hindi, Negotiate("hi-IN,hi;q=0.9,en;q=0.8"))
+	assert.Equal(t, Tamil, Negotiate("ta"))
+	assert.Equal(t, English, Negotiate("en-IN"))
+	assert.Equal(t, DefaultLocale, Negotiate("fr-FR"))
+	assert.Equal(t, DefaultLocale, Negotiate(""))
+}
+
+func TestT(t *testing.T) {
+	t.Run("should replace placeholders", func(t *testing.T) {
+		msg, ok := T(English, "validation.required", map[string]string{"field": "mobile"})
+		assert.True(t, ok)
+		assert.Equal(t, "mobile is required", msg)
+	})
+
+	t.Run("should fall back to English", func(t *testing.T) {
+		msg, ok := T("xx", "metal.gold", nil)
+		assert.True(t, ok)
+		assert.Equal(t, "gold", msg)
+	})
+
+	t.Run("should miss unknown keys", func(t *testing.T) {
+		assert.Equal(t, "fallback", Translate(Hindi, "unknown", "fallback", nil))
+	})
+}
+
+func TestCataloguesComplete(t *testing.T) {
+	for locale, catalogue := range catalogues {
+		if locale == English {
+			continue
+		}
+		// Regional catalogues have every key of the Hindi one
+		for key := range hi {
+			assert.Contains(t, catalogue, key, "%s misses %s", locale, key)
+		}
+		// and every English key
+		for key := range en {
+			assert.Contains(t, catalogue, key, "%s misses %s", locale, key)
+		}
+	}
+}
+
+func TestLocale(t *testing.T) {
+	t.Run("should find the locale of the context", func(t *testing.T) {
+		assert.Equal(t, Tamil, Locale(ContextWithLocale(context.Background(), Tamil)))
+	})
+
+	t.Run("should default without a locale", func(t *testing.T) {
+		assert.Equal(t, DefaultLocale, Locale(context.Background()))
+	})
+}
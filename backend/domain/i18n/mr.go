@@ -0,0 +1,94 @@
+package i18n
+
+// mr is the Marathi catalogue
+var mr = map[string]string{
+	// Errors
+	"invalid_argument":             "अवैध माहिती",
+	"not_found":                    "सापडले नाही",
+	"internal_error":               "सर्व्हर त्रुटी, कृपया नंतर पुन्हा प्रयत्न करा",
+	"bad_request":                  "अवैध विनंती",
+	"unauthorized":                 "कृपया पुन्हा लॉग इन करा",
+	"forbidden":                    "तुम्हाला हे करण्याची परवानगी नाही",
+	"conflict":                     "हे आधीच अस्तित्वात आहे",
+	"unavailable":                  "सेवा उपलब्ध नाही, कृपया नंतर पुन्हा प्रयत्न करा",
+	"validation_failed":            "कृपया चिन्हांकित फील्ड दुरुस्त करा",
+	"augmont.duplicate_mobile":     "हा मोबाइल नंबर आधीच नोंदणीकृत आहे",
+	"augmont.invalid_ifsc":         "वैध IFSC कोड प्रविष्ट करा",
+	"augmont.kyc_required":         "पुढे जाण्यासाठी तुमचे KYC पूर्ण करा",
+	"augmont.insufficient_balance": "या व्यवहारासाठी तुमच्याकडे पुरेशी शिल्लक नाही",
+	"augmont.rate_expired":         "दराची मुदत संपली आहे, कृपया पुन्हा प्रयत्न करा",
+
+	// Fields
+	"field.mobileNumber":  "वैध मोबाइल नंबर प्रविष्ट करा",
+	"field.emailId":       "वैध ईमेल पत्ता प्रविष्ट करा",
+	"field.userPincode":   "वैध पिनकोड प्रविष्ट करा",
+	"field.pincode":       "वैध पिनकोड प्रविष्ट करा",
+	"field.dateOfBirth":   "तुमची जन्मतारीख DD-MM-YYYY स्वरूपात प्रविष्ट करा",
+	"field.panNumber":     "वैध PAN प्रविष्ट करा",
+	"field.panAttachment": "तुमच्या PAN कार्डचा स्पष्ट फोटो अपलोड करा",
+	"field.nameAsPerPan":  "PAN कार्डवर असल्याप्रमाणे तुमचे नाव प्रविष्ट करा",
+	"field.accountNumber": "वैध खाते क्रमांक प्रविष्ट करा",
+	"field.ifscCode":      "वैध IFSC कोड प्रविष्ट करा",
+	"field.quantity":      "वैध प्रमाण प्रविष्ट करा",
+	"field.amount":        "वैध रक्कम प्रविष्ट करा",
+
+	// Validation
+	"validation.required":     "{field} आवश्यक आहे",
+	"validation.email":        "वैध ईमेल पत्ता प्रविष्ट करा",
+	"validation.min":          "{field} किमान {param} असावे",
+	"validation.max":          "{field} जास्तीत जास्त {param} असावे",
+	"validation.len":          "{field} {param} अक्षरांचे असावे",
+	"validation.oneof":        "{field} यापैकी एक असावे: {param}",
+	"validation.numeric":      "{field} संख्या असावी",
+	"validation.locale":       "समर्थित भाषा निवडा",
+	"validation.number":       "{field} पूर्ण संख्या असावी",
+	"validation.ne":           "{field} {param} असू शकत नाही",
+	"validation.mobile":       "वैध 10 अंकी मोबाइल नंबर प्रविष्ट करा",
+	"validation.ifsc":         "वैध IFSC कोड प्रविष्ट करा",
+	"validation.pan":          "वैध PAN प्रविष्ट करा",
+	"validation.pincode":      "वैध 6 अंकी पिनकोड प्रविष्ट करा",
+	"validation.date":         "{field} DD-MM-YYYY स्वरूपातील तारीख असावी",
+	"validation.grams":        "ग्रॅममध्ये प्रमाण जास्तीत जास्त 4 दशांशांपर्यंत प्रविष्ट करा",
+	"validation.amount":       "रुपयांमध्ये रक्कम जास्तीत जास्त 2 दशांशांपर्यंत प्रविष्ट करा",
+	"validation.fy":           "2025-26 सारखे आर्थिक वर्ष प्रविष्ट करा",
+	"validation.clock":        "वेळ HH:MM स्वरूपात प्रविष्ट करा",
+	"validation.cursor":       "पेज कर्सर अवैध आहे, यादी पुन्हा लोड करा",
+	"validation.date_range":   "{field} {param} रोजी किंवा नंतरची असावी",
+	"validation.metal":        "सोने किंवा चांदी निवडा",
+	"validation.min_amount":   "ऑर्डर किमान ₹{param} ची असावी",
+	"validation.max_amount":   "ऑर्डर जास्तीत जास्त ₹{param} ची असू शकते",
+	"validation.max_quantity": "ऑर्डर जास्तीत जास्त {param} ग्रॅमची असू शकते",
+	"validation.product":      "हे उत्पादन आता उपलब्ध नाही",
+	"validation.stock":        "स्टॉकमध्ये फक्त {param} शिल्लक आहेत",
+	"validation.balance":      "डिलिव्हरीसाठी तुमच्याकडे फक्त {param} ग्रॅम आहे",
+	"validation.address":      "तुमच्या जतन केलेल्या पत्त्यांपैकी एक निवडा",
+	"validation.serviceable":  "पिनकोड {param} वर डिलिव्हरी उपलब्ध नाही",
+	"validation.cart_empty":   "आधी तुमच्या कार्टमध्ये उत्पादने जोडा",
+	"validation.self_gift":    "तुम्ही तुमच्याच मोबाइल नंबरवर भेट पाठवू शकत नाही",
+
+	// Metals
+	"metal.gold":   "सोने",
+	"metal.silver": "चांदी",
+
+	// Notifications
+	"notification.kyc_approved.title":        "KYC मंजूर",
+	"notification.kyc_approved.body":         "तुमचे KYC मंजूर झाले आहे, आता तुम्ही खरेदी, विक्री आणि रिडीम करू शकता.",
+	"notification.kyc_rejected.title":        "KYC नाकारले",
+	"notification.kyc_rejected.body":         "आम्ही तुमचे KYC पडताळू शकलो नाही, कृपया तुमचा PAN पुन्हा सबमिट करा.",
+	"notification.buy_completed.title":       "खरेदी यशस्वी",
+	"notification.buy_completed.body":        "तुम्ही ₹{amount} मध्ये {quantity} ग्रॅम {metal} खरेदी केले.",
+	"notification.sell_completed.title":      "विक्री यशस्वी",
+	"notification.sell_completed.body":       "तुम्ही ₹{amount} मध्ये {quantity} ग्रॅम {metal} विकले.",
+	"notification.shipment_dispatched.title": "ऑर्डर पाठवली",
+	"notification.shipment_dispatched.body":  "तुमची ऑर्डर {txnID} पाठवली आहे.",
+	"notification.shipment_in_transit.title": "ऑर्डर मार्गावर आहे",
+	"notification.shipment_in_transit.body":  "तुमची ऑर्डर {txnID} तुमच्याकडे येत आहे.",
+	"notification.shipment_delivered.title":  "ऑर्डर पोहोचली",
+	"notification.shipment_delivered.body":   "तुमची ऑर्डर {txnID} पोहोचवली आहे.",
+	"notification.shipment_returned.title":   "ऑर्डर परत गेली",
+	"notification.shipment_returned.body":    "तुमची ऑर्डर {txnID} पोहोचवता आली नाही आणि परत पाठवली जात आहे, आम्ही तुमच्याशी संपर्क साधू.",
+	"notification.gift_received.title":       "तुम्हाला भेट मिळाली",
+	"notification.gift_received.body":        "{sender} यांनी तुम्हाला {quantity} ग्रॅम {metal} भेट दिले.",
+	"notification.gift_claimed.title":        "तुमची भेट स्वीकारली",
+	"notification.gift_claimed.body":         "तुम्ही भेट दिलेले {quantity} ग्रॅम {metal} {recipient} यांनी स्वीकारले.",
+}
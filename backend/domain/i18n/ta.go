@@ -0,0 +1,94 @@
+package i18n
+
+// ta is the Tamil catalogue
+var ta = map[string]string{
+	// Errors
+	"invalid_argument":             "தவறான தகவல்",
+	"not_found":                    "கிடைக்கவில்லை",
+	"internal_error":               "சர்வர் பிழை, பின்னர் மீண்டும் முயற்சிக்கவும்",
+	"bad_request":                  "தவறான கோரிக்கை",
+	"unauthorized":                 "மீண்டும் உள்நுழையவும்",
+	"forbidden":                    "இதைச் செய்ய உங்களுக்கு அனுமதி இல்லை",
+	"conflict":                     "இது ஏற்கனவே உள்ளது",
+	"unavailable":                  "சேவை கிடைக்கவில்லை, பின்னர் மீண்டும் முயற்சிக்கவும்",
+	"validation_failed":            "குறிக்கப்பட்ட புலங்களைத் திருத்தவும்",
+	"augmont.duplicate_mobile":     "இந்த மொபைல் எண் ஏற்கனவே பதிவு செய்யப்பட்டுள்ளது",
+	"augmont.invalid_ifsc":         "சரியான IFSC குறியீட்டை உள்ளிடவும்",
+	"augmont.kyc_required":         "தொடர உங்கள் KYC-ஐ முடிக்கவும்",
+	"augmont.insufficient_balance": "இந்த பரிவர்த்தனைக்கு போதுமான இருப்பு இல்லை",
+	"augmont.rate_expired":         "விலை காலாவதியாகிவிட்டது, மீண்டும் முயற்சிக்கவும்",
+
+	// Fields
+	"field.mobileNumber":  "சரியான மொபைல் எண்ணை உள்ளிடவும்",
+	"field.emailId":       "சரியான மின்னஞ்சல் முகவரியை உள்ளிடவும்",
+	"field.userPincode":   "சரியான பின்கோடை உள்ளிடவும்",
+	"field.pincode":       "சரியான பின்கோடை உள்ளிடவும்",
+	"field.dateOfBirth":   "உங்கள் பிறந்த தேதியை DD-MM-YYYY வடிவில் உள்ளிடவும்",
+	"field.panNumber":     "சரியான PAN எண்ணை உள்ளிடவும்",
+	"field.panAttachment": "உங்கள் PAN அட்டையின் தெளிவான படத்தைப் பதிவேற்றவும்",
+	"field.nameAsPerPan":  "PAN அட்டையில் உள்ளபடி உங்கள் பெயரை உள்ளிடவும்",
+	"field.accountNumber": "சரியான கணக்கு எண்ணை உள்ளிடவும்",
+	"field.ifscCode":      "சரியான IFSC குறியீட்டை உள்ளிடவும்",
+	"field.quantity":      "சரியான அளவை உள்ளிடவும்",
+	"field.amount":        "சரியான தொகையை உள்ளிடவும்",
+
+	// Validation
+	"validation.required":     "{field} தேவை",
+	"validation.email":        "சரியான மின்னஞ்சல் முகவரியை உள்ளிடவும்",
+	"validation.min":          "{field} குறைந்தது {param} ஆக இருக்க வேண்டும்",
+	"validation.max":          "{field} அதிகபட்சம் {param} ஆக இருக்க வேண்டும்",
+	"validation.len":          "{field} {param} எழுத்துகள் கொண்டதாக இருக்க வேண்டும்",
+	"validation.oneof":        "{field} இவற்றில் ஒன்றாக இருக்க வேண்டும்: {param}",
+	"validation.numeric":      "{field} ஒரு எண்ணாக இருக்க வேண்டும்",
+	"validation.locale":       "ஆதரிக்கப்படும் மொழியைத் தேர்ந்தெடுக்கவும்",
+	"validation.number":       "{field} ஒரு முழு எண்ணாக இருக்க வேண்டும்",
+	"validation.ne":           "{field} {param} ஆக இருக்கக்கூடாது",
+	"validation.mobile":       "சரியான 10 இலக்க மொபைல் எண்ணை உள்ளிடவும்",
+	"validation.ifsc":         "சரியான IFSC குறியீட்டை உள்ளிடவும்",
+	"validation.pan":          "சரியான PAN எண்ணை உள்ளிடவும்",
+	"validation.pincode":      "சரியான 6 இலக்க பின்கோடை உள்ளிடவும்",
+	"validation.date":         "{field} DD-MM-YYYY வடிவில் தேதியாக இருக்க வேண்டும்",
+	"validation.grams":        "கிராமில் அளவை அதிகபட்சம் 4 தசம இடங்களுடன் உள்ளிடவும்",
+	"validation.amount":       "ரூபாயில் தொகையை அதிகபட்சம் 2 தசம இடங்களுடன் உள்ளிடவும்",
+	"validation.fy":           "2025-26 போன்ற நிதியாண்டை உள்ளிடவும்",
+	"validation.clock":        "நேரத்தை HH:MM ஆக உள்ளிடவும்",
+	"validation.cursor":       "பக்க கர்சர் தவறானது, பட்டியலை மீண்டும் ஏற்றவும்",
+	"validation.date_range":   "{field} {param} அன்று அல்லது அதற்குப் பிறகு இருக்க வேண்டும்",
+	"validation.metal":        "தங்கம் அல்லது வெள்ளியைத் தேர்ந்தெடுக்கவும்",
+	"validation.min_amount":   "ஆர்டர் குறைந்தது ₹{param} ஆக இருக்க வேண்டும்",
+	"validation.max_amount":   "ஆர்டர் அதிகபட்சம் ₹{param} ஆக இருக்கலாம்",
+	"validation.max_quantity": "ஆர்டர் அதிகபட்சம் {param} கிராம் ஆக இருக்கலாம்",
+	"validation.product":      "இந்த தயாரிப்பு இனி கிடைக்காது",
+	"validation.stock":        "கையிருப்பில் {param} மட்டுமே உள்ளது",
+	"validation.balance":      "டெலிவரிக்கு உங்களிடம் {param} கிராம் மட்டுமே உள்ளது",
+	"validation.address":      "சேமித்த முகவரிகளில் ஒன்றைத் தேர்ந்தெடுக்கவும்",
+	"validation.serviceable":  "பின்கோடு {param} க்கு டெலிவரி இல்லை",
+	"validation.cart_empty":   "முதலில் உங்கள் கார்ட்டில் தயாரிப்புகளைச் சேர்க்கவும்",
+	"validation.self_gift":    "உங்கள் சொந்த மொபைல் எண்ணுக்கு பரிசு அனுப்ப முடியாது",
+
+	// Metals
+	"metal.gold":   "தங்கம்",
+	"metal.silver": "வெள்ளி",
+
+	// Notifications
+	"notification.kyc_approved.title":        "KYC அங்கீகரிக்கப்பட்டது",
+	"notification.kyc_approved.body":         "உங்கள் KYC அங்கீகரிக்கப்பட்டது, இப்போது நீங்கள் வாங்கலாம், விற்கலாம் மற்றும் மீட்கலாம்.",
+	"notification.kyc_rejected.title":        "KYC நிராகரிக்கப்பட்டது",
+	"notification.kyc_rejected.body":         "உங்கள் KYC-ஐ சரிபார்க்க முடியவில்லை, உங்கள் PAN-ஐ மீண்டும் சமர்ப்பிக்கவும்.",
+	"notification.buy_completed.title":       "வாங்குதல் வெற்றி",
+	"notification.buy_completed.body":        "நீங்கள் ₹{amount}க்கு {quantity} கிராம் {metal} வாங்கினீர்கள்.",
+	"notification.sell_completed.title":      "விற்பனை வெற்றி",
+	"notification.sell_completed.body":       "நீங்கள் ₹{amount}க்கு {quantity} கிராம் {metal} விற்றீர்கள்.",
+	"notification.shipment_dispatched.title": "ஆர்டர் அனுப்பப்பட்டது",
+	"notification.shipment_dispatched.body":  "உங்கள் ஆர்டர் {txnID} அனுப்பப்பட்டது.",
+	"notification.shipment_in_transit.title": "ஆர்டர் வழியில் உள்ளது",
+	"notification.shipment_in_transit.body":  "உங்கள் ஆர்டர் {txnID} உங்களை நோக்கி வருகிறது.",
+	"notification.shipment_delivered.title":  "ஆர்டர் டெலிவரி செய்யப்பட்டது",
+	"notification.shipment_delivered.body":   "உங்கள் ஆர்டர் {txnID} டெலிவரி செய்யப்பட்டது.",
+	"notification.shipment_returned.title":   "ஆர்டர் திருப்பி அனுப்பப்பட்டது",
+	"notification.shipment_returned.body":    "உங்கள் ஆர்டர் {txnID} டெலிவரி செய்ய முடியவில்லை, திருப்பி அனுப்பப்படுகிறது, நாங்கள் உங்களைத் தொடர்புகொள்வோம்.",
+	"notification.gift_received.title":       "உங்களுக்கு ஒரு பரிசு வந்துள்ளது",
+	"notification.gift_received.body":        "{sender} உங்களுக்கு {quantity} கிராம் {metal} பரிசளித்துள்ளார்.",
+	"notification.gift_claimed.title":        "உங்கள் பரிசு பெறப்பட்டது",
+	"notification.gift_claimed.body":         "நீங்கள் பரிசளித்த {quantity} கிராம் {metal} ஐ {recipient} பெற்றுக்கொண்டார்.",
+}
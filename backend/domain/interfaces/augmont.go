@@ -1,111 +1,171 @@
 package interfaces
 
 import (
+	"context"
 	"time"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
 	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
 )
 
 // Augmont User, Bank, Address Table CRUD Interface
 type AugmontUserRepo interface {
-	CreateUser(*models.AugmontUser) error
-	UpdateUser(*models.AugmontUser) error
-	FindUser(*models.AugmontUser) (*models.AugmontUser, error)
-	FindUsers(*models.AugmontUser) ([]*models.AugmontUser, error)
-	FindAllUsers() ([]*models.AugmontUser, error)
-
-	CreateBank(*models.AugmontUserBank) error
-	DeleteBank(*models.AugmontUserBank) error
-	FindBank(*models.AugmontUserBank) (*models.AugmontUserBank, error)
-	FindBanks(*models.AugmontUserBank) ([]*models.AugmontUserBank, error)
-	FindAllBanks() ([]*models.AugmontUserBank, error)
-
-	CreateAddress(*models.AugmontUserAddress) error
-	DeleteAddress(*models.AugmontUserAddress) error
-	FindAddress(*models.AugmontUserAddress) (*models.AugmontUserAddress, error)
-	FindAddresses(*models.AugmontUserAddress) ([]*models.AugmontUserAddress, error)
-	FindAllAddress() ([]*models.AugmontUserAddress, error)
+	CreateUser(context.Context, *models.AugmontUser) error
+	UpdateUser(context.Context, *models.AugmontUser) error
+	FindUser(context.Context, *models.AugmontUser) (*models.AugmontUser, error)
+	FindUsers(context.Context, *models.AugmontUser) ([]*models.AugmontUser, error)
+	ListUsers(context.Context, *utils.ListQuery) ([]*models.AugmontUser, *utils.Page, error)
+
+	CreateBank(context.Context, *models.AugmontUserBank) error
+	DeleteBank(context.Context, *models.AugmontUserBank) error
+	FindBank(context.Context, *models.AugmontUserBank) (*models.AugmontUserBank, error)
+	FindBanks(context.Context, *models.AugmontUserBank) ([]*models.AugmontUserBank, error)
+
+	CreateAddress(context.Context, *models.AugmontUserAddress) error
+	DeleteAddress(context.Context, *models.AugmontUserAddress) error
+	FindAddress(context.Context, *models.AugmontUserAddress) (*models.AugmontUserAddress, error)
+	FindAddresses(context.Context, *models.AugmontUserAddress) ([]*models.AugmontUserAddress, error)
 }
 
 // Augmont Order Interface form Buy, Sell & Redeem
 type AugmontOrderRepo interface {
-	CreateBuy(*models.AugmontBuyOrder) error
-	FindBuy(*models.AugmontBuyOrder) (*models.AugmontBuyOrder, error)
-	FindBuys(*models.AugmontBuyOrder) ([]*models.AugmontBuyOrder, error)
-	FindAllBuys() ([]*models.AugmontBuyOrder, error)
-
-	CreateSell(*models.AugmontSellOrder) error
-	FindSell(*models.AugmontSellOrder) (*models.AugmontSellOrder, error)
-	FindSells(*models.AugmontSellOrder) ([]*models.AugmontSellOrder, error)
-	FindAllSells() ([]*models.AugmontSellOrder, error)
-
-	CreateRedeem(*models.AugmontRedeemOrder) error
-	FindRedeem(*models.AugmontRedeemOrder) (*models.AugmontRedeemOrder, error)
-	FindRedeems(*models.AugmontRedeemOrder) ([]*models.AugmontRedeemOrder, error)
-	FindAllRedeems() ([]*models.AugmontRedeemOrder, error)
+	CreateBuy(context.Context, *models.AugmontBuyOrder) error
+	FindBuy(context.Context, *models.AugmontBuyOrder) (*models.AugmontBuyOrder, error)
+	FindBuys(context.Context, *models.AugmontBuyOrder) ([]*models.AugmontBuyOrder, error)
+
+	CreateSell(context.Context, *models.AugmontSellOrder) error
+	FindSell(context.Context, *models.AugmontSellOrder) (*models.AugmontSellOrder, error)
+	FindSells(context.Context, *models.AugmontSellOrder) ([]*models.AugmontSellOrder, error)
+
+	CreateRedeem(context.Context, *models.AugmontRedeemOrder) error
+	FindRedeem(context.Context, *models.AugmontRedeemOrder) (*models.AugmontRedeemOrder, error)
+	FindRedeems(context.Context, *models.AugmontRedeemOrder) ([]*models.AugmontRedeemOrder, error)
+
+	// Orders of every type of an Augmont user, by merchant transaction id
+	FindOrder(ctx context.Context, augmontUserID uint64, txnID string) (*models.AugmontOrder, error)
+	ListOrders(ctx context.Context, augmontUserID uint64, q *utils.ListQuery) ([]*models.AugmontOrder, *utils.Page, error)
+	// ListAllOrders lists the orders of every Augmont user
+	ListAllOrders(ctx context.Context, q *utils.ListQuery) ([]*models.AugmontOrder, *utils.Page, error)
+	// FindOrdersBetween returns the orders and the ledger entries created in
+	// the range as orders, oldest first
+	FindOrdersBetween(ctx context.Context, augmontUserID uint64, from, to time.Time) ([]*models.AugmontOrder, error)
+	// FindIncompleteOrders returns the orders of the user missing their
+	// metal, quantity or prices, stored before the orders kept them
+	FindIncompleteOrders(ctx context.Context, augmontUserID uint64) ([]*models.AugmontOrder, error)
+	// FillOrder sets the metal, quantity, amount and rate the order is
+	// missing, the ones it has are kept
+	FillOrder(ctx context.Context, order *models.AugmontOrder) error
+	// RerunOrder marks a failed buy or sell as placed again,
+	// false if it is not failed
+	RerunOrder(ctx context.Context, order *models.AugmontOrder) (bool, error)
 }
 
 // Services offered by Augmont
 type AugmontService interface {
 	// Create customer account using mobile number and unique Id
-	CreateUser(info *utils.AugmontUserInfo, user *models.AugmontUser) error
-	GetUserInfo(uniqueID string) (*utils.AugmontUserInfo, error)
-	UpdateUser(userInfo *utils.AugmontUserInfo) error
+	CreateUser(ctx context.Context, info *utils.AugmontUserInfo, user *models.AugmontUser) error
+	GetUserInfo(ctx context.Context, uniqueID string) (*utils.AugmontUserInfo, error)
+	UpdateUser(ctx context.Context, userInfo *utils.AugmontUserInfo) error
 
-	CreateUserBank(user *models.AugmontUser, bankInfo *utils.AugmontUserBankInfo) error
-	GetUserBanks(user *models.AugmontUser) ([]*utils.AugmontUserBankInfo, error)
-	UpdateUserBank(user *models.AugmontUser, bankInfo *utils.AugmontUserBankInfo) error
-	DeleteUserBank(user *models.AugmontUser, bankInfo *utils.AugmontUserBankInfo) error
+	CreateUserBank(ctx context.Context, user *models.AugmontUser, bankInfo *utils.AugmontUserBankInfo) error
+	GetUserBanks(ctx context.Context, user *models.AugmontUser) ([]*utils.AugmontUserBankInfo, error)
+	UpdateUserBank(ctx context.Context, user *models.AugmontUser, bankInfo *utils.AugmontUserBankInfo) error
+	DeleteUserBank(ctx context.Context, user *models.AugmontUser, bankInfo *utils.AugmontUserBankInfo) error
 
-	CreateUserAddress(user *models.AugmontUser, addressInfo *utils.AugmontUserAddressInfo) error
-	GetUserAddresses(user *models.AugmontUser) ([]*utils.AugmontUserAddressInfo, error)
-	DeleteUserAddress(user *models.AugmontUser, addressInfo *utils.AugmontUserAddressInfo) error
+	CreateUserAddress(ctx context.Context, user *models.AugmontUser, addressInfo *utils.AugmontUserAddressInfo) error
+	GetUserAddresses(ctx context.Context, user *models.AugmontUser) ([]*utils.AugmontUserAddressInfo, error)
+	DeleteUserAddress(ctx context.Context, user *models.AugmontUser, addressInfo *utils.AugmontUserAddressInfo) error
 
 	PostUserKyc(
+		ctx context.Context,
 		name, pan, dob string,
 		user *models.AugmontUser,
 		file *utils.File,
 	) (utils.Any, error)
 
 	UpdateUserKycStatus(
+		ctx context.Context,
 		user *models.AugmontUser,
 	) error
 
 	Buy(
+		ctx context.Context,
 		user *models.AugmontUser,
 		buyInfo *utils.AugmontBugInfo,
 	) (utils.Any, error)
 
 	BuyInfo(
+		ctx context.Context,
 		userUniqueID,
 		tnxID string,
 	) (utils.Any, error)
-	BuyList(userUniqueID string) (utils.Any, error)
+	BuyList(ctx context.Context, userUniqueID string) (utils.Any, error)
 
 	Sell(
+		context.Context,
 		*models.AugmontUser,
 		*utils.AugmontSellInfo,
 	) (utils.Any, error)
 
 	SellInfo(
+		ctx context.Context,
 		userUniqueID,
 		tnxID string,
 	) (utils.Any, error)
 
-	SellList(userUniqueID string) (utils.Any, error)
+	SellList(ctx context.Context, userUniqueID string) (utils.Any, error)
 
+	// Redeem orders the products for delivery, weights are the grams of
+	// each metal the products take from the balance
 	Redeem(
-		*models.AugmontUser,
-		*utils.AugmontRedeemInfo,
+		ctx context.Context,
+		user *models.AugmontUser,
+		redeemInfo *utils.AugmontRedeemInfo,
+		weights map[string]decimal.Decimal,
 	) (utils.Any, error)
 
 	RedeemInfo(
+		ctx context.Context,
 		userUniqueID,
 		tnxID string,
 	) (utils.Any, error)
 
-	RedeemList(userUniqueID string) (utils.Any, error)
+	RedeemList(ctx context.Context, userUniqueID string) (utils.Any, error)
+
+	// Invoice returns the GST invoice data of a buy or redeem order
+	Invoice(ctx context.Context, tnxID string) (utils.Any, error)
+
+	// Rates returns the live buy and sell rates of the metals
+	Rates(ctx context.Context) (*utils.MetalRates, error)
+
+	// Products returns a page of the coins and bars Augmont delivers
+	Products(ctx context.Context, page, count int) (utils.Any, error)
+
+	// Serviceable checks Augmont delivers to the pincode
+	Serviceable(ctx context.Context, pincode string) (bool, error)
+
+	// Transfer moves metal between two accounts, sets the transaction id
+	// if it is empty, a retry with the same id never moves the metal twice
+	Transfer(ctx context.Context, info *utils.AugmontTransferInfo) (utils.Any, error)
+}
+
+// Order history of the Augmont users, served from the order tables
+type AugmontOrderService interface {
+	List(ctx context.Context, user *models.AugmontUser, q *utils.ListQuery) ([]*models.AugmontOrder, *utils.Page, error)
+
+	// Find returns the order with its detail from Augmont,
+	// the detail is nil while Augmont is unavailable
+	Find(ctx context.Context, user *models.AugmontUser, txnID string) (*models.AugmontOrder, utils.Any, error)
+
+	// Backfill sets the metal, quantity, amount and rate the order is
+	// missing from its Augmont detail
+	Backfill(ctx context.Context, user *models.AugmontUser, order *models.AugmontOrder) error
+
+	// Portfolio returns the holding of every metal, valued at the
+	// live sell rates unless Augmont is unavailable
+	Portfolio(ctx context.Context, user *models.AugmontUser) ([]*utils.MetalHolding, error)
 }
 
 // InMemory Augmont Repo
@@ -113,13 +173,28 @@ type AugmontInMemRepo interface {
 	// GetToken returns augmont auth token
 	// Return empty string without error
 	// if token not found or expired
-	GetToken() (string, error)
+	GetToken(ctx context.Context) (string, error)
 
 	// Set Token with expiry time
-	SetToken(token string, expireAt time.Time) error
+	SetToken(ctx context.Context, token string, expireAt time.Time) error
+
+	// TokenTTL returns the time left before the stored token expires,
+	// zero if there is no token stored
+	TokenTTL(ctx context.Context) (time.Duration, error)
+
+	// GetOrderDetail returns the cached Augmont detail of an order,
+	// nil without error if it is not cached
+	GetOrderDetail(ctx context.Context, txnID string) (utils.Any, error)
+
+	// SetOrderDetail caches the Augmont detail of an order
+	SetOrderDetail(ctx context.Context, txnID string, detail utils.Any, ttl time.Duration) error
 }
 
 // Augmont Authentication Service
 type AugmontAuthService interface {
-	AuthToken() (string, error)
+	// AuthToken returns the stored token, logs in if it is expired
+	AuthToken(ctx context.Context) (string, error)
+
+	// RotateToken logs in and replaces the stored token
+	RotateToken(ctx context.Context) (string, error)
 }
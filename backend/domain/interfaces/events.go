@@ -0,0 +1,46 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/events"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+// Outbox Table Interface, the events are added in the unit of work
+// writing the changes that raised them
+type OutboxRepo interface {
+	// Add saves the events to publish
+	Add(ctx context.Context, evs ...events.Event) error
+	// Relay passes up to limit unpublished events, oldest first, to
+	// publish and marks them published if it succeeds, returns how many,
+	// the events are locked meanwhile so concurrent relays skip them
+	Relay(ctx context.Context, limit int, publish func(events []*models.OutboxEvent) error) (int, error)
+}
+
+// EventBus carries the published events to the consumer groups, each
+// group gets every event at least once
+type EventBus interface {
+	Publish(ctx context.Context, events []*models.OutboxEvent) error
+	// Consume passes the events of the group to the handler until ctx is
+	// done, events the handler fails are delivered again later
+	Consume(ctx context.Context, group, consumer string, handler EventHandler) error
+}
+
+// EventHandler handles an event, it must be idempotent as an event may
+// be delivered more than once
+type EventHandler func(ctx context.Context, e *events.Envelope) error
+
+// EventConsumer is a consumer group of the event bus
+type EventConsumer interface {
+	Group() string
+	Handle(ctx context.Context, e *events.Envelope) error
+}
+
+// EventRelay publishes the outbox to the event bus
+type EventRelay interface {
+	// RelayOnce publishes the unpublished events, returns how many
+	RelayOnce(ctx context.Context) (int, error)
+	// Run relays the outbox until ctx is done
+	Run(ctx context.Context)
+}
@@ -0,0 +1,60 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+// Augmont Gift and Ledger Table CRUD Interface
+type AugmontGiftRepo interface {
+	// CreateGift saves the gift with its ledger entries
+	CreateGift(ctx context.Context, gift *models.AugmontGift, entries ...*models.AugmontLedgerEntry) error
+	// CompleteSend saves the status of the sending gift with the ledger
+	// entries of the transfer, returns if it was still sending
+	CompleteSend(ctx context.Context, gift *models.AugmontGift, entries ...*models.AugmontLedgerEntry) (bool, error)
+	// FailSend moves the gift from sending to failed, returns if it was
+	// still sending
+	FailSend(ctx context.Context, gift *models.AugmontGift) (bool, error)
+	// StartClaim moves the gift from escrowed to claiming with its claim
+	// transaction id and recipient, returns if it was still escrowed
+	StartClaim(ctx context.Context, gift *models.AugmontGift) (bool, error)
+	// CancelClaim puts the claiming gift back in escrow without a claim
+	// transaction id or recipient, returns if it was still claiming
+	CancelClaim(ctx context.Context, gift *models.AugmontGift) (bool, error)
+	// CompleteClaim saves the claimed gift with the ledger entry of the recipient
+	CompleteClaim(ctx context.Context, gift *models.AugmontGift, entry *models.AugmontLedgerEntry) error
+
+	// FindEscrowedGifts returns the gifts waiting for the mobile number, oldest first
+	FindEscrowedGifts(ctx context.Context, mobile string) ([]*models.AugmontGift, error)
+	// FindStaleClaims returns the gifts claiming since before the time, oldest first
+	FindStaleClaims(ctx context.Context, before time.Time) ([]*models.AugmontGift, error)
+	// FindSendingGifts returns the gifts of the sender still sending
+	FindSendingGifts(ctx context.Context, senderID uint64) ([]*models.AugmontGift, error)
+	// FindStaleSends returns the gifts sending since before the time, oldest first
+	FindStaleSends(ctx context.Context, before time.Time) ([]*models.AugmontGift, error)
+	// ListGifts lists the gifts sent and received by the user and
+	// the ones waiting for the mobile number of the user
+	ListGifts(ctx context.Context, augmontUserID uint64, mobile string, q *utils.ListQuery) ([]*models.AugmontGift, *utils.Page, error)
+	ListLedger(ctx context.Context, augmontUserID uint64, q *utils.ListQuery) ([]*models.AugmontLedgerEntry, *utils.Page, error)
+}
+
+// Gifts of metal between users, gifts to users without KYC are held
+// in the escrow account until they complete KYC and claim them
+type AugmontGiftService interface {
+	FindRecipient(ctx context.Context, sender *models.AugmontUser, mobile string) (*models.GiftRecipient, error)
+	Send(ctx context.Context, sender *models.AugmontUser, info *utils.AugmontGiftInfo) (*models.AugmontGift, error)
+	// Claim transfers the escrowed gifts of the mobile number of the user
+	Claim(ctx context.Context, user *models.AugmontUser) ([]*models.AugmontGift, error)
+	// SweepClaims retries the claims left claiming by a failed transfer
+	// or a crash, returns the number completed
+	SweepClaims(ctx context.Context) (int, error)
+	// SweepSends retries the gifts left sending by an unanswered transfer
+	// or a crash, returns the number completed
+	SweepSends(ctx context.Context) (int, error)
+
+	List(ctx context.Context, user *models.AugmontUser, q *utils.ListQuery) ([]*models.AugmontGift, *utils.Page, error)
+	Ledger(ctx context.Context, user *models.AugmontUser, q *utils.ListQuery) ([]*models.AugmontLedgerEntry, *utils.Page, error)
+}
@@ -0,0 +1,27 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+// Augmont Invoice Table CRUD Interface
+type AugmontInvoiceRepo interface {
+	// Create saves the invoice, an invoice saved
+	// concurrently for the same order is kept
+	Create(context.Context, *models.AugmontInvoice) error
+	// FindByTxnID returns the invoice of an order of the user,
+	// nil without error if it is not stored
+	FindByTxnID(ctx context.Context, augmontUserID uint64, txnID string) (*models.AugmontInvoice, error)
+}
+
+// GST invoices of the buy and redeem orders of the Augmont users
+type AugmontInvoiceService interface {
+	// Fetch fetches and stores the invoice of an order unless it is stored
+	Fetch(ctx context.Context, user *models.AugmontUser, txnID string) (*models.AugmontInvoice, error)
+
+	// Find returns the invoice of an order and its download URL,
+	// the invoice is fetched if it is not stored yet
+	Find(ctx context.Context, user *models.AugmontUser, txnID string) (*models.AugmontInvoice, string, error)
+}
@@ -0,0 +1,45 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/jobs"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+// Job queues on Redis, jobs are scheduled until due, ready, in flight
+// while a worker runs them, or dead letters once they ran out of attempts
+type JobRepo interface {
+	// Enqueue saves the job and schedules it at its run time
+	Enqueue(ctx context.Context, job *models.Job) error
+	// Dequeue takes a due job of the queue in flight until the lease ends,
+	// it is ready again if not completed by then, nil if none are due
+	Dequeue(ctx context.Context, queue string, lease time.Duration) (*models.Job, error)
+	Complete(ctx context.Context, job *models.Job) error
+	// Retry saves the failure of the job and schedules it at its run time
+	Retry(ctx context.Context, job *models.Job) error
+	// Bury moves the job to the dead letters of its queue
+	Bury(ctx context.Context, job *models.Job) error
+
+	// ListDead returns up to limit dead letters of the queue, newest first
+	ListDead(ctx context.Context, queue string, limit int) ([]*models.Job, error)
+	// Requeue makes a dead letter ready again with fresh attempts
+	Requeue(ctx context.Context, queue, id string) error
+}
+
+// JobQueue queues the background jobs
+type JobQueue interface {
+	// Enqueue queues the job to run after the delay
+	Enqueue(ctx context.Context, job jobs.Job, delay time.Duration) (*models.Job, error)
+	ListDead(ctx context.Context, queue string, limit int) ([]*models.Job, error)
+	Requeue(ctx context.Context, queue, id string) error
+}
+
+// JobHandler runs the jobs of a type, it must be idempotent as a job
+// may run again when its worker stops, failures marked jobs.Permanent
+// are not retried
+type JobHandler interface {
+	JobType() string
+	Handle(ctx context.Context, job jobs.Job) error
+}
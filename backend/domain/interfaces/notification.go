@@ -0,0 +1,49 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+// NotificationProvider delivers notifications of one channel,
+// e.g. an SMS gateway, an email service or a push service
+type NotificationProvider interface {
+	Channel() string
+	Send(ctx context.Context, n *models.Notification) error
+}
+
+// Notification preferences and deliveries Table CRUD Interface
+type NotificationRepo interface {
+	// FindPreference returns the preference of the user, nil if there is none
+	FindPreference(ctx context.Context, userID uint64) (*models.NotificationPreference, error)
+	// SavePreference creates or replaces the preference of the user
+	SavePreference(ctx context.Context, pref *models.NotificationPreference) error
+
+	// Create saves the notifications, the ones of an event already saved
+	// on their channel are skipped and left without an ID
+	Create(ctx context.Context, notifications []*models.Notification) error
+	// Update saves the delivery of the notification
+	Update(ctx context.Context, n *models.Notification) error
+	// ClaimDue marks up to limit pending notifications due by now as
+	// sending and returns them, so concurrent dispatchers never share one,
+	// notifications left sending since before stale are claimed again
+	ClaimDue(ctx context.Context, now, stale time.Time, limit int) ([]*models.Notification, error)
+	List(ctx context.Context, userID uint64, q *utils.ListQuery) ([]*models.Notification, *utils.Page, error)
+}
+
+// Notifications rendered from the i18n templates and sent on the
+// channels the user enabled, retried when the provider fails
+type NotificationService interface {
+	Notifier
+	EventNotifier
+
+	Preferences(ctx context.Context, userID uint64) (*models.NotificationPreference, error)
+	SavePreferences(ctx context.Context, userID uint64, info *utils.NotificationPreferenceInfo) (*models.NotificationPreference, error)
+
+	// Dispatch sends the notifications that are due, returns how many were sent
+	Dispatch(ctx context.Context) (int, error)
+	List(ctx context.Context, userID uint64, q *utils.ListQuery) ([]*models.Notification, *utils.Page, error)
+}
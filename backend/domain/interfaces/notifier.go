@@ -0,0 +1,15 @@
+package interfaces
+
+import "context"
+
+// Notifier sends a user the "notification.<name>" title and body,
+// rendered in the locale of the user with the args
+type Notifier interface {
+	Notify(ctx context.Context, userID uint64, name string, args map[string]string) error
+}
+
+// EventNotifier sends the notification of an event once per channel,
+// however often the event is delivered
+type EventNotifier interface {
+	NotifyEvent(ctx context.Context, eventID, userID uint64, name string, args map[string]string) error
+}
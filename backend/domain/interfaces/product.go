@@ -0,0 +1,53 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+// Augmont Product Table CRUD Interface
+type AugmontProductRepo interface {
+	// Upsert saves the products by SKU
+	Upsert(context.Context, []*models.AugmontProduct) error
+	// Deactivate marks the active products missing from the SKUs
+	// inactive and returns how many were
+	Deactivate(ctx context.Context, keep []string) (int64, error)
+
+	FindBySKU(ctx context.Context, sku string) (*models.AugmontProduct, error)
+	// FindBySKUs returns the products found of the SKUs, active or not
+	FindBySKUs(ctx context.Context, skus []string) ([]*models.AugmontProduct, error)
+	List(ctx context.Context, q *utils.ListQuery) ([]*models.AugmontProduct, *utils.Page, error)
+}
+
+// Delivery carts of the Augmont users, kept in memory
+type AugmontCartRepo interface {
+	// GetCart returns the quantities by SKU, empty if there is no cart
+	GetCart(ctx context.Context, augmontUserID uint64) (map[string]int64, error)
+	// SetCartItem sets the quantity of a product, zero removes it
+	SetCartItem(ctx context.Context, augmontUserID uint64, sku string, quantity int64) error
+	DeleteCart(ctx context.Context, augmontUserID uint64) error
+}
+
+// Catalogue of the products delivered by Augmont
+type AugmontProductService interface {
+	// Sync saves the Augmont catalogue, returns the products
+	// synced and the ones dropped from the catalogue
+	Sync(ctx context.Context) (int, int64, error)
+
+	List(ctx context.Context, q *utils.ListQuery) ([]*models.AugmontProduct, *utils.Page, error)
+	Find(ctx context.Context, sku string) (*models.AugmontProduct, error)
+}
+
+// Delivery carts, checked out into redeem orders
+type AugmontCartService interface {
+	Get(ctx context.Context, user *models.AugmontUser) (*models.Cart, error)
+	// SetItem sets the quantity of a product in the cart, zero removes it
+	SetItem(ctx context.Context, user *models.AugmontUser, sku string, quantity int64) (*models.Cart, error)
+	Clear(ctx context.Context, user *models.AugmontUser) error
+
+	// Checkout redeems the cart to one of the addresses of the user, after
+	// checking the stock, the balance and that the pincode is served
+	Checkout(ctx context.Context, user *models.AugmontUser, info *utils.AugmontCheckoutInfo) (utils.Any, error)
+}
@@ -0,0 +1,58 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+// Schedules and scheduled runs Table CRUD Interface
+type ScheduleRepo interface {
+	List(ctx context.Context) ([]*models.Schedule, error)
+	// Save creates or replaces the schedule
+	Save(ctx context.Context, s *models.Schedule) error
+	// StartRun creates the run and saves it as the last run of the
+	// schedule, false if a run of the task is going on
+	StartRun(ctx context.Context, s *models.Schedule, run *models.ScheduleRun) (bool, error)
+	// RenewRun extends the lease of the run
+	RenewRun(ctx context.Context, run *models.ScheduleRun) error
+	// FinishRun saves the outcome of the run and of its schedule
+	FinishRun(ctx context.Context, s *models.Schedule, run *models.ScheduleRun) error
+	// InterruptRuns fails the runs left running past their lease
+	InterruptRuns(ctx context.Context) error
+	ListRuns(ctx context.Context, q *utils.ListQuery) ([]*models.ScheduleRun, *utils.Page, error)
+}
+
+// LeaderLease elects a single holder among the replicas, the lease
+// ends unless the holder renews it
+type LeaderLease interface {
+	// Acquire takes the free lease or renews the one of the holder for
+	// ttl, returns if the holder has it
+	Acquire(ctx context.Context, holder string, ttl time.Duration) (bool, error)
+	// Release frees the lease if the holder has it
+	Release(ctx context.Context, holder string) error
+	// Holder returns the holder of the lease, empty if it is free
+	Holder(ctx context.Context) (string, error)
+}
+
+// ScheduledTask is periodic work run by the scheduler leader, a run is
+// cancelled when the leader loses the lease
+type ScheduledTask interface {
+	// Name names the task in the config and the run history
+	Name() string
+	// Spec is the default cron expression, in IST
+	Spec() string
+	Run(ctx context.Context) error
+}
+
+// Scheduled tasks with their run history
+type SchedulerService interface {
+	// List returns the schedules of the tasks, the leader holds the lease
+	List(ctx context.Context) (schedules []*models.Schedule, leader string, err error)
+	ListRuns(ctx context.Context, name string, q *utils.ListQuery) ([]*models.ScheduleRun, *utils.Page, error)
+	// Run runs the task once now, outside its schedule, a conflict
+	// while a run of the task is going on
+	Run(ctx context.Context, name string) (*models.ScheduleRun, error)
+}
@@ -0,0 +1,32 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+// Shipments of the redeem orders
+type AugmontShipmentRepo interface {
+	// FindRedeem returns the redeem order with its user, nil if there is none
+	FindRedeem(ctx context.Context, txnID string) (*models.AugmontRedeemOrder, error)
+	// FindOpenShipments returns the redeem orders with their users
+	// whose shipment is not delivered or returned yet
+	FindOpenShipments(ctx context.Context) ([]*models.AugmontRedeemOrder, error)
+
+	// UpdateShipment moves the shipment of the order on and records the
+	// event, only if the status is still from, returns if it was moved
+	UpdateShipment(ctx context.Context, order *models.AugmontRedeemOrder, from *string, event *models.AugmontShipmentEvent) (bool, error)
+	FindEvents(ctx context.Context, redeemOrderID uint64) ([]*models.AugmontShipmentEvent, error)
+}
+
+// Tracking of the redeem orders, updated by polling Augmont and by
+// the Augmont callbacks, users are notified of each change
+type AugmontShipmentService interface {
+	// Poll updates the open shipments from Augmont, returns how many changed
+	Poll(ctx context.Context) (int, error)
+	Callback(ctx context.Context, info *utils.AugmontShipmentCallback) error
+
+	Timeline(ctx context.Context, user *models.AugmontUser, txnID string) (*models.ShipmentTimeline, error)
+}
@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+// Augmont Statement Table CRUD Interface
+type AugmontStatementRepo interface {
+	Create(context.Context, *models.AugmontStatement) error
+	Update(context.Context, *models.AugmontStatement) error
+	FindOne(context.Context, *models.AugmontStatement) (*models.AugmontStatement, error)
+}
+
+// Statements of the orders of the Augmont users
+type AugmontStatementService interface {
+	// Request saves a pending statement and generates it in the background
+	Request(ctx context.Context, user *models.AugmontUser, from, to time.Time, format string) (*models.AugmontStatement, error)
+
+	// Find returns the statement and its download URL once it is ready
+	Find(ctx context.Context, user *models.AugmontUser, id uint64) (*models.AugmontStatement, string, error)
+}
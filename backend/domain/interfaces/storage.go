@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// FileStorage stores the generated files, like statements
+// and invoices, by slash separated keys
+type FileStorage interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+
+	// SignedURL returns a URL to download the file until it expires
+	SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/tax"
+)
+
+// Capital gains of the Augmont users
+type AugmontTaxService interface {
+	// Report computes the capital gains of a financial year like 2025-26
+	Report(ctx context.Context, user *models.AugmontUser, fy string) (*tax.Report, error)
+}
@@ -0,0 +1,11 @@
+package interfaces
+
+import "context"
+
+// UnitOfWork runs the writes of several repos atomically
+type UnitOfWork interface {
+	// Do runs work in a transaction joined by the repos called with the
+	// ctx of work, committed if work returns nil and rolled back
+	// otherwise, work done in a transaction rolls back to a savepoint
+	Do(ctx context.Context, work func(ctx context.Context) error) error
+}
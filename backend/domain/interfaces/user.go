@@ -1,21 +1,28 @@
 package interfaces
 
-import "github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+import (
+	"context"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
 
 // UserRepo interface, which is used to interact with the user repository
 type UserRepo interface {
-	Create(user *models.User) error
-	Update(user *models.User) error
-	Delete(user *models.User) error
-	FindOne(user *models.User) (*models.User, error)
-	FindMany(user *models.User) ([]*models.User, error)
+	Create(ctx context.Context, user *models.User) error
+	Update(ctx context.Context, user *models.User) error
+	Delete(ctx context.Context, user *models.User) error
+	FindOne(ctx context.Context, user *models.User) (*models.User, error)
+	FindMany(ctx context.Context, user *models.User) ([]*models.User, error)
+	// List returns a page of users matching the query
+	List(ctx context.Context, q *utils.ListQuery) ([]*models.User, *utils.Page, error)
 }
 
 // UserService interface, which is used to interact with the repo and controller
 type UserService interface {
-	Create(user *models.User) error
-	Update(user *models.User) error
-	Delete(user *models.User) error
-	FindOne(user *models.User) (*models.User, error)
-	FindAll() ([]*models.User, error)
+	Create(ctx context.Context, user *models.User) error
+	Update(ctx context.Context, user *models.User) error
+	Delete(ctx context.Context, user *models.User) error
+	FindOne(ctx context.Context, user *models.User) (*models.User, error)
+	List(ctx context.Context, q *utils.ListQuery) ([]*models.User, *utils.Page, error)
 }
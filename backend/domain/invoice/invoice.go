@@ -0,0 +1,139 @@
+// Package invoice reads the GST invoices of the orders from
+// the Augmont invoice data and renders them as PDF
+package invoice
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/document"
+)
+
+// Tax is a part of the GST of an invoice, like CGST
+type Tax struct {
+	Type    string
+	Percent decimal.Decimal
+	Amount  decimal.Decimal
+}
+
+// Invoice is the GST invoice of a buy or redeem order,
+// values missing from the Augmont data are left empty
+type Invoice struct {
+	Number string
+	// As sent by Augmont
+	Date  string
+	TxnID string
+	HSN   string
+	Metal string
+	// Grams, rupees per gram and rupees
+	Quantity decimal.Decimal
+	Rate     decimal.Decimal
+	Gross    decimal.Decimal
+	Taxes    []Tax
+	TotalTax decimal.Decimal
+	Net      decimal.Decimal
+}
+
+// Parse reads the invoice from the data of the Augmont invoice result
+func Parse(data map[string]interface{}, txnID string) *Invoice {
+	inv := &Invoice{
+		Number:   text(data["invoiceNumber"]),
+		Date:     text(data["invoiceDate"]),
+		TxnID:    txnID,
+		HSN:      text(data["hsnCode"]),
+		Metal:    text(data["metalType"]),
+		Quantity: number(data["quantity"]),
+		Rate:     number(data["rate"]),
+		Gross:    number(data["grossAmount"]),
+		Net:      number(data["netAmount"]),
+	}
+
+	taxes, _ := data["taxes"].(map[string]interface{})
+	inv.TotalTax = number(taxes["totalTaxAmount"])
+	split, _ := taxes["taxSplit"].([]interface{})
+	for _, t := range split {
+		t, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		inv.Taxes = append(inv.Taxes, Tax{
+			Type:    text(t["type"]),
+			Percent: number(t["taxPerc"]),
+			Amount:  number(t["taxAmount"]),
+		})
+	}
+	return inv
+}
+
+var lineColumns = []document.Column{
+	{Title: "Description", Width: 60, Align: "L"},
+	{Title: "HSN", Width: 25, Align: "L"},
+	{Title: "Qty (g)", Width: 30, Align: "R"},
+	{Title: "Rate (Rs/g)", Width: 35, Align: "R"},
+	{Title: "Amount (Rs)", Width: 40, Align: "R"},
+}
+
+var totalColumns = []document.Column{
+	{Title: "", Width: 150, Align: "L"},
+	{Title: "Amount (Rs)", Width: 40, Align: "R"},
+}
+
+// WritePDF writes the invoice as a branded PDF
+func WritePDF(w io.Writer, holder document.Holder, inv *Invoice) error {
+	pdf := document.NewPDF()
+	pdf.Title("Tax invoice")
+	pdf.Details([][2]string{
+		{"Invoice number", inv.Number},
+		{"Invoice date", inv.Date},
+		{"Transaction ID", inv.TxnID},
+		{"Name", holder.Name},
+		{"Mobile", holder.Mobile},
+		{"Account ID", holder.AccountID},
+	})
+
+	description := "Digital metal"
+	if inv.Metal != "" {
+		description = "Digital " + strings.ToLower(inv.Metal)
+	}
+	pdf.Section("Items")
+	pdf.Table(lineColumns, [][]string{{
+		description,
+		inv.HSN,
+		inv.Quantity.StringFixed(4),
+		inv.Rate.StringFixed(2),
+		inv.Gross.StringFixed(2),
+	}}, "")
+
+	totals := [][]string{{"Gross amount", inv.Gross.StringFixed(2)}}
+	for _, t := range inv.Taxes {
+		totals = append(totals, []string{fmt.Sprintf("%v @ %v%%", t.Type, t.Percent), t.Amount.StringFixed(2)})
+	}
+	totals = append(totals,
+		[]string{"Total tax", inv.TotalTax.StringFixed(2)},
+		[]string{"Net amount", inv.Net.StringFixed(2)},
+	)
+	pdf.Section("Totals")
+	pdf.Table(totalColumns, totals, "")
+	pdf.Note("The metal is sold and vaulted by Augmont Goldtech Pvt. Ltd., this invoice is issued on its behalf.")
+
+	return pdf.Write(w)
+}
+
+func text(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// number parses numbers sent as strings or numbers, zero if malformed
+func number(v interface{}) decimal.Decimal {
+	d, err := decimal.NewFromString(text(v))
+	if err != nil {
+		return decimal.Zero
+	}
+	return d
+}
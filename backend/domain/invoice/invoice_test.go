@@ -0,0 +1,61 @@
+package invoice
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/document"
+)
+
+const augmontInvoice = `{
+	"invoiceNumber": "AUG/2025/001",
+	"invoiceDate": "2025-09-01",
+	"hsnCode": "71081300",
+	"metalType": "Gold",
+	"quantity": "0.1000",
+	"rate": 10000,
+	"grossAmount": "1000.00",
+	"netAmount": 1030,
+	"taxes": {
+		"totalTaxAmount": "30.00",
+		"taxSplit": [
+			{"type": "CGST", "taxPerc": "1.50", "taxAmount": "15.00"},
+			{"type": "SGST", "taxPerc": 1.5, "taxAmount": 15}
+		]
+	}
+}`
+
+func parse(t *testing.T) *Invoice {
+	data := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal([]byte(augmontInvoice), &data))
+	return Parse(data, "T1")
+}
+
+func TestParse(t *testing.T) {
+	t.Run("should read strings and numbers", func(t *testing.T) {
+		inv := parse(t)
+		assert.Equal(t, "AUG/2025/001", inv.Number)
+		assert.Equal(t, "0.1", inv.Quantity.String())
+		assert.Equal(t, "10000", inv.Rate.String())
+		assert.Equal(t, "1030", inv.Net.String())
+		assert.Len(t, inv.Taxes, 2)
+		assert.Equal(t, "15", inv.Taxes[1].Amount.String())
+	})
+
+	t.Run("should leave missing values empty", func(t *testing.T) {
+		inv := Parse(map[string]interface{}{"quantity": "N/A"}, "T1")
+		assert.Equal(t, "T1", inv.TxnID)
+		assert.True(t, inv.Quantity.IsZero())
+		assert.Empty(t, inv.Taxes)
+	})
+}
+
+func TestWritePDF(t *testing.T) {
+	buf := &bytes.Buffer{}
+	holder := document.Holder{Name: "Asha", Mobile: "9876543210", AccountID: "U1"}
+	assert.NoError(t, WritePDF(buf, holder, parse(t)))
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+}
@@ -0,0 +1,105 @@
+// Package jobs defines the background jobs, queued on Redis and run by
+// the workers, a job may run more than once if a worker stops mid-way
+package jobs
+
+import (
+	"encoding/json"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Job types
+const (
+	TypeFetchInvoice = "fetch_invoice"
+	TypeRefreshKYC   = "refresh_kyc"
+)
+
+// Queues, each has its own workers
+const (
+	QueueInvoices = "invoices"
+	QueueKYC      = "kyc"
+)
+
+// Job is a background job
+type Job interface {
+	JobType() string
+	// Queue the job runs on
+	Queue() string
+}
+
+// FetchInvoice stores the GST invoice of a new order
+type FetchInvoice struct {
+	AugmontUserID uint64 `json:"goldUserId"`
+	MerchantTxnID string `json:"merchantTxnID"`
+}
+
+func (FetchInvoice) JobType() string { return TypeFetchInvoice }
+func (FetchInvoice) Queue() string   { return QueueInvoices }
+
+// RefreshKYC checks the KYC submitted by a user with Augmont until it
+// is approved or rejected
+type RefreshKYC struct {
+	AugmontUserID uint64 `json:"goldUserId"`
+}
+
+func (RefreshKYC) JobType() string { return TypeRefreshKYC }
+func (RefreshKYC) Queue() string   { return QueueKYC }
+
+// registry creates an empty job of each type to decode into
+var registry = map[string]func() Job{
+	TypeFetchInvoice: func() Job { return &FetchInvoice{} },
+	TypeRefreshKYC:   func() Job { return &RefreshKYC{} },
+}
+
+// ErrUnknownType is returned when decoding a job of a type not in this build
+var ErrUnknownType = errors.New("unknown job type")
+
+// QueueOf returns the queue of the job type
+func QueueOf(jobType string) (string, bool) {
+	newJob, ok := registry[jobType]
+	if !ok {
+		return "", false
+	}
+	return newJob().Queue(), true
+}
+
+// Encode returns the JSON payload of the job
+func Encode(j Job) ([]byte, error) {
+	return json.Marshal(j)
+}
+
+// Decode returns the job of the type from its JSON payload
+func Decode(jobType string, payload []byte) (Job, error) {
+	newJob, ok := registry[jobType]
+	if !ok {
+		return nil, errors.Wrapf(ErrUnknownType, "%q", jobType)
+	}
+	j := newJob()
+	if err := json.Unmarshal(payload, j); err != nil {
+		return nil, errors.Wrapf(err, "decode %v job", jobType)
+	}
+	return j, nil
+}
+
+// permanent is a failure retrying will not fix
+type permanent struct {
+	cause error
+}
+
+func (p *permanent) Error() string { return p.cause.Error() }
+func (p *permanent) Unwrap() error { return p.cause }
+
+// Permanent marks the failure of a job as final, the job goes to the
+// dead letters without being retried
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanent{cause: err}
+}
+
+// IsPermanent returns if the failure was marked Permanent
+func IsPermanent(err error) bool {
+	var p *permanent
+	return errors.As(err, &p)
+}
@@ -0,0 +1,42 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode(t *testing.T) {
+	t.Run("should decode what was encoded", func(t *testing.T) {
+		job := &FetchInvoice{AugmontUserID: 1, MerchantTxnID: "B1"}
+		payload, err := Encode(job)
+		assert.NoError(t, err)
+
+		j, err := Decode(job.JobType(), payload)
+		assert.NoError(t, err)
+		assert.Equal(t, job, j)
+	})
+
+	t.Run("should tell unknown types apart", func(t *testing.T) {
+		_, err := Decode("reconcile", []byte(`{}`))
+		assert.True(t, errors.Is(err, ErrUnknownType))
+	})
+
+	t.Run("should register the queue of every type", func(t *testing.T) {
+		for jobType, newJob := range registry {
+			assert.Equal(t, jobType, newJob().JobType())
+			queue, ok := QueueOf(jobType)
+			assert.True(t, ok)
+			assert.Equal(t, newJob().Queue(), queue)
+		}
+	})
+}
+
+func TestPermanent(t *testing.T) {
+	err := errors.Wrap(Permanent(errors.New("order not found")), "fetch invoice")
+	assert.True(t, IsPermanent(err))
+	assert.Equal(t, "fetch invoice: order not found", err.Error())
+	assert.False(t, IsPermanent(errors.New("timeout")))
+	assert.Nil(t, Permanent(nil))
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Hook is a named stop function registered by a background worker
+type Hook struct {
+	Name   string
+	OnStop func(ctx context.Context) error
+}
+
+// Lifecycle collects the stop hooks of the background workers,
+// so the server can stop them before closing the connections
+type Lifecycle struct {
+	mu    sync.Mutex
+	hooks []Hook
+}
+
+// NewLifecycle returns an empty Lifecycle
+func NewLifecycle() *Lifecycle {
+	return &Lifecycle{}
+}
+
+// Append registers a stop hook
+func (l *Lifecycle) Append(hook Hook) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = append(l.hooks, hook)
+}
+
+// Stop runs the stop hooks in reverse order of registration,
+// every hook runs even if an earlier one fails
+func (l *Lifecycle) Stop(ctx context.Context) error {
+	l.mu.Lock()
+	hooks := l.hooks
+	l.hooks = nil
+	l.mu.Unlock()
+
+	var errs error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hook := hooks[i]
+		log.WithField("hook", hook.Name).Info("stopping")
+		if err := hook.OnStop(ctx); err != nil {
+			errs = errors.CombineErrors(errs, errors.Wrapf(err, "stop %v", hook.Name))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"context"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// RequestIDHeader carries the request id between services
+const RequestIDHeader = "X-Request-ID"
+
+type ctxKey int
+
+const (
+	loggerKey ctxKey = iota
+	requestIDKey
+)
+
+// SetupLogger configures the global logrus logger,
+// JSON output outside dev so the log collector can parse fields
+func SetupLogger() {
+	log.SetOutput(os.Stdout)
+	if Config().Server.Env != "dev" {
+		log.SetFormatter(&log.JSONFormatter{})
+	}
+
+	level, err := log.ParseLevel(Config().Server.LogLevel)
+	if err != nil {
+		log.WithField("level", Config().Server.LogLevel).Warn("unknown log level, using info")
+		level = log.InfoLevel
+	}
+	log.SetLevel(level)
+}
+
+// Logger returns the request scoped log entry,
+// or the standard logger if the context has none
+func Logger(ctx context.Context) *log.Entry {
+	if ctx != nil {
+		if entry, ok := ctx.Value(loggerKey).(*log.Entry); ok {
+			return entry
+		}
+	}
+	return log.NewEntry(log.StandardLogger())
+}
+
+// ContextWithLogger stores the log entry in the context
+func ContextWithLogger(ctx context.Context, entry *log.Entry) context.Context {
+	return context.WithValue(ctx, loggerKey, entry)
+}
+
+// ContextWithLogFields adds fields to the request scoped log entry
+func ContextWithLogFields(ctx context.Context, fields log.Fields) context.Context {
+	return ContextWithLogger(ctx, Logger(ctx).WithFields(fields))
+}
+
+// RequestID returns the request id stored in the context
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// ContextWithRequestID stores the request id in the context
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
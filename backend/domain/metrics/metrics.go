@@ -0,0 +1,138 @@
+// Package metrics holds the prometheus collectors shared
+// by the controllers, services and repos
+package metrics
+
+import (
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "pinch"
+
+// ---- HTTP ----
+
+// HTTPRequestDuration observes api requests per gin route and status
+var HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Subsystem: "http",
+	Name:      "request_duration_seconds",
+	Help:      "Duration of HTTP requests by route and status.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"method", "route", "status"})
+
+// ---- Augmont ----
+
+// AugmontRequests counts augmont api calls per endpoint and statusCode
+var AugmontRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "augmont",
+	Name:      "requests_total",
+	Help:      "Augmont API calls by endpoint and status code.",
+}, []string{"method", "endpoint", "statusCode"})
+
+// AugmontRequestDuration observes augmont api calls per endpoint
+var AugmontRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Subsystem: "augmont",
+	Name:      "request_duration_seconds",
+	Help:      "Duration of Augmont API calls by endpoint.",
+	Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+}, []string{"method", "endpoint"})
+
+// AugmontTokenRefreshes counts augmont logins by outcome
+var AugmontTokenRefreshes = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "augmont",
+	Name:      "token_refreshes_total",
+	Help:      "Augmont auth token refreshes by outcome.",
+}, []string{"outcome"})
+
+// ---- Database ----
+
+// DBQueryDuration observes gorm queries per operation and table
+var DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Subsystem: "db",
+	Name:      "query_duration_seconds",
+	Help:      "Duration of database queries by operation and table.",
+	Buckets:   []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+}, []string{"operation", "table", "outcome"})
+
+// ---- Business ----
+
+// OrdersCreated counts the orders placed with augmont
+var OrdersCreated = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "orders",
+	Name:      "created_total",
+	Help:      "Orders created by type and metal.",
+}, []string{"type", "metal"})
+
+// KYCSubmissions counts the KYC documents submitted to augmont
+var KYCSubmissions = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "kyc",
+	Name:      "submissions_total",
+	Help:      "KYC submissions by outcome.",
+}, []string{"outcome"})
+
+// Outcome label value of an error
+func Outcome(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
+
+// ---- Redis ----
+
+// redisPoolCollector exports the connection pool stats of a redis client
+type redisPoolCollector struct {
+	client *redis.Client
+
+	hits       *prometheus.Desc
+	misses     *prometheus.Desc
+	timeouts   *prometheus.Desc
+	totalConns *prometheus.Desc
+	idleConns  *prometheus.Desc
+	staleConns *prometheus.Desc
+}
+
+// NewRedisPoolCollector returns a collector for the client pool stats
+func NewRedisPoolCollector(client *redis.Client) prometheus.Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "redis_pool", name),
+			help, nil, nil,
+		)
+	}
+	return &redisPoolCollector{
+		client:     client,
+		hits:       desc("hits_total", "Free connections found in the pool."),
+		misses:     desc("misses_total", "Free connections not found in the pool."),
+		timeouts:   desc("timeouts_total", "Waits for a connection that timed out."),
+		totalConns: desc("conns", "Connections in the pool."),
+		idleConns:  desc("idle_conns", "Idle connections in the pool."),
+		staleConns: desc("stale_conns_total", "Stale connections removed from the pool."),
+	}
+}
+
+func (c *redisPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.timeouts
+	ch <- c.totalConns
+	ch <- c.idleConns
+	ch <- c.staleConns
+}
+
+func (c *redisPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.client.PoolStats()
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(c.timeouts, prometheus.CounterValue, float64(stats.Timeouts))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stats.TotalConns))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stats.IdleConns))
+	ch <- prometheus.MustNewConstMetric(c.staleConns, prometheus.CounterValue, float64(stats.StaleConns))
+}
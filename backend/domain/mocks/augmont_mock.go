@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"context"
+
 	"github.com/stretchr/testify/mock"
 
 	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
@@ -15,16 +17,16 @@ func NewAugmontService() *AugmontService {
 	return &AugmontService{}
 }
 
-func (m *AugmontService) CreateUser(info *utils.AugmontUserInfo, user *models.AugmontUser) error {
+func (m *AugmontService) CreateUser(ctx context.Context, info *utils.AugmontUserInfo, user *models.AugmontUser) error {
 	args := m.Called(info, user)
 	return args.Error(0)
 }
-func (m *AugmontService) GetUserInfo(user string) (*utils.AugmontUserInfo, error) {
+func (m *AugmontService) GetUserInfo(ctx context.Context, user string) (*utils.AugmontUserInfo, error) {
 	args := m.Called(user)
 	return nil, args.Error(0)
 }
 
-func (m *AugmontService) UpdateUser(info *utils.AugmontUserInfo) error {
+func (m *AugmontService) UpdateUser(ctx context.Context, info *utils.AugmontUserInfo) error {
 	args := m.Called(info)
 	return args.Error(0)
 }
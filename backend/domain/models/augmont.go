@@ -26,6 +26,13 @@ type AugmontUser struct {
 	Address []*AugmontUserAddress `json:"address" gorm:"->"`
 }
 
+// KYC statuses
+const (
+	KYCPending  = "pending"
+	KYCApproved = "approved"
+	KYCRejected = "rejected"
+)
+
 // Augment User Bank Model
 // atmost 10 banks per Augmont User
 type AugmontUserBank struct {
@@ -56,6 +63,36 @@ type AugmontUserAddress struct {
 	AugmontUser *AugmontUser `json:"goldUser" gorm:"foreignkey:AugmontUserID"`
 }
 
+// Order types
+const (
+	OrderBuy    = "buy"
+	OrderSell   = "sell"
+	OrderRedeem = "redeem"
+)
+
+// Order statuses
+const (
+	OrderPending   = "pending"
+	OrderCompleted = "completed"
+	// Refused by Augmont, kept with the requested metal and quantity
+	OrderFailed = "failed"
+	// Failed and placed again as a new order
+	OrderRerun = "rerun"
+)
+
+// AugmontOrderInfo is the part of an order kept locally,
+// so the order history is served without Augmont
+type AugmontOrderInfo struct {
+	Status    *string `json:"status" gorm:"type:varchar(20); not null; default:completed"`
+	MetalType *string `json:"metalType" gorm:"type:varchar(10)"`
+	// Grams, of the first metal for redeem orders of several metals
+	Quantity *string `json:"quantity" gorm:"type:numeric(14,4)"`
+	// Rupees paid or received, including taxes
+	Amount *string `json:"amount" gorm:"type:numeric(14,2)"`
+	// Locked rate per gram
+	Rate *string `json:"rate" gorm:"type:numeric(14,2)"`
+}
+
 type AugmontRedeemOrder struct {
 	ID        *uint64    `json:"id" gorm:"primary_key;autoIncrement"`
 	CreatedAt *time.Time `json:"createdAt"`
@@ -65,10 +102,32 @@ type AugmontRedeemOrder struct {
 	MerchantTxnID *string `json:"merchantTxnID" gorm:"not null; unique"`
 	AugmontUserID *uint64 `json:"goldUserID" gorm:"not null"`
 
+	AugmontOrderInfo
+
+	// Delivery of the products, empty for orders placed before tracking
+	ShipmentStatus *string `json:"shipmentStatus" gorm:"type:varchar(20); index"`
+	Courier        *string `json:"courier"`
+	TrackingNumber *string `json:"trackingNumber"`
+
+	// Metal delivered, created with the order
+	Metals []*AugmontRedeemMetal `json:"metals,omitempty" gorm:"foreignkey:RedeemOrderID"`
+
 	// Relations
 	AugmontUser *AugmontUser `json:"goldUser" gorm:"foreignkey:AugmontUserID"`
 }
 
+// AugmontRedeemMetal is the grams of a metal delivered by a redeem order,
+// one for each metal of its products
+type AugmontRedeemMetal struct {
+	ID        *uint64    `json:"-" gorm:"primary_key;autoIncrement"`
+	CreatedAt *time.Time `json:"-"`
+
+	RedeemOrderID *uint64 `json:"-" gorm:"not null; uniqueIndex:idx_redeem_order_metal"`
+	MetalType     *string `json:"metalType" gorm:"type:varchar(10); not null; uniqueIndex:idx_redeem_order_metal"`
+	// Grams
+	Quantity *string `json:"quantity" gorm:"type:numeric(14,4); not null"`
+}
+
 type AugmontBuyOrder struct {
 	ID        *uint64    `json:"id" gorm:"primary_key;autoIncrement"`
 	CreatedAt *time.Time `json:"createdAt"`
@@ -78,6 +137,8 @@ type AugmontBuyOrder struct {
 	MerchantTxnID *string `json:"merchantTxnID" gorm:"not null; unique"`
 	AugmontUserID *uint64 `json:"goldUserID" gorm:"not null"`
 
+	AugmontOrderInfo
+
 	// Relations
 	AugmontUser *AugmontUser `json:"goldUser" gorm:"foreignkey:AugmontUserID"`
 }
@@ -91,6 +152,83 @@ type AugmontSellOrder struct {
 	MerchantTxnID *string `json:"merchantTxnID" gorm:"not null; unique"`
 	AugmontUserID *uint64 `json:"goldUserID" gorm:"not null"`
 
+	AugmontOrderInfo
+
 	// Relations
 	AugmontUser *AugmontUser `json:"goldUser" gorm:"foreignkey:AugmontUserID"`
 }
+
+// AugmontOrder is an order of any type, read from
+// the union of the order tables, never migrated
+type AugmontOrder struct {
+	ID        *uint64    `json:"id"`
+	CreatedAt *time.Time `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+
+	// buy, sell or redeem, or the type of a ledger entry
+	// read with the orders
+	Type          *string `json:"type"`
+	MerchantTxnID *string `json:"merchantTxnID"`
+	AugmontUserID *uint64 `json:"goldUserID"`
+
+	AugmontOrderInfo
+}
+
+// Statement formats
+const (
+	StatementCSV = "csv"
+	StatementPDF = "pdf"
+)
+
+// Statement statuses
+const (
+	StatementPending = "pending"
+	StatementReady   = "ready"
+	StatementFailed  = "failed"
+)
+
+// AugmontStatement is a statement of the orders of a date range,
+// generated in the background and kept in the file storage
+type AugmontStatement struct {
+	ID        *uint64    `json:"id" gorm:"primary_key;autoIncrement"`
+	CreatedAt *time.Time `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+
+	AugmontUserID *uint64 `json:"goldUserID" gorm:"not null; index"`
+
+	// Dates of the range, both inclusive
+	From   *time.Time `json:"from" gorm:"type:date; not null"`
+	To     *time.Time `json:"to" gorm:"type:date; not null"`
+	Format *string    `json:"format" gorm:"type:varchar(10); not null"`
+
+	// pending -> generating, ready -> stored at FileKey, failed
+	Status  *string `json:"status" gorm:"type:varchar(20); not null"`
+	FileKey *string `json:"-"`
+
+	// Relations
+	AugmontUser *AugmontUser `json:"-" gorm:"foreignkey:AugmontUserID"`
+}
+
+// AugmontInvoice is the GST invoice of a buy or redeem order, the
+// Augmont data and the rendered PDF are kept in the file storage
+type AugmontInvoice struct {
+	ID        *uint64    `json:"id" gorm:"primary_key;autoIncrement"`
+	CreatedAt *time.Time `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+
+	AugmontUserID *uint64 `json:"goldUserID" gorm:"not null; index"`
+	// One invoice for each order
+	MerchantTxnID *string `json:"merchantTxnID" gorm:"not null; unique"`
+	// buy or redeem
+	OrderType *string `json:"orderType" gorm:"type:varchar(10); not null"`
+
+	InvoiceNumber *string `json:"invoiceNumber"`
+	// As sent by Augmont
+	InvoiceDate *string `json:"invoiceDate"`
+
+	DataKey *string `json:"-" gorm:"not null"`
+	FileKey *string `json:"-" gorm:"not null"`
+
+	// Relations
+	AugmontUser *AugmontUser `json:"-" gorm:"foreignkey:AugmontUserID"`
+}
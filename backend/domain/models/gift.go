@@ -0,0 +1,79 @@
+package models
+
+import "time"
+
+// Gift statuses, a gift is sending until Augmont answers its transfer,
+// gifts to users without KYC wait in escrow until claimed
+const (
+	GiftSending   = "sending"
+	GiftFailed    = "failed"
+	GiftCompleted = "completed"
+	GiftEscrowed  = "escrowed"
+	GiftClaiming  = "claiming"
+	GiftClaimed   = "claimed"
+)
+
+// AugmontGift is metal transferred from one user to another by mobile number
+type AugmontGift struct {
+	ID        *uint64    `json:"id" gorm:"primary_key;autoIncrement"`
+	CreatedAt *time.Time `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+
+	SenderID        *uint64 `json:"senderId" gorm:"not null; index"`
+	RecipientMobile *string `json:"recipientMobile" gorm:"type:varchar(10); not null; index"`
+	// Empty until a recipient without KYC starts to claim the gift, set
+	// while sending to a recipient with KYC
+	RecipientID *uint64 `json:"recipientId" gorm:"index"`
+
+	MetalType *string `json:"metalType" gorm:"type:varchar(10); not null"`
+	// Grams
+	Quantity *string `json:"quantity" gorm:"type:numeric(14,4); not null"`
+	Message  *string `json:"message" gorm:"type:varchar(200)"`
+
+	Status *string `json:"status" gorm:"type:varchar(20); not null"`
+	// Transfer from the sender, to the recipient or to the escrow, saved
+	// before the transfer and reused when it is retried
+	TransferTxnID *string `json:"transferTxnID" gorm:"not null; unique"`
+	// Transfer from the escrow to the recipient, saved before the
+	// transfer and reused when it is retried
+	ClaimTxnID *string    `json:"claimTxnID"`
+	ClaimedAt  *time.Time `json:"claimedAt"`
+
+	// Relations
+	Sender *AugmontUser `json:"-" gorm:"foreignkey:SenderID"`
+}
+
+// Ledger entry types
+const (
+	LedgerGiftSent     = "gift_sent"
+	LedgerGiftReceived = "gift_received"
+)
+
+// AugmontLedgerEntry is metal moved in or out of a user account
+// other than by orders
+type AugmontLedgerEntry struct {
+	ID        *uint64    `json:"id" gorm:"primary_key;autoIncrement"`
+	CreatedAt *time.Time `json:"createdAt"`
+
+	AugmontUserID *uint64 `json:"goldUserID" gorm:"not null; index"`
+	Type          *string `json:"type" gorm:"type:varchar(20); not null"`
+	MetalType     *string `json:"metalType" gorm:"type:varchar(10); not null"`
+	// Grams, negative when the metal left the account
+	Quantity      *string `json:"quantity" gorm:"type:numeric(14,4); not null"`
+	MerchantTxnID *string `json:"merchantTxnID" gorm:"not null"`
+	GiftID        *uint64 `json:"giftId" gorm:"index"`
+
+	// Relations
+	AugmontUser *AugmontUser `json:"-" gorm:"foreignkey:AugmontUserID"`
+	Gift        *AugmontGift `json:"-" gorm:"foreignkey:GiftID"`
+}
+
+// GiftRecipient is who a gift to a mobile number goes to, never migrated
+type GiftRecipient struct {
+	Mobile string `json:"mobile"`
+	// First name of the Pinch user, empty if there is none
+	Name    string `json:"name"`
+	OnPinch bool   `json:"onPinch"`
+	// Gifts to users without KYC wait in escrow until they claim them
+	Escrowed bool `json:"escrowed"`
+}
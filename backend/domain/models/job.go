@@ -0,0 +1,23 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Job is a background job as stored in the queue, never migrated
+type Job struct {
+	ID      string          `json:"id"`
+	Type    string          `json:"type"`
+	Queue   string          `json:"queue"`
+	Payload json.RawMessage `json:"payload"`
+
+	// Runs so far, counted when a worker takes the job
+	Attempts    int    `json:"attempts"`
+	MaxAttempts int    `json:"maxAttempts"`
+	LastError   string `json:"lastError,omitempty"`
+
+	EnqueuedAt time.Time `json:"enqueuedAt"`
+	// Not run before
+	RunAt time.Time `json:"runAt"`
+}
@@ -0,0 +1,90 @@
+package models
+
+import "time"
+
+// Notification channels
+const (
+	ChannelSMS   = "sms"
+	ChannelEmail = "email"
+	ChannelPush  = "push"
+)
+
+// Channels are the notification channels
+var Channels = []string{ChannelSMS, ChannelEmail, ChannelPush}
+
+// Notification statuses, failed sends are retried as pending until
+// they run out of attempts
+const (
+	NotificationPending = "pending"
+	NotificationSending = "sending"
+	NotificationSent    = "sent"
+	NotificationFailed  = "failed"
+)
+
+// NotificationPreference is how a user wants to be notified, users
+// without one get every channel they have an address for
+type NotificationPreference struct {
+	ID        *uint64    `json:"-" gorm:"primary_key;autoIncrement"`
+	CreatedAt *time.Time `json:"-"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+
+	UserID *uint64 `json:"-" gorm:"not null; unique"`
+
+	SMS   *bool `json:"sms" gorm:"not null; default:true"`
+	Email *bool `json:"email" gorm:"not null; default:true"`
+	Push  *bool `json:"push" gorm:"not null; default:true"`
+
+	EmailAddress *string `json:"emailAddress" gorm:"type:varchar(100)"`
+	// Token of the device the push notifications are sent to
+	PushToken *string `json:"pushToken" gorm:"type:varchar(255)"`
+
+	// SMS and push notifications due between the quiet hours, HH:MM in
+	// IST, wait for them to end, empty for the default quiet hours
+	QuietStart *string `json:"quietStart" gorm:"type:varchar(5)"`
+	QuietEnd   *string `json:"quietEnd" gorm:"type:varchar(5)"`
+
+	// Relations
+	User *User `json:"-" gorm:"foreignkey:UserID"`
+}
+
+// Enabled returns if the user wants notifications on the channel
+func (p *NotificationPreference) Enabled(channel string) bool {
+	var enabled *bool
+	switch channel {
+	case ChannelSMS:
+		enabled = p.SMS
+	case ChannelEmail:
+		enabled = p.Email
+	case ChannelPush:
+		enabled = p.Push
+	}
+	return enabled == nil || *enabled
+}
+
+// Notification is a message to a user on one channel, with its delivery
+type Notification struct {
+	ID        *uint64    `json:"id" gorm:"primary_key;autoIncrement"`
+	CreatedAt *time.Time `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+
+	UserID *uint64 `json:"-" gorm:"not null; index"`
+	// Name of the notification template
+	Name    *string `json:"name" gorm:"type:varchar(50); not null"`
+	Channel *string `json:"channel" gorm:"type:varchar(10); not null; uniqueIndex:idx_notifications_event,priority:2"`
+	// Event the notification was sent for, once per channel
+	EventID *uint64 `json:"-" gorm:"uniqueIndex:idx_notifications_event,priority:1"`
+	// Mobile number, email address or push token it is sent to
+	Recipient *string `json:"-" gorm:"type:varchar(255); not null"`
+	Title     *string `json:"title"`
+	Body      *string `json:"body"`
+
+	Status    *string `json:"status" gorm:"type:varchar(10); not null; index:idx_notifications_due,priority:1"`
+	Attempts  *int    `json:"attempts" gorm:"not null; default:0"`
+	LastError *string `json:"-"`
+	// Not sent before, set by the quiet hours and the retries
+	SendAfter *time.Time `json:"sendAfter" gorm:"not null; index:idx_notifications_due,priority:2"`
+	SentAt    *time.Time `json:"sentAt"`
+
+	// Relations
+	User *User `json:"-" gorm:"foreignkey:UserID"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// OutboxEvent is a domain event saved with the write that raised it,
+// waiting to be published to the event bus
+type OutboxEvent struct {
+	ID        *uint64    `json:"id" gorm:"primary_key;autoIncrement"`
+	CreatedAt *time.Time `json:"createdAt"`
+
+	Type    *string `json:"type" gorm:"type:varchar(50); not null"`
+	Payload *string `json:"payload" gorm:"type:jsonb; not null"`
+
+	// Empty until the relay published the event
+	PublishedAt *time.Time `json:"publishedAt" gorm:"index:idx_outbox_events_unpublished,where:published_at IS NULL"`
+}
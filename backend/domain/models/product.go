@@ -0,0 +1,79 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/shopspring/decimal"
+)
+
+// StringList is a list of strings kept as a json array in a text column
+type StringList []string
+
+// Value implements driver.Valuer
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(l)
+	return string(b), err
+}
+
+// Scan implements sql.Scanner
+func (l *StringList) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case string:
+		return json.Unmarshal([]byte(src), l)
+	case []byte:
+		return json.Unmarshal(src, l)
+	}
+	return errors.Newf("can not scan %T into a string list", src)
+}
+
+// AugmontProduct is a coin or bar that can be delivered against
+// the metal held, synced from the Augmont catalogue
+type AugmontProduct struct {
+	ID        *uint64    `json:"id" gorm:"primary_key;autoIncrement"`
+	CreatedAt *time.Time `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+
+	SKU       *string `json:"sku" gorm:"type:varchar(50); not null; unique"`
+	Name      *string `json:"name" gorm:"not null"`
+	MetalType *string `json:"metalType" gorm:"type:varchar(10); not null; index"`
+	Purity    *string `json:"purity" gorm:"type:varchar(10)"`
+	// Grams of metal taken from the balance for each piece
+	Weight *string `json:"weight" gorm:"type:numeric(10,4); not null"`
+	// Rupees charged for each piece on delivery
+	MakingCharges *string    `json:"makingCharges" gorm:"type:numeric(14,2)"`
+	Images        StringList `json:"images" gorm:"type:text"`
+	// Pieces in stock, empty if Augmont does not say
+	Stock *int64 `json:"stock"`
+	// Products dropped from the Augmont catalogue are kept inactive
+	Active *bool `json:"active" gorm:"not null; default:true; index"`
+
+	SyncedAt *time.Time `json:"syncedAt"`
+}
+
+// CartItem is a product in the delivery cart with its quantity
+type CartItem struct {
+	SKU      string          `json:"sku"`
+	Quantity int64           `json:"quantity"`
+	Product  *AugmontProduct `json:"product"`
+	// Grams and rupees of all the pieces
+	Weight        decimal.Decimal `json:"weight"`
+	MakingCharges decimal.Decimal `json:"makingCharges"`
+}
+
+// Cart is the delivery cart of a user, never migrated, products no
+// longer in the catalogue are dropped from the items
+type Cart struct {
+	Items []*CartItem `json:"items"`
+	// Grams of each metal taken from the balance
+	Weights       map[string]decimal.Decimal `json:"weights"`
+	MakingCharges decimal.Decimal            `json:"makingCharges"`
+}
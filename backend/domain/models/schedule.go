@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// Outcomes of the scheduled runs
+const (
+	ScheduleRunning   = "running"
+	ScheduleSucceeded = "succeeded"
+	ScheduleFailed    = "failed"
+)
+
+// ScheduleOff is the spec of the scheduled tasks turned off
+const ScheduleOff = "off"
+
+// Schedule is a scheduled task with its last and next run, kept by the
+// scheduler leader
+type Schedule struct {
+	Name      *string    `json:"name" gorm:"primary_key; type:varchar(50)"`
+	CreatedAt *time.Time `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+
+	// Cron expression in IST, or off
+	Spec *string `json:"spec" gorm:"type:varchar(100); not null"`
+
+	LastRunAt   *time.Time `json:"lastRunAt"`
+	LastOutcome *string    `json:"lastOutcome" gorm:"type:varchar(10)"`
+	LastError   *string    `json:"lastError"`
+	// Empty while the task is off
+	NextRunAt *time.Time `json:"nextRunAt"`
+}
+
+// ScheduleRun is a run of a scheduled task
+type ScheduleRun struct {
+	ID *uint64 `json:"id" gorm:"primary_key;autoIncrement"`
+
+	Name *string `json:"name" gorm:"type:varchar(50); not null; index"`
+	// Replica that ran the task
+	Host *string `json:"host" gorm:"type:varchar(100); not null"`
+
+	StartedAt  *time.Time `json:"startedAt" gorm:"not null"`
+	FinishedAt *time.Time `json:"finishedAt"`
+	Outcome    *string    `json:"outcome" gorm:"type:varchar(10); not null; index"`
+	Error      *string    `json:"error"`
+	// Renewed by the replica while the run goes on, a run left running
+	// past it is interrupted
+	LeaseUntil *time.Time `json:"-"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// Shipment statuses of redeem orders, in the order they happen,
+// returned shipments go back to Augmont instead of being delivered
+const (
+	ShipmentPlaced     = "placed"
+	ShipmentDispatched = "dispatched"
+	ShipmentInTransit  = "in_transit"
+	ShipmentDelivered  = "delivered"
+	ShipmentReturned   = "returned"
+)
+
+// ShipmentStatuses are the shipment statuses in order
+var ShipmentStatuses = []string{
+	ShipmentPlaced,
+	ShipmentDispatched,
+	ShipmentInTransit,
+	ShipmentDelivered,
+	ShipmentReturned,
+}
+
+// Sources of shipment updates
+const (
+	ShipmentSourcePoll     = "poll"
+	ShipmentSourceCallback = "callback"
+)
+
+// AugmontShipmentEvent is a change of the shipment status of a redeem order
+type AugmontShipmentEvent struct {
+	ID        *uint64    `json:"id" gorm:"primary_key;autoIncrement"`
+	CreatedAt *time.Time `json:"createdAt"`
+
+	RedeemOrderID *uint64 `json:"-" gorm:"not null; index"`
+	Status        *string `json:"status" gorm:"type:varchar(20); not null"`
+	// Where the shipment was, when the courier says
+	Location *string `json:"location"`
+	// When it happened as reported, else when the change was seen
+	At *time.Time `json:"at" gorm:"not null"`
+	// poll or callback
+	Source *string `json:"-" gorm:"type:varchar(10)"`
+
+	// Relations
+	RedeemOrder *AugmontRedeemOrder `json:"-" gorm:"foreignkey:RedeemOrderID"`
+}
+
+// ShipmentTimeline is the tracking of a redeem order, never migrated
+type ShipmentTimeline struct {
+	MerchantTxnID  *string                 `json:"merchantTxnID"`
+	Status         *string                 `json:"status"`
+	Courier        *string                 `json:"courier"`
+	TrackingNumber *string                 `json:"trackingNumber"`
+	Events         []*AugmontShipmentEvent `json:"events"`
+}
@@ -6,10 +6,14 @@ import (
 
 // Pitch  User Model
 type User struct {
-	ID *uint64 `json:"id"`
+	ID        *uint64    `json:"id"`
+	CreatedAt *time.Time `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt"`
 
 	Mobile *string `json:"mobile" gorm:"type:varchar(10); unique; not null"`
 	Name   *string `json:"name" gorm:"type:varchar(50);"`
+	// Preferred language, overrides Accept-Language
+	Locale *string `json:"locale" gorm:"type:varchar(10);"`
 }
 
 // Pitch Admin User Model
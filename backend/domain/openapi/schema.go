@@ -0,0 +1,260 @@
+package openapi
+
+import (
+	"mime/multipart"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	fileType    = reflect.TypeOf(multipart.FileHeader{})
+	decimalType = reflect.TypeOf(decimal.Decimal{})
+	metalType   = reflect.TypeOf(utils.Metal(""))
+)
+
+// tagPatterns are the patterns of the custom binding tags
+var tagPatterns = map[string]string{
+	"mobile":  utils.MobilePattern,
+	"ifsc":    utils.IFSCPattern,
+	"pan":     utils.PANPattern,
+	"pincode": utils.PincodePattern,
+	"date":    utils.DatePattern,
+	"grams":   utils.GramsPattern,
+	"amount":  utils.AmountPattern,
+	"fy":      utils.FYPattern,
+	"clock":   utils.ClockPattern,
+}
+
+// tagDescriptions explain the custom binding tags
+var tagDescriptions = map[string]string{
+	"mobile":  "10 digit Indian mobile number",
+	"date":    "Date as DD-MM-YYYY",
+	"grams":   "Positive quantity in grams, up to 4 decimals",
+	"amount":  "Positive amount in rupees, up to 2 decimals",
+	"pincode": "6 digit pincode",
+	"fy":      "Financial year as YYYY-YY, e.g. 2025-26",
+	"clock":   "Time of day as HH:MM in IST",
+}
+
+// tagEnums are the values of the custom binding tags of enums
+var tagEnums = map[string][]string{
+	"metal": utils.MetalNames(),
+}
+
+// Schema returns the schema of the value, named structs are added
+// to the components and referenced, nil returns an empty schema
+func (d *Document) Schema(v interface{}) *Schema {
+	if v == nil {
+		return &Schema{}
+	}
+	return d.schemaOf(reflect.TypeOf(v))
+}
+
+// QueryParameters returns the query parameters of the fields of a struct,
+// named by their form tags
+func (d *Document) QueryParameters(v interface{}) []*Parameter {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var params []*Parameter
+	for _, field := range structFields(t) {
+		name := tagName(field, "form")
+		if name == "" {
+			continue
+		}
+		schema := d.schemaOf(field.Type)
+		required := applyBinding(schema, field.Tag.Get("binding"))
+		params = append(params, &Parameter{
+			Name:     name,
+			In:       "query",
+			Required: required,
+			Schema:   schema,
+		})
+	}
+	return params
+}
+
+func (d *Document) schemaOf(t reflect.Type) *Schema {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+
+	var schema *Schema
+	switch {
+	case t == timeType:
+		schema = &Schema{Type: "string", Format: "date-time"}
+	case t == fileType:
+		schema = &Schema{Type: "string", Format: "binary"}
+	case t == decimalType:
+		// Amounts and grams are exact decimals in strings
+		schema = &Schema{Type: "string", Format: "decimal"}
+	case t == metalType:
+		schema = &Schema{Type: "string", Enum: utils.MetalNames()}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		// $ref siblings are ignored, so references are never nullable
+		return &Schema{Ref: "#/components/schemas/" + d.component(t)}
+	case t.Kind() == reflect.Struct:
+		schema = d.objectSchema(t)
+	case t.Kind() == reflect.String:
+		schema = &Schema{Type: "string"}
+	case t.Kind() == reflect.Bool:
+		schema = &Schema{Type: "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		schema = &Schema{Type: "integer"}
+		if t.Kind() == reflect.Int64 || t.Kind() == reflect.Uint64 {
+			schema.Format = "int64"
+		}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		schema = &Schema{Type: "number"}
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		schema = &Schema{Type: "array", Items: d.schemaOf(t.Elem())}
+	case t.Kind() == reflect.Map:
+		schema = &Schema{Type: "object", AdditionalProperties: d.schemaOf(t.Elem())}
+	default:
+		// interface{}, e.g. utils.Any results passed through from Augmont
+		schema = &Schema{}
+	}
+	schema.Nullable = nullable && schema.Type != ""
+	return schema
+}
+
+// component adds the named struct to the components once
+// and returns its name, exported or not
+func (d *Document) component(t reflect.Type) string {
+	name := strings.ToUpper(t.Name()[:1]) + t.Name()[1:]
+	if _, ok := d.Components.Schemas[name]; ok {
+		return name
+	}
+	// Reserve the name first, structs may reference themselves
+	d.Components.Schemas[name] = &Schema{}
+	*d.Components.Schemas[name] = *d.objectSchema(t)
+	return name
+}
+
+func (d *Document) objectSchema(t reflect.Type) *Schema {
+	schema := &Schema{
+		Type:       "object",
+		Properties: map[string]*Schema{},
+	}
+	for _, field := range structFields(t) {
+		name := tagName(field, "json", "form")
+		if name == "" {
+			continue
+		}
+		prop := d.schemaOf(field.Type)
+		if applyBinding(prop, field.Tag.Get("binding")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = prop
+	}
+	return schema
+}
+
+// structFields returns the exported fields, flattening embedded structs
+func structFields(t reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			fields = append(fields, structFields(field.Type)...)
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// tagName returns the name from the first tag set, empty for skipped fields
+func tagName(field reflect.StructField, tags ...string) string {
+	for _, tag := range tags {
+		name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// applyBinding adds the binding rules to the schema and
+// returns whether the field is required
+func applyBinding(schema *Schema, binding string) bool {
+	required := false
+	target := schema
+	for _, rule := range strings.Split(binding, ",") {
+		tag, param := rule, ""
+		if i := strings.Index(rule, "="); i >= 0 {
+			tag, param = rule[:i], rule[i+1:]
+		}
+
+		// A $ref can not carry rules
+		if target.Ref != "" && tag != "required" && tag != "dive" {
+			continue
+		}
+		switch tag {
+		case "required":
+			required = true
+		case "dive":
+			if target.Items != nil {
+				target = target.Items
+			}
+		case "email":
+			target.Format = "email"
+		case "number":
+			target.Pattern = "^[0-9]+$"
+		case "numeric":
+			target.Pattern = `^-?[0-9]+(\.[0-9]+)?$`
+		case "oneof":
+			target.Enum = strings.Fields(param)
+		case "min", "max", "len":
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				continue
+			}
+			setLength(target, tag, n)
+		default:
+			if enum, ok := tagEnums[tag]; ok {
+				target.Enum = enum
+			}
+			if pattern, ok := tagPatterns[tag]; ok {
+				target.Pattern = pattern
+				target.Description = tagDescriptions[tag]
+			}
+		}
+	}
+	return required
+}
+
+func setLength(schema *Schema, tag string, n int) {
+	if schema.Type == "array" {
+		if tag != "max" {
+			schema.MinItems = &n
+		}
+		return
+	}
+	if schema.Type != "string" {
+		return
+	}
+	if tag != "max" {
+		schema.MinLength = &n
+	}
+	if tag != "min" {
+		schema.MaxLength = &n
+	}
+}
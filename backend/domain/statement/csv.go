@@ -0,0 +1,78 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/document"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+// WriteCSV writes the statement as CSV, the account details,
+// the orders and the balances are separated by empty lines
+func WriteCSV(w io.Writer, s *Statement) error {
+	cw := csv.NewWriter(w)
+
+	records := [][]string{
+		{document.BrandName + " statement"},
+		{"Name", s.Holder.Name},
+		{"Mobile", s.Holder.Mobile},
+		{"Account ID", s.Holder.AccountID},
+		{"Period", s.From.Format(utils.DateLayout) + " to " + s.To.Format(utils.DateLayout)},
+		{"Generated at", s.GeneratedAt.Format("02-01-2006 15:04 MST")},
+		{},
+		{"Date", "Type", "Transaction ID", "Status", "Metal", "Quantity (g)", "Rate (INR/g)", "Amount (INR)"},
+	}
+	for _, row := range s.Rows {
+		records = append(records, []string{
+			row.Date.Format("02-01-2006 15:04"),
+			row.Type,
+			row.TxnID,
+			row.Status,
+			row.Metal,
+			grams(row.Quantity),
+			rupees(row.Rate),
+			rupees(row.Amount),
+		})
+	}
+
+	records = append(records,
+		[]string{},
+		[]string{"Metal", "Opening (g)", "Bought (g)", "Received (g)", "Sold (g)", "Redeemed (g)", "Gifted (g)", "Closing (g)"},
+	)
+	for _, b := range s.Balances {
+		records = append(records, []string{
+			b.Metal,
+			b.Opening.StringFixed(4),
+			b.Bought.StringFixed(4),
+			b.Received.StringFixed(4),
+			b.Sold.StringFixed(4),
+			b.Redeemed.StringFixed(4),
+			b.Gifted.StringFixed(4),
+			b.Closing.StringFixed(4),
+		})
+	}
+
+	if err := cw.WriteAll(records); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// grams formats a quantity, empty if unknown
+func grams(d decimal.Decimal) string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.StringFixed(4)
+}
+
+// rupees formats an amount, empty if unknown
+func rupees(d decimal.Decimal) string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.StringFixed(2)
+}
@@ -0,0 +1,86 @@
+package statement
+
+import (
+	"io"
+	"strings"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/document"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+var balanceColumns = []document.Column{
+	{Title: "Metal", Width: 22, Align: "L"},
+	{Title: "Opening (g)", Width: 24, Align: "R"},
+	{Title: "Bought (g)", Width: 24, Align: "R"},
+	{Title: "Received (g)", Width: 24, Align: "R"},
+	{Title: "Sold (g)", Width: 24, Align: "R"},
+	{Title: "Redeemed (g)", Width: 24, Align: "R"},
+	{Title: "Gifted (g)", Width: 24, Align: "R"},
+	{Title: "Closing (g)", Width: 24, Align: "R"},
+}
+
+var orderColumns = []document.Column{
+	{Title: "Date", Width: 27, Align: "L"},
+	{Title: "Type", Width: 20, Align: "L"},
+	{Title: "Transaction ID", Width: 47, Align: "L"},
+	{Title: "Status", Width: 19, Align: "L"},
+	{Title: "Metal", Width: 14, Align: "L"},
+	{Title: "Qty (g)", Width: 20, Align: "R"},
+	{Title: "Rate (Rs/g)", Width: 21, Align: "R"},
+	{Title: "Amount (Rs)", Width: 22, Align: "R"},
+}
+
+// WritePDF writes the statement as a branded PDF
+func WritePDF(w io.Writer, s *Statement) error {
+	pdf := document.NewPDF()
+	pdf.Title("Transaction statement")
+	pdf.Details([][2]string{
+		{"Name", s.Holder.Name},
+		{"Mobile", s.Holder.Mobile},
+		{"Account ID", s.Holder.AccountID},
+		{"Period", s.From.Format(utils.DateLayout) + " to " + s.To.Format(utils.DateLayout)},
+		{"Generated at", s.GeneratedAt.Format("02-01-2006 15:04 MST")},
+	})
+
+	balances := make([][]string, 0, len(s.Balances))
+	for _, b := range s.Balances {
+		balances = append(balances, []string{
+			title(b.Metal),
+			b.Opening.StringFixed(4),
+			b.Bought.StringFixed(4),
+			b.Received.StringFixed(4),
+			b.Sold.StringFixed(4),
+			b.Redeemed.StringFixed(4),
+			b.Gifted.StringFixed(4),
+			b.Closing.StringFixed(4),
+		})
+	}
+	pdf.Section("Balances")
+	pdf.Table(balanceColumns, balances, "")
+
+	orders := make([][]string, 0, len(s.Rows))
+	for _, row := range s.Rows {
+		orders = append(orders, []string{
+			row.Date.Format("02-01-2006 15:04"),
+			title(strings.ReplaceAll(row.Type, "_", " ")),
+			row.TxnID,
+			title(row.Status),
+			title(row.Metal),
+			grams(row.Quantity),
+			rupees(row.Rate),
+			rupees(row.Amount),
+		})
+	}
+	pdf.Section("Orders")
+	pdf.Table(orderColumns, orders, "No orders in this period")
+
+	return pdf.Write(w)
+}
+
+// title capitalises the first letter, for order types and metals
+func title(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,144 @@
+// Package statement builds and renders the order statements of the users
+package statement
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/document"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+// Row is an order or a gift of the statement, redeem orders have a row
+// for each metal delivered
+type Row struct {
+	Date   time.Time
+	Type   string
+	TxnID  string
+	Status string
+	Metal  string
+	// Grams, rupees per gram and rupees, zero if unknown
+	Quantity decimal.Decimal
+	Rate     decimal.Decimal
+	Amount   decimal.Decimal
+}
+
+// Balance is the movement of a metal in grams over the range
+type Balance struct {
+	Metal    string
+	Opening  decimal.Decimal
+	Bought   decimal.Decimal
+	Received decimal.Decimal
+	Sold     decimal.Decimal
+	Redeemed decimal.Decimal
+	Gifted   decimal.Decimal
+	Closing  decimal.Decimal
+}
+
+// Statement is a date ranged statement of the orders of a user
+type Statement struct {
+	Holder document.Holder
+	// Dates of the range in IST, both inclusive
+	From        time.Time
+	To          time.Time
+	GeneratedAt time.Time
+
+	Rows     []Row
+	Balances []Balance
+}
+
+// Build builds the statement of the range from the orders and gifts
+// created until its end, oldest first, earlier ones count in the
+// opening balance, only completed orders move the balances
+func Build(holder document.Holder, orders []*models.AugmontOrder, from, to, now time.Time) *Statement {
+	s := &Statement{
+		Holder:      holder,
+		From:        from.In(utils.IST),
+		To:          to.In(utils.IST),
+		GeneratedAt: now.In(utils.IST),
+	}
+
+	balances := map[string]*Balance{}
+	for _, metal := range utils.Metals {
+		balances[string(metal)] = &Balance{Metal: string(metal)}
+	}
+
+	for _, order := range orders {
+		row := newRow(order)
+		inRange := !row.Date.Before(from)
+		if inRange {
+			s.Rows = append(s.Rows, row)
+		}
+
+		balance, ok := balances[row.Metal]
+		if !ok || row.Status != models.OrderCompleted {
+			continue
+		}
+		in := row.Type == models.OrderBuy || row.Type == models.LedgerGiftReceived
+		switch {
+		case !inRange && in:
+			balance.Opening = balance.Opening.Add(row.Quantity)
+		case !inRange:
+			balance.Opening = balance.Opening.Sub(row.Quantity)
+		case row.Type == models.OrderBuy:
+			balance.Bought = balance.Bought.Add(row.Quantity)
+		case row.Type == models.LedgerGiftReceived:
+			balance.Received = balance.Received.Add(row.Quantity)
+		case row.Type == models.OrderSell:
+			balance.Sold = balance.Sold.Add(row.Quantity)
+		case row.Type == models.OrderRedeem:
+			balance.Redeemed = balance.Redeemed.Add(row.Quantity)
+		case row.Type == models.LedgerGiftSent:
+			balance.Gifted = balance.Gifted.Add(row.Quantity)
+		}
+	}
+
+	for _, metal := range utils.Metals {
+		b := balances[string(metal)]
+		b.Closing = b.Opening.Add(b.Bought).Add(b.Received).
+			Sub(b.Sold).Sub(b.Redeemed).Sub(b.Gifted)
+		s.Balances = append(s.Balances, *b)
+	}
+	return s
+}
+
+func newRow(order *models.AugmontOrder) Row {
+	return Row{
+		Date:     deref(order.CreatedAt).In(utils.IST),
+		Type:     str(order.Type),
+		TxnID:    str(order.MerchantTxnID),
+		Status:   str(order.Status),
+		Metal:    str(order.MetalType),
+		Quantity: number(order.Quantity),
+		Rate:     number(order.Rate),
+		Amount:   number(order.Amount),
+	}
+}
+
+func deref(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+func str(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// number parses a stored number, zero if it is missing
+func number(s *string) decimal.Decimal {
+	if s == nil {
+		return decimal.Zero
+	}
+	d, err := decimal.NewFromString(*s)
+	if err != nil {
+		return decimal.Zero
+	}
+	return d
+}
@@ -0,0 +1,88 @@
+package statement
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/document"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+func order(day int, orderType, status, metal, quantity string) *models.AugmontOrder {
+	created := time.Date(2025, 4, day, 10, 0, 0, 0, utils.IST)
+	txnID := orderType + "-" + metal
+	return &models.AugmontOrder{
+		CreatedAt:     &created,
+		Type:          &orderType,
+		MerchantTxnID: &txnID,
+		AugmontOrderInfo: models.AugmontOrderInfo{
+			Status:    &status,
+			MetalType: &metal,
+			Quantity:  &quantity,
+		},
+	}
+}
+
+func testStatement() *Statement {
+	from := time.Date(2025, 4, 10, 0, 0, 0, 0, utils.IST)
+	to := time.Date(2025, 4, 30, 23, 59, 59, 0, utils.IST)
+	orders := []*models.AugmontOrder{
+		order(1, models.OrderBuy, models.OrderCompleted, "gold", "1.5"),
+		order(2, models.LedgerGiftReceived, models.OrderCompleted, "gold", "0.5"),
+		order(3, models.OrderRedeem, models.OrderCompleted, "gold", "0.25"),
+		order(5, models.OrderSell, models.OrderCompleted, "gold", "0.5"),
+		order(12, models.OrderBuy, models.OrderCompleted, "gold", "0.25"),
+		order(13, models.OrderBuy, models.OrderFailed, "gold", "3"),
+		order(15, models.OrderBuy, models.OrderCompleted, "silver", "10"),
+		order(20, models.OrderSell, models.OrderCompleted, "silver", "4"),
+		order(22, models.OrderRedeem, models.OrderCompleted, "silver", "2"),
+		order(25, models.LedgerGiftSent, models.OrderCompleted, "silver", "1"),
+	}
+	holder := document.Holder{Name: "Asha", Mobile: "9876543210", AccountID: "U1"}
+	return Build(holder, orders, from, to, to)
+}
+
+func TestBuild(t *testing.T) {
+	s := testStatement()
+
+	t.Run("should list the orders of the range", func(t *testing.T) {
+		assert.Len(t, s.Rows, 6)
+		assert.Equal(t, 12, s.Rows[0].Date.Day())
+	})
+
+	t.Run("should balance completed orders per metal", func(t *testing.T) {
+		gold, silver := s.Balances[0], s.Balances[1]
+		assert.Equal(t, "1.25", gold.Opening.String())
+		assert.Equal(t, "0.25", gold.Bought.String())
+		assert.Equal(t, "1.5", gold.Closing.String())
+		assert.Equal(t, "0", silver.Opening.String())
+		assert.Equal(t, "2", silver.Redeemed.String())
+		assert.Equal(t, "1", silver.Gifted.String())
+		assert.Equal(t, "3", silver.Closing.String())
+	})
+}
+
+func TestWrite(t *testing.T) {
+	s := testStatement()
+
+	t.Run("should write the sections as CSV", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		assert.NoError(t, WriteCSV(buf, s))
+		out := buf.String()
+		assert.Contains(t, out, "Period,10-04-2025 to 30-04-2025")
+		assert.Contains(t, out, "gold,1.2500,0.2500,0.0000,0.0000,0.0000,0.0000,1.5000")
+		assert.Contains(t, out, "silver,0.0000,10.0000,0.0000,4.0000,2.0000,1.0000,3.0000")
+		assert.Equal(t, 6, strings.Count(out, ",completed,")+strings.Count(out, ",failed,"))
+	})
+
+	t.Run("should write a PDF", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		assert.NoError(t, WritePDF(buf, s))
+		assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+	})
+}
@@ -0,0 +1,52 @@
+package tax
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+// Holding is the quantity of a metal held and the cost of its buys,
+// sells, redemptions and gifts sent use up the oldest lots first,
+// gifts received cost nothing
+type Holding struct {
+	Metal string
+	// Grams and rupees
+	Quantity decimal.Decimal
+	Cost     decimal.Decimal
+}
+
+// Holdings returns the holdings by metal from the completed orders
+func Holdings(orders []*models.AugmontOrder) map[string]Holding {
+	lots := map[string][]*lot{}
+	for _, o := range completed(orders) {
+		metal := str(o.MetalType)
+		quantity := number(o.Quantity)
+		if !quantity.IsPositive() {
+			continue
+		}
+		switch str(o.Type) {
+		case models.OrderBuy:
+			lots[metal] = append(lots[metal], &lot{
+				quantity: quantity,
+				price:    pricePerGram(o, quantity),
+			})
+		case models.LedgerGiftReceived:
+			lots[metal] = append(lots[metal], &lot{quantity: quantity, gift: true})
+		case models.OrderSell, models.OrderRedeem, models.LedgerGiftSent:
+			lots[metal], _ = consume(lots[metal], quantity, nil)
+		}
+	}
+
+	holdings := make(map[string]Holding, len(lots))
+	for metal, left := range lots {
+		h := Holding{Metal: metal}
+		for _, l := range left {
+			h.Quantity = h.Quantity.Add(l.quantity)
+			h.Cost = h.Cost.Add(l.price.Mul(l.quantity))
+		}
+		h.Cost = h.Cost.Round(2)
+		holdings[metal] = h
+	}
+	return holdings
+}
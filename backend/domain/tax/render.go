@@ -0,0 +1,122 @@
+package tax
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/document"
+)
+
+// disclaimer is printed under the summaries
+const disclaimer = "Gains are computed from the orders placed on Pinch, matching sells to the oldest " +
+	"buys. Short term gains are taxed at your slab rate. The long term tax is an estimate " +
+	"before surcharge, cess and set off of other losses, consult a tax advisor for filing."
+
+var gainColumns = []document.Column{
+	{Title: "Sold on", Width: 20, Align: "L"},
+	{Title: "Bought on", Width: 20, Align: "L"},
+	{Title: "Metal", Width: 14, Align: "L"},
+	{Title: "Qty (g)", Width: 18, Align: "R"},
+	{Title: "Days", Width: 12, Align: "R"},
+	{Title: "Term", Width: 14, Align: "L"},
+	{Title: "Cost (Rs)", Width: 23, Align: "R"},
+	{Title: "Indexed (Rs)", Width: 23, Align: "R"},
+	{Title: "Sale (Rs)", Width: 23, Align: "R"},
+	{Title: "Gain (Rs)", Width: 23, Align: "R"},
+}
+
+var summaryColumns = []document.Column{
+	{Title: "", Width: 130, Align: "L"},
+	{Title: "Amount (Rs)", Width: 60, Align: "R"},
+}
+
+// WriteCSV writes the gains of the report as CSV, followed by the totals
+func WriteCSV(w io.Writer, r *Report) error {
+	cw := csv.NewWriter(w)
+	records := [][]string{
+		{document.BrandName + " capital gains", r.FY},
+		{},
+		{"Sold on", "Sell transaction ID", "Bought on", "Buy transaction ID", "Metal",
+			"Quantity (g)", "Holding days", "Term", "Cost (INR)", "Indexed cost (INR)",
+			"Sale value (INR)", "Gain (INR)", "LTCG rate (%)"},
+	}
+	for _, g := range r.Gains {
+		records = append(records, []string{
+			g.SellDate.Format("02-01-2006"),
+			g.SellTxnID,
+			g.BuyDate.Format("02-01-2006"),
+			g.BuyTxnID,
+			g.Metal,
+			g.Quantity.StringFixed(4),
+			strconv.Itoa(g.HoldingDays),
+			g.Term,
+			g.Cost.StringFixed(2),
+			g.IndexedCost.StringFixed(2),
+			g.Proceeds.StringFixed(2),
+			g.Gain.StringFixed(2),
+			g.Rate.String(),
+		})
+	}
+	records = append(records, []string{})
+	records = append(records, summaryRows(r)...)
+	if err := cw.WriteAll(records); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// WritePDF writes the report as a branded PDF
+func WritePDF(w io.Writer, holder document.Holder, r *Report, now time.Time) error {
+	pdf := document.NewPDF()
+	pdf.Title("Capital gains report, FY " + r.FY)
+	pdf.Details([][2]string{
+		{"Name", holder.Name},
+		{"Mobile", holder.Mobile},
+		{"Account ID", holder.AccountID},
+		{"Generated at", now.Format("02-01-2006 15:04 MST")},
+	})
+
+	pdf.Section("Summary")
+	pdf.Table(summaryColumns, summaryRows(r), "")
+
+	rows := make([][]string, 0, len(r.Gains))
+	for _, g := range r.Gains {
+		rows = append(rows, []string{
+			g.SellDate.Format("02-01-2006"),
+			g.BuyDate.Format("02-01-2006"),
+			g.Metal,
+			g.Quantity.StringFixed(4),
+			strconv.Itoa(g.HoldingDays),
+			g.Term,
+			g.Cost.StringFixed(2),
+			g.IndexedCost.StringFixed(2),
+			g.Proceeds.StringFixed(2),
+			g.Gain.StringFixed(2),
+		})
+	}
+	pdf.Section("Gains")
+	pdf.Table(gainColumns, rows, "No sales in this financial year")
+	pdf.Note(disclaimer)
+
+	return pdf.Write(w)
+}
+
+func summaryRows(r *Report) [][]string {
+	rows := [][]string{
+		{"Short term capital gains", r.ShortTermGain.StringFixed(2)},
+		{"Long term capital gains", r.LongTermGain.StringFixed(2)},
+		{"Estimated tax on long term gains", r.LongTermTax.StringFixed(2)},
+	}
+	metals := make([]string, 0, len(r.Unmatched))
+	for metal := range r.Unmatched {
+		metals = append(metals, metal)
+	}
+	sort.Strings(metals)
+	for _, metal := range metals {
+		rows = append(rows, []string{"Unmatched " + metal + " sold (g), not included", r.Unmatched[metal].StringFixed(4)})
+	}
+	return rows
+}
@@ -0,0 +1,374 @@
+// Package tax computes the capital gains of the sells of digital gold,
+// sells are matched to the oldest buys first
+package tax
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+// Terms of the gains
+const (
+	ShortTerm = "short"
+	LongTerm  = "long"
+)
+
+// Rule is the capital gains rule of the sales from a date
+type Rule struct {
+	// Sales on or after this date follow the rule
+	From time.Time
+	// Holdings longer than this many months are long term
+	LongTermMonths int
+	// Long term costs are indexed with the cost inflation index
+	Indexation bool
+	// Tax rate of long term gains in percent, short
+	// term gains are taxed at the slab rate of the user
+	LongTermRate decimal.Decimal
+}
+
+// Rules are the rules of digital gold, oldest first
+var Rules = []Rule{
+	{
+		LongTermMonths: 36,
+		Indexation:     true,
+		LongTermRate:   decimal.NewFromInt(20),
+	},
+	// Finance (No. 2) Act, 2024
+	{
+		From:           time.Date(2024, 7, 23, 0, 0, 0, 0, utils.IST),
+		LongTermMonths: 24,
+		Indexation:     false,
+		LongTermRate:   decimal.RequireFromString("12.5"),
+	},
+}
+
+// CII is the cost inflation index notified for each financial year,
+// later years are added with TAX_CII until they are listed here
+var CII = map[string]int64{
+	"2001-02": 100, "2002-03": 105, "2003-04": 109, "2004-05": 113,
+	"2005-06": 117, "2006-07": 122, "2007-08": 129, "2008-09": 137,
+	"2009-10": 148, "2010-11": 167, "2011-12": 184, "2012-13": 200,
+	"2013-14": 220, "2014-15": 240, "2015-16": 254, "2016-17": 264,
+	"2017-18": 272, "2018-19": 280, "2019-20": 289, "2020-21": 301,
+	"2021-22": 317, "2022-23": 331, "2023-24": 348, "2024-25": 363,
+	"2025-26": 376,
+}
+
+// FY is an Indian financial year, April to March
+type FY struct {
+	// e.g. 2025-26
+	Name  string
+	Start time.Time
+	// Last instant of the year
+	End time.Time
+}
+
+// ParseFY parses a financial year like 2025-26
+func ParseFY(s string) (FY, error) {
+	if !utils.IsFY(s) {
+		return FY{}, errors.Newf("invalid financial year %q", s)
+	}
+	start, _ := strconv.Atoi(s[:4])
+	return newFY(start), nil
+}
+
+// FYOf returns the financial year of the time
+func FYOf(t time.Time) FY {
+	t = t.In(utils.IST)
+	if t.Month() < time.April {
+		return newFY(t.Year() - 1)
+	}
+	return newFY(t.Year())
+}
+
+func newFY(startYear int) FY {
+	start := time.Date(startYear, time.April, 1, 0, 0, 0, 0, utils.IST)
+	return FY{
+		Name:  strconv.Itoa(startYear) + "-" + strconv.Itoa(startYear + 1)[2:],
+		Start: start,
+		End:   start.AddDate(1, 0, 0).Add(-time.Nanosecond),
+	}
+}
+
+// Gain is the gain of a part of a sell matched to a buy,
+// amounts are in rupees and quantities in grams
+type Gain struct {
+	Metal       string          `json:"metal"`
+	SellTxnID   string          `json:"sellTxnID"`
+	SellDate    time.Time       `json:"sellDate"`
+	BuyTxnID    string          `json:"buyTxnID"`
+	BuyDate     time.Time       `json:"buyDate"`
+	Quantity    decimal.Decimal `json:"quantity"`
+	HoldingDays int             `json:"holdingDays"`
+	Term        string          `json:"term"`
+
+	Cost decimal.Decimal `json:"cost"`
+	// Cost used for the gain, indexed for long term gains under indexation
+	IndexedCost decimal.Decimal `json:"indexedCost"`
+	Proceeds    decimal.Decimal `json:"proceeds"`
+	Gain        decimal.Decimal `json:"gain"`
+	// Tax rate of long term gains in percent, zero for short term gains
+	Rate decimal.Decimal `json:"rate"`
+}
+
+// Report is the capital gains report of a financial year
+type Report struct {
+	FY    string `json:"fy"`
+	Gains []Gain `json:"gains"`
+
+	ShortTermGain decimal.Decimal `json:"shortTermGain"`
+	LongTermGain  decimal.Decimal `json:"longTermGain"`
+	// Estimated tax of the long term gains, short term
+	// gains are added to the income of the user
+	LongTermTax decimal.Decimal `json:"longTermTax"`
+
+	// Grams sold by metal that no buy covers, like buys without
+	// quantities or gifts received, their gains are not in the report
+	Unmatched map[string]decimal.Decimal `json:"unmatched,omitempty"`
+}
+
+// Engine computes the reports with the rules and the index
+type Engine struct {
+	rules []Rule
+	cii   map[string]int64
+}
+
+// NewEngine returns an engine of the Rules and the CII,
+// extra indices are added to or override the CII
+func NewEngine(extra map[string]int64) *Engine {
+	cii := make(map[string]int64, len(CII)+len(extra))
+	for fy, index := range CII {
+		cii[fy] = index
+	}
+	for fy, index := range extra {
+		cii[fy] = index
+	}
+	return &Engine{
+		rules: Rules,
+		cii:   cii,
+	}
+}
+
+// lot is the part of a buy or a gift received not sold yet
+type lot struct {
+	txnID    string
+	date     time.Time
+	quantity decimal.Decimal
+	// Cost per gram, unknown for gifts
+	price decimal.Decimal
+	gift  bool
+}
+
+// Report computes the gains of the sells of the year from the orders
+// until its end, only completed orders count, redemptions and gifts
+// sent use up the oldest lots without gains
+func (e *Engine) Report(orders []*models.AugmontOrder, fy FY) (*Report, error) {
+	sorted := completed(orders)
+	report := &Report{
+		FY:        fy.Name,
+		Gains:     []Gain{},
+		Unmatched: map[string]decimal.Decimal{},
+	}
+	lots := map[string][]*lot{}
+
+	for _, o := range sorted {
+		date := o.CreatedAt.In(utils.IST)
+		if date.After(fy.End) {
+			break
+		}
+		metal := str(o.MetalType)
+		quantity := number(o.Quantity)
+		if !quantity.IsPositive() {
+			continue
+		}
+
+		switch str(o.Type) {
+		case models.OrderBuy:
+			lots[metal] = append(lots[metal], &lot{
+				txnID:    str(o.MerchantTxnID),
+				date:     date,
+				quantity: quantity,
+				price:    pricePerGram(o, quantity),
+			})
+		case models.LedgerGiftReceived:
+			lots[metal] = append(lots[metal], &lot{
+				txnID:    str(o.MerchantTxnID),
+				date:     date,
+				quantity: quantity,
+				gift:     true,
+			})
+		case models.OrderRedeem, models.LedgerGiftSent:
+			lots[metal], _ = consume(lots[metal], quantity, nil)
+		case models.OrderSell:
+			inYear := !date.Before(fy.Start)
+			var matched []matchedLot
+			var remaining decimal.Decimal
+			lots[metal], remaining = consume(lots[metal], quantity, &matched)
+			if !inYear {
+				continue
+			}
+			for _, m := range matched {
+				// The cost of a gift is the giver's, which is not known
+				if m.lot.gift {
+					remaining = remaining.Add(m.quantity)
+					continue
+				}
+				gain, err := e.gain(o, date, metal, pricePerGram(o, quantity), m)
+				if err != nil {
+					return nil, err
+				}
+				report.Gains = append(report.Gains, gain)
+			}
+			if remaining.IsPositive() {
+				report.Unmatched[metal] = report.Unmatched[metal].Add(remaining)
+			}
+		}
+	}
+
+	taxable := decimal.Zero
+	for _, g := range report.Gains {
+		if g.Term == LongTerm {
+			report.LongTermGain = report.LongTermGain.Add(g.Gain)
+			taxable = taxable.Add(g.Gain.Mul(g.Rate).Div(decimal.NewFromInt(100)))
+		} else {
+			report.ShortTermGain = report.ShortTermGain.Add(g.Gain)
+		}
+	}
+	// Long term losses only offset long term gains
+	if taxable.IsPositive() {
+		report.LongTermTax = taxable.Round(2)
+	}
+	if len(report.Unmatched) == 0 {
+		report.Unmatched = nil
+	}
+	return report, nil
+}
+
+// completed returns the completed orders, oldest first
+func completed(orders []*models.AugmontOrder) []*models.AugmontOrder {
+	sorted := make([]*models.AugmontOrder, 0, len(orders))
+	for _, o := range orders {
+		if o.Status != nil && *o.Status == models.OrderCompleted && o.CreatedAt != nil {
+			sorted = append(sorted, o)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(*sorted[j].CreatedAt)
+	})
+	return sorted
+}
+
+type matchedLot struct {
+	lot      lot
+	quantity decimal.Decimal
+}
+
+// consume takes the quantity from the oldest lots, the matched parts are
+// added to matched if given, returns the lots left and the quantity no
+// lot covered
+func consume(lots []*lot, quantity decimal.Decimal, matched *[]matchedLot) ([]*lot, decimal.Decimal) {
+	for len(lots) > 0 && quantity.IsPositive() {
+		l := lots[0]
+		take := decimal.Min(l.quantity, quantity)
+		if matched != nil {
+			*matched = append(*matched, matchedLot{lot: *l, quantity: take})
+		}
+		l.quantity = l.quantity.Sub(take)
+		quantity = quantity.Sub(take)
+		if !l.quantity.IsPositive() {
+			lots = lots[1:]
+		}
+	}
+	return lots, quantity
+}
+
+// gain computes the gain of the part of a sell matched to a lot
+func (e *Engine) gain(sell *models.AugmontOrder, date time.Time, metal string, price decimal.Decimal, m matchedLot) (Gain, error) {
+	rule := e.rule(date)
+	term := ShortTerm
+	if date.After(m.lot.date.AddDate(0, rule.LongTermMonths, 0)) {
+		term = LongTerm
+	}
+
+	cost := m.lot.price.Mul(m.quantity)
+	indexed := cost
+	if term == LongTerm && rule.Indexation {
+		buyFY, sellFY := FYOf(m.lot.date).Name, FYOf(date).Name
+		buyIndex, ok := e.cii[buyFY]
+		if !ok {
+			return Gain{}, errors.Newf("no cost inflation index for %v", buyFY)
+		}
+		sellIndex, ok := e.cii[sellFY]
+		if !ok {
+			return Gain{}, errors.Newf("no cost inflation index for %v", sellFY)
+		}
+		indexed = cost.Mul(decimal.NewFromInt(sellIndex)).Div(decimal.NewFromInt(buyIndex))
+	}
+
+	proceeds := price.Mul(m.quantity).Round(2)
+	indexed = indexed.Round(2)
+	rate := decimal.Zero
+	if term == LongTerm {
+		rate = rule.LongTermRate
+	}
+	return Gain{
+		Metal:       metal,
+		SellTxnID:   str(sell.MerchantTxnID),
+		SellDate:    date,
+		BuyTxnID:    m.lot.txnID,
+		BuyDate:     m.lot.date,
+		Quantity:    m.quantity,
+		HoldingDays: int(date.Sub(m.lot.date).Hours() / 24),
+		Term:        term,
+		Cost:        cost.Round(2),
+		IndexedCost: indexed,
+		Proceeds:    proceeds,
+		Gain:        proceeds.Sub(indexed),
+		Rate:        rate,
+	}, nil
+}
+
+// rule returns the rule of a sale on the date
+func (e *Engine) rule(date time.Time) Rule {
+	rule := e.rules[0]
+	for _, r := range e.rules {
+		if !date.Before(r.From) {
+			rule = r
+		}
+	}
+	return rule
+}
+
+// pricePerGram is the amount of the order per gram,
+// the rate if the amount is unknown
+func pricePerGram(o *models.AugmontOrder, quantity decimal.Decimal) decimal.Decimal {
+	if amount := number(o.Amount); amount.IsPositive() {
+		return amount.Div(quantity)
+	}
+	return number(o.Rate)
+}
+
+func str(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func number(s *string) decimal.Decimal {
+	if s == nil {
+		return decimal.Zero
+	}
+	d, err := decimal.NewFromString(*s)
+	if err != nil {
+		return decimal.Zero
+	}
+	return d
+}
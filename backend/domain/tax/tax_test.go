@@ -0,0 +1,197 @@
+package tax
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+func order(date, orderType, quantity, amount string) *models.AugmontOrder {
+	created, err := utils.ParseDate(date)
+	if err != nil {
+		panic(err)
+	}
+	created = created.Add(10 * time.Hour)
+	txnID := orderType + "-" + date
+	status, metal := models.OrderCompleted, "gold"
+	return &models.AugmontOrder{
+		CreatedAt:     &created,
+		Type:          &orderType,
+		MerchantTxnID: &txnID,
+		AugmontOrderInfo: models.AugmontOrderInfo{
+			Status:    &status,
+			MetalType: &metal,
+			Quantity:  &quantity,
+			Amount:    &amount,
+		},
+	}
+}
+
+func report(t *testing.T, engine *Engine, fy string, orders ...*models.AugmontOrder) *Report {
+	year, err := ParseFY(fy)
+	assert.NoError(t, err)
+	r, err := engine.Report(orders, year)
+	assert.NoError(t, err)
+	return r
+}
+
+func TestReport(t *testing.T) {
+	engine := NewEngine(nil)
+
+	t.Run("should match sells to the oldest buys", func(t *testing.T) {
+		r := report(t, engine, "2025-26",
+			order("01-06-2025", models.OrderBuy, "1", "6000"),
+			order("01-05-2025", models.OrderBuy, "1", "5000"),
+			order("01-09-2025", models.OrderSell, "1.5", "10500"),
+		)
+		assert.Len(t, r.Gains, 2)
+		assert.Equal(t, "buy-01-05-2025", r.Gains[0].BuyTxnID)
+		assert.Equal(t, "2000", r.Gains[0].Gain.String())
+		assert.Equal(t, "0.5", r.Gains[1].Quantity.String())
+		assert.Equal(t, "500", r.Gains[1].Gain.String())
+		assert.Equal(t, ShortTerm, r.Gains[1].Term)
+		assert.Equal(t, "2500", r.ShortTermGain.String())
+		assert.True(t, r.LongTermTax.IsZero())
+	})
+
+	t.Run("should not index long term gains after july 2024", func(t *testing.T) {
+		r := report(t, engine, "2024-25",
+			order("01-06-2022", models.OrderBuy, "1", "5000"),
+			order("01-09-2024", models.OrderSell, "1", "7000"),
+		)
+		assert.Len(t, r.Gains, 1)
+		assert.Equal(t, LongTerm, r.Gains[0].Term)
+		assert.Equal(t, "5000", r.Gains[0].IndexedCost.String())
+		assert.Equal(t, "2000", r.LongTermGain.String())
+		assert.Equal(t, "250", r.LongTermTax.String())
+	})
+
+	t.Run("should index long term gains before july 2024", func(t *testing.T) {
+		r := report(t, engine, "2023-24",
+			order("01-06-2019", models.OrderBuy, "1", "4000"),
+			order("01-06-2023", models.OrderSell, "1", "6000"),
+		)
+		assert.Len(t, r.Gains, 1)
+		assert.Equal(t, LongTerm, r.Gains[0].Term)
+		// 4000 * 348 / 289
+		assert.Equal(t, "4816.61", r.Gains[0].IndexedCost.String())
+		assert.Equal(t, "1183.39", r.LongTermGain.String())
+		assert.Equal(t, "236.68", r.LongTermTax.String())
+	})
+
+	t.Run("should keep short term holdings of the old rule short", func(t *testing.T) {
+		r := report(t, engine, "2023-24",
+			order("01-06-2021", models.OrderBuy, "1", "4000"),
+			order("01-06-2023", models.OrderSell, "1", "6000"),
+		)
+		assert.Equal(t, ShortTerm, r.Gains[0].Term)
+		assert.Equal(t, "2000", r.ShortTermGain.String())
+	})
+
+	t.Run("should use up buys with redemptions", func(t *testing.T) {
+		r := report(t, engine, "2025-26",
+			order("01-05-2025", models.OrderBuy, "1", "5000"),
+			order("10-05-2025", models.OrderRedeem, "0.5", ""),
+			order("01-09-2025", models.OrderSell, "1", "7000"),
+		)
+		assert.Len(t, r.Gains, 1)
+		assert.Equal(t, "0.5", r.Gains[0].Quantity.String())
+		assert.Equal(t, "1000", r.Gains[0].Gain.String())
+		assert.Equal(t, "0.5", r.Unmatched["gold"].String())
+	})
+
+	t.Run("should match sells after a redemption to the buys left", func(t *testing.T) {
+		r := report(t, engine, "2025-26",
+			order("01-05-2025", models.OrderBuy, "1", "5000"),
+			order("01-06-2025", models.OrderBuy, "1", "6000"),
+			order("01-07-2025", models.OrderRedeem, "1", ""),
+			order("01-09-2025", models.OrderSell, "1", "7000"),
+		)
+		assert.Len(t, r.Gains, 1)
+		assert.Equal(t, "buy-01-06-2025", r.Gains[0].BuyTxnID)
+		assert.Equal(t, 92, r.Gains[0].HoldingDays)
+		assert.Equal(t, "1000", r.Gains[0].Gain.String())
+		assert.Nil(t, r.Unmatched)
+	})
+
+	t.Run("should move lots with gifts", func(t *testing.T) {
+		r := report(t, engine, "2025-26",
+			order("01-05-2025", models.OrderBuy, "1", "5000"),
+			order("01-06-2025", models.LedgerGiftSent, "0.5", ""),
+			order("01-07-2025", models.LedgerGiftReceived, "1", ""),
+			order("01-09-2025", models.OrderSell, "1.5", "10500"),
+		)
+		assert.Len(t, r.Gains, 1)
+		assert.Equal(t, "0.5", r.Gains[0].Quantity.String())
+		assert.Equal(t, "1000", r.Gains[0].Gain.String())
+		// The gift received has no known cost
+		assert.Equal(t, "1", r.Unmatched["gold"].String())
+	})
+
+	t.Run("should only report the sells of the year", func(t *testing.T) {
+		r := report(t, engine, "2025-26",
+			order("01-05-2024", models.OrderBuy, "2", "10000"),
+			order("01-09-2024", models.OrderSell, "1", "6000"),
+			order("01-09-2025", models.OrderSell, "1", "7000"),
+			order("01-05-2026", models.OrderSell, "1", "8000"),
+		)
+		assert.Len(t, r.Gains, 1)
+		assert.Equal(t, "sell-01-09-2025", r.Gains[0].SellTxnID)
+		assert.Nil(t, r.Unmatched)
+	})
+
+	t.Run("should use the extra indices", func(t *testing.T) {
+		r := report(t, NewEngine(map[string]int64{"2023-24": 578}), "2023-24",
+			order("01-06-2019", models.OrderBuy, "1", "4000"),
+			order("01-06-2023", models.OrderSell, "1", "6000"),
+		)
+		// 4000 * 578 / 289
+		assert.Equal(t, "8000", r.Gains[0].IndexedCost.String())
+		assert.True(t, r.LongTermTax.IsZero())
+	})
+}
+
+func TestFY(t *testing.T) {
+	t.Run("should parse financial years", func(t *testing.T) {
+		fy, err := ParseFY("2025-26")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2025, 4, 1, 0, 0, 0, 0, utils.IST), fy.Start)
+		assert.Equal(t, 2026, fy.End.Year())
+		assert.Equal(t, time.March, fy.End.Month())
+
+		_, err = ParseFY("2025-27")
+		assert.Error(t, err)
+	})
+
+	t.Run("should find the year of a date", func(t *testing.T) {
+		assert.Equal(t, "2024-25", FYOf(time.Date(2025, 3, 31, 23, 0, 0, 0, utils.IST)).Name)
+		assert.Equal(t, "2025-26", FYOf(time.Date(2025, 4, 1, 0, 0, 0, 0, utils.IST)).Name)
+		assert.Equal(t, "1999-00", FYOf(time.Date(1999, 4, 1, 0, 0, 0, 0, utils.IST)).Name)
+	})
+}
+
+func TestHoldings(t *testing.T) {
+	holdings := Holdings([]*models.AugmontOrder{
+		order("01-05-2025", models.OrderBuy, "1", "5000"),
+		order("01-06-2025", models.OrderBuy, "1", "6000"),
+		order("01-07-2025", models.OrderSell, "0.5", "3500"),
+		order("01-08-2025", models.OrderRedeem, "1", ""),
+	})
+	gold := holdings["gold"]
+	assert.Equal(t, "0.5", gold.Quantity.String())
+	// What is left of the second buy
+	assert.Equal(t, "3000", gold.Cost.String())
+
+	holdings = Holdings([]*models.AugmontOrder{
+		order("01-05-2025", models.OrderBuy, "1", "5000"),
+		order("01-06-2025", models.LedgerGiftSent, "0.5", ""),
+		order("01-07-2025", models.LedgerGiftReceived, "2", ""),
+	})
+	gold = holdings["gold"]
+	assert.Equal(t, "2.5", gold.Quantity.String())
+	assert.Equal(t, "2500", gold.Cost.String())
+}
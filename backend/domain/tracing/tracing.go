@@ -0,0 +1,88 @@
+// Package tracing configures the opentelemetry tracer provider
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+)
+
+// Tracer returns a named tracer from the global provider
+func Tracer(name string) trace.Tracer {
+	return otel.Tracer(name)
+}
+
+// Setup sets the global tracer provider and propagator,
+// the exporter is chosen by TRACING_EXPORTER, none keeps
+// the noop provider but still propagates incoming trace headers
+func Setup(lifecycle *domain.Lifecycle) error {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	cfg := domain.Config().Tracing
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case "none", "":
+		return nil
+	case "stdout":
+		exporter, err = stdouttrace.New(
+			stdouttrace.WithWriter(os.Stdout),
+		)
+	case "otlp":
+		opts := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(cfg.OtlpEndpoint),
+		}
+		if cfg.OtlpInsecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(context.Background(), opts...)
+	default:
+		return fmt.Errorf("unknown tracing exporter %q", cfg.Exporter)
+	}
+	if err != nil {
+		return err
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(cfg.ServiceName),
+			semconv.DeploymentEnvironmentKey.String(domain.Config().Server.Env),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(
+			sdktrace.TraceIDRatioBased(cfg.SampleRatio),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+
+	// Flush the buffered spans on shutdown
+	lifecycle.Append(domain.Hook{
+		Name:   "tracer provider",
+		OnStop: provider.Shutdown,
+	})
+	return nil
+}
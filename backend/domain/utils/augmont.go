@@ -10,18 +10,18 @@ import (
 
 // Model for get, validate api data, and presenting
 type AugmontUserInfo struct {
-	MobileNo string `json:"mobileNumber,omitempty" mapstructure:"mobileNumber"`
-	EmailID  string `json:"emailId,omitempty" mapstructure:"emailId"`
+	MobileNo string `json:"mobileNumber,omitempty" mapstructure:"mobileNumber" binding:"omitempty,mobile"`
+	EmailID  string `json:"emailId,omitempty" mapstructure:"emailId" binding:"omitempty,email"`
 	UniqueID string `json:"uniqueId,omitempty" mapstructure:"uniqueId"`
-	Name     string `json:"userName,omitempty" mapstructure:"userName"`
-	City     string `json:"userCity,omitempty" mapstructure:"userCity"`
-	State    string `json:"userState,omitempty" mapstructure:"userState"`
-	Pincode  string `json:"userPincode,omitempty" mapstructure:"userPincode"`
-	DOB      string `json:"dateOfBirth,omitempty" mapstructure:"dateOfBirth"`
+	Name     string `json:"userName,omitempty" mapstructure:"userName" binding:"omitempty,max=50"`
+	City     string `json:"userCity,omitempty" mapstructure:"userCity" binding:"omitempty,max=50"`
+	State    string `json:"userState,omitempty" mapstructure:"userState" binding:"omitempty,max=50"`
+	Pincode  string `json:"userPincode,omitempty" mapstructure:"userPincode" binding:"omitempty,pincode"`
+	DOB      string `json:"dateOfBirth,omitempty" mapstructure:"dateOfBirth" binding:"omitempty,date"`
 
-	NomineeName     string `json:"nomineeName,omitempty" mapstructure:"nomineeName"`
-	NomineeDOB      string `json:"nomineeDateOfBirth,omitempty" mapstructure:"nomineeDateOfBirth"`
-	NomineeRelation string `json:"nomineeRelation,omitempty" mapstructure:"nomineeRelation"`
+	NomineeName     string `json:"nomineeName,omitempty" mapstructure:"nomineeName" binding:"omitempty,max=50"`
+	NomineeDOB      string `json:"nomineeDateOfBirth,omitempty" mapstructure:"nomineeDateOfBirth" binding:"omitempty,date"`
+	NomineeRelation string `json:"nomineeRelation,omitempty" mapstructure:"nomineeRelation" binding:"omitempty,max=30"`
 
 	UtmSource   string `json:"utmSource,omitempty" mapstructure:"utmSource"`
 	UtmMedium   string `json:"utmMedium,omitempty" mapstructure:"utmMedium"`
@@ -30,27 +30,27 @@ type AugmontUserInfo struct {
 
 type AugmontUserBankInfo struct {
 	UserBankID string `json:"userBankId,omitempty" mapstructure:"userBankId"`
-	AccNo      string `json:"accountNumber,omitempty" mapstructure:"accountNumber"`
-	AccName    string `json:"accountName,omitempty" mapstructure:"accountName"`
-	Ifsc       string `json:"ifscCode,omitempty" mapstructure:"ifscCode"`
+	AccNo      string `json:"accountNumber,omitempty" mapstructure:"accountNumber" binding:"required,numeric,min=9,max=18"`
+	AccName    string `json:"accountName,omitempty" mapstructure:"accountName" binding:"required,max=100"`
+	Ifsc       string `json:"ifscCode,omitempty" mapstructure:"ifscCode" binding:"required,ifsc"`
 }
 
 type AugmontUserAddressInfo struct {
 	UserAddressID string `json:"userAddressId,omitempty" mapstructure:"userAddressId"`
-	Name          string `json:"name,omitempty" mapstructure:"name"`
-	MobileNo      string `json:"mobileNumber,omitempty" mapstructure:"mobileNumber"`
-	Email         string `json:"email,omitempty" mapstructure:"email"`
-	Address       string `json:"address,omitempty" mapstructure:"address"`
-	Pincode       string `json:"pincode,omitempty" mapstructure:"pincode"`
+	Name          string `json:"name,omitempty" mapstructure:"name" binding:"required,max=50"`
+	MobileNo      string `json:"mobileNumber,omitempty" mapstructure:"mobileNumber" binding:"required,mobile"`
+	Email         string `json:"email,omitempty" mapstructure:"email" binding:"omitempty,email"`
+	Address       string `json:"address,omitempty" mapstructure:"address" binding:"required,max=255"`
+	Pincode       string `json:"pincode,omitempty" mapstructure:"pincode" binding:"required,pincode"`
 }
 
 type AugmontBugInfo struct {
-	LockPrice     string `json:"lockPrice" `
-	MetalType     string `json:"metalType" `
-	Quantity      string `json:"quantity" `
-	Amount        string `json:"amount" `
+	LockPrice     string `json:"lockPrice" binding:"required,amount"`
+	MetalType     string `json:"metalType" binding:"required,metal"`
+	Quantity      string `json:"quantity" binding:"required_without=Amount,omitempty,grams"`
+	Amount        string `json:"amount" binding:"required_without=Quantity,omitempty,amount"`
 	MerchantTnxID string `json:"merchantTransactionId" `
-	BlockID       string `json:"blockId"`
+	BlockID       string `json:"blockId" binding:"required"`
 
 	PaymentMode string `json:"modeOfPayment"`
 	UserUID     string `json:"uniqueId"`
@@ -63,23 +63,23 @@ type AugmontBugInfo struct {
 }
 
 type AugmontSellInfo struct {
-	LockPrice     string `json:"lockPrice" `
-	MetalType     string `json:"metalType" `
-	Quantity      string `json:"quantity" `
-	Amount        string `json:"amount" `
+	LockPrice     string `json:"lockPrice" binding:"required,amount"`
+	MetalType     string `json:"metalType" binding:"required,metal"`
+	Quantity      string `json:"quantity" binding:"required_without=Amount,omitempty,grams"`
+	Amount        string `json:"amount" binding:"required_without=Quantity,omitempty,amount"`
 	MerchantTnxID string `json:"merchantTransactionId" `
-	BlockID       string `json:"blockId"`
+	BlockID       string `json:"blockId" binding:"required"`
 
 	UserBankID string `json:"userBankId"`
-	AccNo      string `json:"accountNumber"`
-	AccName    string `json:"accountName"`
-	Ifsc       string `json:"ifscCode"`
+	AccNo      string `json:"accountNumber" binding:"omitempty,numeric,min=9,max=18"`
+	AccName    string `json:"accountName" binding:"omitempty,max=100"`
+	Ifsc       string `json:"ifscCode" binding:"omitempty,ifsc"`
 }
 
 type AugmontRedeemInfo struct {
-	MobileNo      string               `json:"mobileNumber"`
-	UserAddressID string               `json:"userAddressId"`
-	Product       []AugmontProductInfo `json:"product" binding:"required"`
+	MobileNo      string               `json:"mobileNumber" binding:"omitempty,mobile"`
+	UserAddressID string               `json:"userAddressId" binding:"required"`
+	Product       []AugmontProductInfo `json:"product" binding:"required,min=1,dive"`
 
 	PaymentMode   string `json:"modeOfPayment"`
 	MerchantTnxID string `json:"merchantTransactionId" `
@@ -92,9 +92,43 @@ func (r *AugmontRedeemInfo) Write(writer *multipart.Writer) (err error) {
 	return
 }
 
+// AugmontCheckoutInfo is where the delivery cart is redeemed to
+type AugmontCheckoutInfo struct {
+	UserAddressID string `json:"userAddressId" binding:"required"`
+	MobileNo      string `json:"mobileNumber" binding:"omitempty,mobile"`
+}
+
+// AugmontShipmentCallback is a shipment update of a redeem order sent by Augmont
+type AugmontShipmentCallback struct {
+	MerchantTxnID  string `json:"merchantTransactionId" binding:"required"`
+	Status         string `json:"status" binding:"required"`
+	Courier        string `json:"logisticName"`
+	TrackingNumber string `json:"awbNo"`
+	Location       string `json:"location"`
+	// When it happened, RFC 3339
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// AugmontGiftInfo is metal gifted to the user of a mobile number
+type AugmontGiftInfo struct {
+	MobileNo  string `json:"mobileNumber" binding:"required,mobile"`
+	MetalType string `json:"metalType" binding:"required,metal"`
+	Quantity  string `json:"quantity" binding:"required,grams"`
+	Message   string `json:"message" binding:"omitempty,max=200"`
+}
+
+// AugmontTransferInfo moves metal between two Augmont accounts
+type AugmontTransferInfo struct {
+	SenderUID     string `json:"uniqueId"`
+	ReceiverUID   string `json:"receiverUniqueId"`
+	MetalType     string `json:"metalType"`
+	Quantity      string `json:"quantity"`
+	MerchantTnxID string `json:"merchantTransactionId"`
+}
+
 type AugmontProductInfo struct {
-	SKU      string `json:"sku" `
-	Quantity string `json:"quantity" `
+	SKU      string `json:"sku" binding:"required"`
+	Quantity string `json:"quantity" binding:"required,number,ne=0"`
 }
 
 func (p *AugmontProductInfo) Write(writer *multipart.Writer, index int) (err error) {
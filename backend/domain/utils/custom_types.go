@@ -15,11 +15,14 @@ func (d Dict) Get(keys ...string) interface{} {
 	if len(keys) == 1 {
 		return d[keys[0]]
 	}
-	t, ok := d[keys[0]].(Dict)
-	if !ok {
-		return nil
+	// Decoded json objects are plain maps
+	switch t := d[keys[0]].(type) {
+	case Dict:
+		return t.Get(keys[1:]...)
+	case map[string]interface{}:
+		return Dict(t).Get(keys[1:]...)
 	}
-	return t.Get(keys[1:]...)
+	return nil
 }
 
 func (d *Dict) ToString() string {
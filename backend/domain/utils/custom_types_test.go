@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -31,6 +32,13 @@ func TestDictGet(t *testing.T) {
 		assert.Equal(t, nil, got)
 
 	})
+
+	t.Run("test Get of decoded json", func(t *testing.T) {
+		d := Dict{}
+		json.Unmarshal([]byte(`{"result":{"data":{"invoiceNumber":"INV1"}}}`), &d)
+		got := d.Get("result", "data", "invoiceNumber")
+		assert.Equal(t, "INV1", got)
+	})
 }
 
 func TestDictToString(t *testing.T) {
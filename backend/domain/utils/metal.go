@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Metal is a metal sold on pinch, named as Augmont names it
+type Metal string
+
+// Metals sold on pinch
+const (
+	Gold   Metal = "gold"
+	Silver Metal = "silver"
+)
+
+// Metals are the metals sold on pinch, in display order
+var Metals = []Metal{Gold, Silver}
+
+// ParseMetal returns the metal of a name in any case
+func ParseMetal(s string) (Metal, bool) {
+	metal := Metal(strings.ToLower(s))
+	for _, m := range Metals {
+		if m == metal {
+			return m, true
+		}
+	}
+	return "", false
+}
+
+// IsMetal checks for the name of a metal sold on pinch, in lower case
+func IsMetal(s string) bool {
+	metal, ok := ParseMetal(s)
+	return ok && string(metal) == s
+}
+
+// MetalNames returns the names of the metals
+func MetalNames() []string {
+	names := make([]string, len(Metals))
+	for i, m := range Metals {
+		names[i] = string(m)
+	}
+	return names
+}
+
+// MetalRate is the live rate of a metal in rupees per gram
+type MetalRate struct {
+	Metal Metal `json:"metal"`
+	// Buy rate before GST and the GST on it
+	Buy    decimal.Decimal `json:"buy"`
+	BuyGST decimal.Decimal `json:"buyGst"`
+	Sell   decimal.Decimal `json:"sell"`
+}
+
+// MetalRates are the rates of the metals, orders lock
+// a rate with its block id while the block is valid
+type MetalRates struct {
+	BlockID string       `json:"blockId"`
+	Rates   []*MetalRate `json:"rates"`
+}
+
+// Rate returns the rate of the metal, nil if it is not quoted
+func (r *MetalRates) Rate(metal Metal) *MetalRate {
+	for _, rate := range r.Rates {
+		if rate.Metal == metal {
+			return rate
+		}
+	}
+	return nil
+}
+
+// MetalHolding is the quantity of a metal held, what was paid for it
+// and its value at the sell rate, the rate and the value are nil
+// while the rates are unavailable
+type MetalHolding struct {
+	Metal Metal `json:"metal"`
+	// Grams and rupees
+	Quantity decimal.Decimal  `json:"quantity"`
+	Invested decimal.Decimal  `json:"invested"`
+	SellRate *decimal.Decimal `json:"sellRate"`
+	Value    *decimal.Decimal `json:"value"`
+}
@@ -0,0 +1,16 @@
+package utils
+
+// NotificationPreferenceInfo changes the notification preference of a
+// user, fields left out keep their value and empty strings clear them
+type NotificationPreferenceInfo struct {
+	SMS   *bool `json:"sms"`
+	Email *bool `json:"email"`
+	Push  *bool `json:"push"`
+
+	EmailAddress *string `json:"emailAddress" binding:"omitempty,email,max=100"`
+	PushToken    *string `json:"pushToken" binding:"omitempty,max=255"`
+
+	// Both or neither, HH:MM in IST
+	QuietStart *string `json:"quietStart" binding:"omitempty,clock"`
+	QuietEnd   *string `json:"quietEnd" binding:"omitempty,clock"`
+}
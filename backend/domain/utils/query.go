@@ -0,0 +1,142 @@
+package utils
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Page size limits of list endpoints
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// Filter operators
+const (
+	FilterEq     = "eq"     // FilterEq matches equal values
+	FilterPrefix = "prefix" // FilterPrefix matches strings starting with the value
+	FilterGte    = "gte"    // FilterGte matches values greater or equal
+	FilterLte    = "lte"    // FilterLte matches values less or equal
+	FilterNull   = "null"   // FilterNull matches missing values
+)
+
+// ListQuery is the pagination, sorting and filtering of list endpoints,
+// bound from the query params, resource specific filters are added
+// by the controllers
+type ListQuery struct {
+	Limit  int `form:"limit" json:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int `form:"offset" json:"offset" binding:"omitempty,min=0"`
+	// Cursor of the next page, returned in Page, replaces offset. It is
+	// the offset of the next page encoded, rows created or deleted
+	// between two pages shift the rows of the next one
+	Cursor string `form:"cursor" json:"cursor"`
+	// Comma separated fields, prefixed with - for descending, e.g. -createdAt,id
+	Sort string `form:"sort" json:"sort"`
+
+	// RFC 3339 range of the creation time, both inclusive
+	CreatedFrom *time.Time `form:"createdFrom" json:"createdFrom"`
+	CreatedTo   *time.Time `form:"createdTo" json:"createdTo"`
+
+	Filters []Filter `form:"-" json:"-"`
+}
+
+// Filter is a condition on a field, fields are named as in the API
+type Filter struct {
+	Field string
+	Op    string
+	Value interface{}
+}
+
+// Page is the pagination metadata of list responses
+type Page struct {
+	// Number of rows matching the filters
+	Total  int64 `json:"total"`
+	Limit  int   `json:"limit"`
+	Offset int   `json:"offset"`
+	// Cursor of the next page, empty on the last page, an encoded offset
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// Normalize applies the defaults and decodes the cursor into the offset
+func (q *ListQuery) Normalize() error {
+	if q.Limit <= 0 {
+		q.Limit = DefaultPageLimit
+	}
+	if q.Limit > MaxPageLimit {
+		q.Limit = MaxPageLimit
+	}
+	if q.Cursor != "" {
+		offset, err := decodeCursor(q.Cursor)
+		if err != nil {
+			return err
+		}
+		q.Offset = offset
+	}
+	if q.CreatedFrom != nil {
+		q.Where("createdAt", FilterGte, *q.CreatedFrom)
+	}
+	if q.CreatedTo != nil {
+		q.Where("createdAt", FilterLte, *q.CreatedTo)
+	}
+	return nil
+}
+
+// Where adds a filter
+func (q *ListQuery) Where(field, op string, value interface{}) *ListQuery {
+	q.Filters = append(q.Filters, Filter{
+		Field: field,
+		Op:    op,
+		Value: value,
+	})
+	return q
+}
+
+// SortFields returns the sort fields and if they are descending
+func (q *ListQuery) SortFields() ([]string, []bool) {
+	var (
+		fields []string
+		desc   []bool
+	)
+	for _, field := range strings.Split(q.Sort, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		fields = append(fields, strings.TrimPrefix(field, "-"))
+		desc = append(desc, strings.HasPrefix(field, "-"))
+	}
+	return fields, desc
+}
+
+// NewPage returns the metadata of the page of rows starting at the offset
+func (q *ListQuery) NewPage(total int64, rows int) *Page {
+	page := &Page{
+		Total:  total,
+		Limit:  q.Limit,
+		Offset: q.Offset,
+	}
+	if next := q.Offset + rows; rows == q.Limit && int64(next) < total {
+		page.NextCursor = encodeCursor(next)
+	}
+	return page
+}
+
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("o:" + strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(b), "o:") {
+		return 0, errors.Newf("invalid cursor %q", cursor)
+	}
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(b), "o:"))
+	if err != nil || offset < 0 {
+		return 0, errors.Newf("invalid cursor %q", cursor)
+	}
+	return offset, nil
+}
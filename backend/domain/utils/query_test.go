@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListQuery(t *testing.T) {
+	t.Run("should apply the default limit", func(t *testing.T) {
+		q := &ListQuery{}
+		assert.NoError(t, q.Normalize())
+		assert.Equal(t, DefaultPageLimit, q.Limit)
+
+		q = &ListQuery{Limit: 1000}
+		assert.NoError(t, q.Normalize())
+		assert.Equal(t, MaxPageLimit, q.Limit)
+	})
+
+	t.Run("should return the next cursor until the last page", func(t *testing.T) {
+		q := &ListQuery{Limit: 10}
+		assert.NoError(t, q.Normalize())
+		page := q.NewPage(25, 10)
+		assert.NotEmpty(t, page.NextCursor)
+
+		next := &ListQuery{Limit: 10, Cursor: page.NextCursor}
+		assert.NoError(t, next.Normalize())
+		assert.Equal(t, 10, next.Offset)
+
+		last := &ListQuery{Limit: 10, Offset: 20}
+		assert.NoError(t, last.Normalize())
+		assert.Empty(t, last.NewPage(25, 5).NextCursor)
+	})
+
+	t.Run("should reject invalid cursors", func(t *testing.T) {
+		q := &ListQuery{Cursor: "not-a-cursor"}
+		assert.Error(t, q.Normalize())
+	})
+
+	t.Run("should parse sort fields", func(t *testing.T) {
+		q := &ListQuery{Sort: "-createdAt, id"}
+		fields, desc := q.SortFields()
+		assert.Equal(t, []string{"createdAt", "id"}, fields)
+		assert.Equal(t, []bool{true, false}, desc)
+	})
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// FilesPath is the path the files of signed URLs are downloaded from
+const FilesPath = "/files/"
+
+// Errors of VerifyPath
+var (
+	ErrSignatureInvalid = errors.New("invalid signature")
+	ErrSignatureExpired = errors.New("signature expired")
+)
+
+// SignPath returns the query of a URL path valid until expiresAt,
+// signed with HMAC-SHA256
+func SignPath(key []byte, path string, expiresAt time.Time) url.Values {
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	return url.Values{
+		"expires":   {expires},
+		"signature": {pathSignature(key, path, expires)},
+	}
+}
+
+// VerifyPath checks the query of a path signed by SignPath
+func VerifyPath(key []byte, path string, query url.Values, now time.Time) error {
+	expires := query.Get("expires")
+	signature, err := hex.DecodeString(query.Get("signature"))
+	if err != nil || expires == "" {
+		return ErrSignatureInvalid
+	}
+	expected, _ := hex.DecodeString(pathSignature(key, path, expires))
+	if !hmac.Equal(signature, expected) {
+		return ErrSignatureInvalid
+	}
+
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrSignatureInvalid
+	}
+	if now.After(time.Unix(unix, 0)) {
+		return ErrSignatureExpired
+	}
+	return nil
+}
+
+func pathSignature(key []byte, path, expires string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(path + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignPath(t *testing.T) {
+	key := []byte("secret")
+	now := time.Unix(1700000000, 0)
+	query := SignPath(key, "/files/a.pdf", now.Add(time.Minute))
+
+	t.Run("should verify before the expiry", func(t *testing.T) {
+		assert.NoError(t, VerifyPath(key, "/files/a.pdf", query, now))
+	})
+
+	t.Run("should reject expired signatures", func(t *testing.T) {
+		assert.ErrorIs(t, VerifyPath(key, "/files/a.pdf", query, now.Add(2*time.Minute)), ErrSignatureExpired)
+	})
+
+	t.Run("should reject other paths and keys", func(t *testing.T) {
+		assert.ErrorIs(t, VerifyPath(key, "/files/b.pdf", query, now), ErrSignatureInvalid)
+		assert.ErrorIs(t, VerifyPath([]byte("other"), "/files/a.pdf", query, now), ErrSignatureInvalid)
+	})
+
+	t.Run("should reject a changed expiry", func(t *testing.T) {
+		forged := SignPath(key, "/files/a.pdf", now.Add(time.Minute))
+		forged.Set("expires", "1900000000")
+		assert.ErrorIs(t, VerifyPath(key, "/files/a.pdf", forged, now), ErrSignatureInvalid)
+	})
+}
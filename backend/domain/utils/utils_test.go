@@ -77,3 +77,63 @@ func TestGetNonEmptyFields(t *testing.T) {
 		assert.Equal(t, Dict{}, got)
 	})
 }
+
+func TestValidators(t *testing.T) {
+	t.Run("should validate mobile numbers", func(t *testing.T) {
+		assert.True(t, IsIndianMobile("9876543210"))
+		assert.False(t, IsIndianMobile("5876543210"))
+		assert.False(t, IsIndianMobile("+919876543210"))
+		assert.False(t, IsIndianMobile("987654321"))
+	})
+
+	t.Run("should validate IFSC codes", func(t *testing.T) {
+		assert.True(t, IsIFSC("HDFC0001234"))
+		assert.True(t, IsIFSC("SBIN00ABC12"))
+		assert.False(t, IsIFSC("HDFC1001234"))
+		assert.False(t, IsIFSC("hdfc0001234"))
+	})
+
+	t.Run("should validate PAN", func(t *testing.T) {
+		assert.True(t, IsPAN("ABCDE1234F"))
+		assert.False(t, IsPAN("ABCD12345F"))
+	})
+
+	t.Run("should validate pincodes", func(t *testing.T) {
+		assert.True(t, IsPincode("560001"))
+		assert.False(t, IsPincode("060001"))
+		assert.False(t, IsPincode("56001"))
+	})
+
+	t.Run("should validate DD-MM-YYYY dates", func(t *testing.T) {
+		assert.True(t, IsDate("29-02-2024"))
+		assert.False(t, IsDate("29-02-2023"))
+		assert.False(t, IsDate("2024-02-29"))
+	})
+
+	t.Run("should validate financial years", func(t *testing.T) {
+		assert.True(t, IsFY("2025-26"))
+		assert.True(t, IsFY("1999-00"))
+		assert.False(t, IsFY("2025-27"))
+		assert.False(t, IsFY("2025-2026"))
+	})
+
+	t.Run("should validate grams and amounts", func(t *testing.T) {
+		assert.True(t, IsGrams("0.0001"))
+		assert.True(t, IsGrams("10"))
+		assert.False(t, IsGrams("0"))
+		assert.False(t, IsGrams("-1"))
+		assert.False(t, IsGrams("1.00001"))
+		assert.True(t, IsAmount("100.50"))
+		assert.False(t, IsAmount("100.505"))
+		assert.False(t, IsAmount("-100"))
+	})
+
+	t.Run("should validate metals", func(t *testing.T) {
+		assert.True(t, IsMetal("silver"))
+		assert.False(t, IsMetal("Silver"))
+		assert.False(t, IsMetal("platinum"))
+		metal, ok := ParseMetal("GOLD")
+		assert.True(t, ok)
+		assert.Equal(t, Gold, metal)
+	})
+}
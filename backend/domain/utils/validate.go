@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// DateLayout is the DD-MM-YYYY layout of dates sent to Augmont
+const DateLayout = "02-01-2006"
+
+// IST is the time zone of the dates of the users
+var IST = time.FixedZone("IST", 5*60*60+30*60)
+
+// ParseDate parses a DD-MM-YYYY date as the start of the day in IST
+func ParseDate(s string) (time.Time, error) {
+	return time.ParseInLocation(DateLayout, s, IST)
+}
+
+// Patterns of the validated formats, also published in the API docs
+const (
+	MobilePattern  = `^[6-9][0-9]{9}$`
+	IFSCPattern    = `^[A-Z]{4}0[A-Z0-9]{6}$`
+	PANPattern     = `^[A-Z]{5}[0-9]{4}[A-Z]$`
+	PincodePattern = `^[1-9][0-9]{5}$`
+	DatePattern    = `^[0-9]{2}-[0-9]{2}-[0-9]{4}$`
+	GramsPattern   = `^[0-9]+(\.[0-9]{1,4})?$`
+	AmountPattern  = `^[0-9]+(\.[0-9]{1,2})?$`
+	FYPattern      = `^[0-9]{4}-[0-9]{2}$`
+	ClockPattern   = `^([01][0-9]|2[0-3]):[0-5][0-9]$`
+)
+
+var (
+	mobileRegex  = regexp.MustCompile(MobilePattern)
+	ifscRegex    = regexp.MustCompile(IFSCPattern)
+	panRegex     = regexp.MustCompile(PANPattern)
+	pincodeRegex = regexp.MustCompile(PincodePattern)
+	gramsRegex   = regexp.MustCompile(GramsPattern)
+	amountRegex  = regexp.MustCompile(AmountPattern)
+	fyRegex      = regexp.MustCompile(FYPattern)
+	clockRegex   = regexp.MustCompile(ClockPattern)
+)
+
+// IsIndianMobile checks for a 10 digit Indian mobile number without country code
+func IsIndianMobile(s string) bool {
+	return mobileRegex.MatchString(s)
+}
+
+// IsIFSC checks for an 11 character IFSC code, 4 letter bank code, 0 and the branch code
+func IsIFSC(s string) bool {
+	return ifscRegex.MatchString(s)
+}
+
+// IsPAN checks for a 10 character PAN, 5 letters, 4 digits and a letter
+func IsPAN(s string) bool {
+	return panRegex.MatchString(s)
+}
+
+// IsPincode checks for a 6 digit Indian pincode
+func IsPincode(s string) bool {
+	return pincodeRegex.MatchString(s)
+}
+
+// IsDate checks for a valid DD-MM-YYYY date
+func IsDate(s string) bool {
+	_, err := time.Parse(DateLayout, s)
+	return err == nil
+}
+
+// IsGrams checks for a positive quantity in grams with up to 4 decimals
+func IsGrams(s string) bool {
+	return gramsRegex.MatchString(s) && isPositive(s)
+}
+
+// IsAmount checks for a positive rupee amount with up to 2 decimals
+func IsAmount(s string) bool {
+	return amountRegex.MatchString(s) && isPositive(s)
+}
+
+// IsFY checks for a financial year like 2025-26, of consecutive years
+func IsFY(s string) bool {
+	if !fyRegex.MatchString(s) {
+		return false
+	}
+	start, _ := strconv.Atoi(s[:4])
+	end, _ := strconv.Atoi(s[5:])
+	return (start+1)%100 == end
+}
+
+// IsClock checks for a time of day as HH:MM, 00:00 to 23:59
+func IsClock(s string) bool {
+	return clockRegex.MatchString(s)
+}
+
+// ClockMinutes returns the minutes since midnight of an HH:MM time of day
+func ClockMinutes(s string) (int, bool) {
+	if !IsClock(s) {
+		return 0, false
+	}
+	hours, _ := strconv.Atoi(s[:2])
+	minutes, _ := strconv.Atoi(s[3:])
+	return hours*60 + minutes, true
+}
+
+func isPositive(s string) bool {
+	f, err := strconv.ParseFloat(s, 64)
+	return err == nil && f > 0
+}
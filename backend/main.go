@@ -1,20 +1,49 @@
 package main
 
 import (
-	"log"
+	"flag"
 
-	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/app"
+	"github.com/EQUISEED-WEALTH/pinch/backend/service"
+)
+
+// Modes of the binary
+const (
+	modeServer = "server"
+	modeWorker = "worker"
+	modeAll    = "all"
 )
 
 func main() {
+	mode := flag.String("mode", modeAll,
+		"server serves the API, worker runs the jobs, the event consumers and the scheduler, all does both")
+	flag.Parse()
+	if *mode != modeServer && *mode != modeWorker && *mode != modeAll {
+		log.Fatalf("unknown mode %q", *mode)
+	}
+
 	// Build the container, which will automatically register
 	// all the services, contorllers, and middleware & repos
 	container := buildContainer()
 
-	// Invoke the server with the container
-	err := container.Invoke(func(router *gin.Engine) {
-		router.Run(":8080")
-	})
+	if *mode != modeServer {
+		app.Invoke(container,
+			service.StartEventWorkers,
+			service.StartJobWorkers,
+			service.StartScheduler,
+		)
+	}
+
+	// Invoke the server or the worker with the container,
+	// both return after a graceful shutdown
+	var err error
+	if *mode == modeWorker {
+		err = container.Invoke(runWorker)
+	} else {
+		err = container.Invoke(runServer)
+	}
 
 	if err != nil {
 		log.Fatal(err)
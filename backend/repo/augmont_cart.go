@@ -0,0 +1,63 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+)
+
+// Carts left untouched this long are dropped
+const cartTTL = 7 * 24 * time.Hour
+
+type augmontCartRepo struct {
+	db *redis.Client
+}
+
+// NewAugmontCartRepo returns a new AugmontCartRepo, each cart is a
+// hash of the quantities by SKU
+func NewAugmontCartRepo(db *redis.Client) interfaces.AugmontCartRepo {
+	return &augmontCartRepo{db}
+}
+
+func cartKey(augmontUserID uint64) string {
+	return fmt.Sprintf("augmont-cart:%d", augmontUserID)
+}
+
+func (r *augmontCartRepo) GetCart(ctx context.Context, augmontUserID uint64) (map[string]int64, error) {
+	items, err := r.db.HGetAll(ctx, cartKey(augmontUserID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	cart := make(map[string]int64, len(items))
+	for sku, quantity := range items {
+		q, err := strconv.ParseInt(quantity, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		cart[sku] = q
+	}
+	return cart, nil
+}
+
+func (r *augmontCartRepo) SetCartItem(ctx context.Context, augmontUserID uint64, sku string, quantity int64) error {
+	key := cartKey(augmontUserID)
+	_, err := r.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if quantity <= 0 {
+			pipe.HDel(ctx, key, sku)
+		} else {
+			pipe.HSet(ctx, key, sku, quantity)
+		}
+		pipe.Expire(ctx, key, cartTTL)
+		return nil
+	})
+	return err
+}
+
+func (r *augmontCartRepo) DeleteCart(ctx context.Context, augmontUserID uint64) error {
+	return r.db.Del(ctx, cartKey(augmontUserID)).Err()
+}
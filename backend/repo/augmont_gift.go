@@ -0,0 +1,230 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+type augmontGiftRepo struct {
+	db *gorm.DB
+}
+
+// NewAugmontGiftRepo creates a new Augmont gift repo
+func NewAugmontGiftRepo(db *gorm.DB) interfaces.AugmontGiftRepo {
+	return &augmontGiftRepo{
+		db: db,
+	}
+}
+
+func (r *augmontGiftRepo) CreateGift(ctx context.Context, gift *models.AugmontGift, entries ...*models.AugmontLedgerEntry) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(gift).Error; err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			entry.GiftID = gift.ID
+			if err := tx.Create(entry).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *augmontGiftRepo) CompleteSend(ctx context.Context, gift *models.AugmontGift, entries ...*models.AugmontLedgerEntry) (bool, error) {
+	completed := false
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.AugmontGift{}).
+			Where("id = ? AND status = ?", gift.ID, models.GiftSending).
+			Updates(map[string]interface{}{
+				"status":     gift.Status,
+				"updated_at": gorm.Expr("now()"),
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		for _, entry := range entries {
+			entry.GiftID = gift.ID
+			if err := tx.Create(entry).Error; err != nil {
+				return err
+			}
+		}
+		completed = true
+		return nil
+	})
+	return completed, err
+}
+
+func (r *augmontGiftRepo) FailSend(ctx context.Context, gift *models.AugmontGift) (bool, error) {
+	result := conn(ctx, r.db).
+		Model(&models.AugmontGift{}).
+		Where("id = ? AND status = ?", gift.ID, models.GiftSending).
+		Updates(map[string]interface{}{
+			"status":     models.GiftFailed,
+			"updated_at": gorm.Expr("now()"),
+		})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false, result.Error
+	}
+	status := models.GiftFailed
+	gift.Status = &status
+	return true, nil
+}
+
+func (r *augmontGiftRepo) StartClaim(ctx context.Context, gift *models.AugmontGift) (bool, error) {
+	result := conn(ctx, r.db).
+		Model(&models.AugmontGift{}).
+		Where("id = ? AND status = ?", gift.ID, models.GiftEscrowed).
+		Updates(map[string]interface{}{
+			"status":       models.GiftClaiming,
+			"claim_txn_id": gift.ClaimTxnID,
+			"recipient_id": gift.RecipientID,
+			"updated_at":   gorm.Expr("now()"),
+		})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false, result.Error
+	}
+	status := models.GiftClaiming
+	gift.Status = &status
+	return true, nil
+}
+
+func (r *augmontGiftRepo) CancelClaim(ctx context.Context, gift *models.AugmontGift) (bool, error) {
+	result := conn(ctx, r.db).
+		Model(&models.AugmontGift{}).
+		Where("id = ? AND status = ?", gift.ID, models.GiftClaiming).
+		Updates(map[string]interface{}{
+			"status":       models.GiftEscrowed,
+			"claim_txn_id": nil,
+			"recipient_id": nil,
+			"updated_at":   gorm.Expr("now()"),
+		})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false, result.Error
+	}
+	status := models.GiftEscrowed
+	gift.Status, gift.ClaimTxnID, gift.RecipientID = &status, nil, nil
+	return true, nil
+}
+
+func (r *augmontGiftRepo) CompleteClaim(ctx context.Context, gift *models.AugmontGift, entry *models.AugmontLedgerEntry) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(gift).
+			Select("status", "recipient_id", "claim_txn_id", "claimed_at").
+			Updates(gift).
+			Error
+		if err != nil {
+			return err
+		}
+		entry.GiftID = gift.ID
+		return tx.Create(entry).Error
+	})
+}
+
+func (r *augmontGiftRepo) FindEscrowedGifts(ctx context.Context, mobile string) ([]*models.AugmontGift, error) {
+	var gifts []*models.AugmontGift
+	err := conn(ctx, r.db).
+		Preload("Sender").
+		Where("recipient_mobile = ? AND status = ?", mobile, models.GiftEscrowed).
+		Order("id").
+		Find(&gifts).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return gifts, nil
+}
+
+func (r *augmontGiftRepo) FindStaleClaims(ctx context.Context, before time.Time) ([]*models.AugmontGift, error) {
+	var gifts []*models.AugmontGift
+	err := conn(ctx, r.db).
+		Preload("Sender").
+		Where("status = ? AND updated_at < ?", models.GiftClaiming, before).
+		Order("id").
+		Find(&gifts).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return gifts, nil
+}
+
+func (r *augmontGiftRepo) FindSendingGifts(ctx context.Context, senderID uint64) ([]*models.AugmontGift, error) {
+	var gifts []*models.AugmontGift
+	err := conn(ctx, r.db).
+		Where("sender_id = ? AND status = ?", senderID, models.GiftSending).
+		Order("id").
+		Find(&gifts).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return gifts, nil
+}
+
+func (r *augmontGiftRepo) FindStaleSends(ctx context.Context, before time.Time) ([]*models.AugmontGift, error) {
+	var gifts []*models.AugmontGift
+	err := conn(ctx, r.db).
+		Preload("Sender").
+		Where("status = ? AND updated_at < ?", models.GiftSending, before).
+		Order("id").
+		Find(&gifts).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return gifts, nil
+}
+
+// giftColumns are the gift fields clients may sort and filter on
+var giftColumns = queryColumns{
+	"createdAt": "augmont_gifts.created_at",
+	"status":    "augmont_gifts.status",
+	"metalType": "augmont_gifts.metal_type",
+}
+
+func (r *augmontGiftRepo) ListGifts(
+	ctx context.Context,
+	augmontUserID uint64,
+	mobile string,
+	q *utils.ListQuery,
+) ([]*models.AugmontGift, *utils.Page, error) {
+	var gifts []*models.AugmontGift
+	db := conn(ctx, r.db).Model(&models.AugmontGift{}).
+		Where("(augmont_gifts.sender_id = ? OR augmont_gifts.recipient_id = ? OR "+
+			"(augmont_gifts.recipient_mobile = ? AND augmont_gifts.status = ?))",
+			augmontUserID, augmontUserID, mobile, models.GiftEscrowed)
+	page, err := paginate(ctx, db, q, giftColumns, "augmont_gifts", &gifts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return gifts, page, nil
+}
+
+// ledgerColumns are the ledger fields clients may sort and filter on
+var ledgerColumns = queryColumns{
+	"createdAt": "augmont_ledger_entries.created_at",
+	"type":      "augmont_ledger_entries.type",
+	"metalType": "augmont_ledger_entries.metal_type",
+}
+
+func (r *augmontGiftRepo) ListLedger(
+	ctx context.Context,
+	augmontUserID uint64,
+	q *utils.ListQuery,
+) ([]*models.AugmontLedgerEntry, *utils.Page, error) {
+	var entries []*models.AugmontLedgerEntry
+	db := conn(ctx, r.db).Model(&models.AugmontLedgerEntry{}).
+		Where("augmont_ledger_entries.augmont_user_id = ?", augmontUserID)
+	page, err := paginate(ctx, db, q, ledgerColumns, "augmont_ledger_entries", &entries)
+	if err != nil {
+		return nil, nil, err
+	}
+	return entries, page, nil
+}
@@ -0,0 +1,45 @@
+package repo
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+type augmontInvoiceRepo struct {
+	db *gorm.DB
+}
+
+// NewAugmontInvoiceRepo creates a new Augmont invoice repo
+func NewAugmontInvoiceRepo(db *gorm.DB) interfaces.AugmontInvoiceRepo {
+	return &augmontInvoiceRepo{
+		db: db,
+	}
+}
+
+func (r *augmontInvoiceRepo) Create(ctx context.Context, invoice *models.AugmontInvoice) error {
+	return conn(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "merchant_txn_id"}},
+			DoNothing: true,
+		}).
+		Create(invoice).
+		Error
+}
+
+func (r *augmontInvoiceRepo) FindByTxnID(ctx context.Context, augmontUserID uint64, txnID string) (*models.AugmontInvoice, error) {
+	var found []*models.AugmontInvoice
+	err := conn(ctx, r.db).
+		Where(&models.AugmontInvoice{AugmontUserID: &augmontUserID, MerchantTxnID: &txnID}).
+		Limit(1).
+		Find(&found).
+		Error
+	if err != nil || len(found) == 0 {
+		return nil, err
+	}
+	return found[0], nil
+}
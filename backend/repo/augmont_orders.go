@@ -1,10 +1,16 @@
 package repo
 
 import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
 	"gorm.io/gorm"
 
 	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
 	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
 )
 
 type augmontOrdersRepo struct {
@@ -20,13 +26,13 @@ func NewAugmontOrderRepo(db *gorm.DB) interfaces.AugmontOrderRepo {
 
 // ---- BuyOrders Repo ----
 
-func (r augmontOrdersRepo) CreateBuy(order *models.AugmontBuyOrder) error {
-	return r.db.Create(order).Error
+func (r augmontOrdersRepo) CreateBuy(ctx context.Context, order *models.AugmontBuyOrder) error {
+	return conn(ctx, r.db).Create(order).Error
 }
 
-func (r *augmontOrdersRepo) FindBuy(order *models.AugmontBuyOrder) (*models.AugmontBuyOrder, error) {
+func (r *augmontOrdersRepo) FindBuy(ctx context.Context, order *models.AugmontBuyOrder) (*models.AugmontBuyOrder, error) {
 	var newOrder models.AugmontBuyOrder
-	err := r.db.
+	err := conn(ctx, r.db).
 		Where(order).
 		First(&newOrder).
 		Error
@@ -35,21 +41,39 @@ func (r *augmontOrdersRepo) FindBuy(order *models.AugmontBuyOrder) (*models.Augm
 	}
 	return &newOrder, err
 }
-func (r *augmontOrdersRepo) FindBuys(order *models.AugmontBuyOrder) ([]*models.AugmontBuyOrder, error) {
+func (r *augmontOrdersRepo) FindBuys(ctx context.Context, order *models.AugmontBuyOrder) ([]*models.AugmontBuyOrder, error) {
 	var orders []*models.AugmontBuyOrder
-	err := r.db.
+	err := conn(ctx, r.db).
 		Where(order).
-		Find(orders).
+		Find(&orders).
 		Error
 	if err != nil {
 		return nil, err
 	}
 	return orders, err
 }
-func (r *augmontOrdersRepo) FindAllBuys() ([]*models.AugmontBuyOrder, error) {
-	var orders []*models.AugmontBuyOrder
-	err := r.db.
-		Find(orders).
+
+func (r *augmontOrdersRepo) CreateSell(ctx context.Context, order *models.AugmontSellOrder) error {
+	return conn(ctx, r.db).Create(order).Error
+}
+
+func (r *augmontOrdersRepo) FindSell(ctx context.Context, order *models.AugmontSellOrder) (*models.AugmontSellOrder, error) {
+	var newOrder models.AugmontSellOrder
+	err := conn(ctx, r.db).
+		Where(order).
+		First(&newOrder).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &newOrder, err
+}
+
+func (r *augmontOrdersRepo) FindSells(ctx context.Context, order *models.AugmontSellOrder) ([]*models.AugmontSellOrder, error) {
+	var orders []*models.AugmontSellOrder
+	err := conn(ctx, r.db).
+		Where(order).
+		Find(&orders).
 		Error
 	if err != nil {
 		return nil, err
@@ -57,13 +81,13 @@ func (r *augmontOrdersRepo) FindAllBuys() ([]*models.AugmontBuyOrder, error) {
 	return orders, err
 }
 
-func (r *augmontOrdersRepo) CreateSell(order *models.AugmontSellOrder) error {
-	return r.db.Create(order).Error
+func (r *augmontOrdersRepo) CreateRedeem(ctx context.Context, order *models.AugmontRedeemOrder) error {
+	return conn(ctx, r.db).Create(order).Error
 }
 
-func (r *augmontOrdersRepo) FindSell(order *models.AugmontSellOrder) (*models.AugmontSellOrder, error) {
-	var newOrder models.AugmontSellOrder
-	err := r.db.
+func (r *augmontOrdersRepo) FindRedeem(ctx context.Context, order *models.AugmontRedeemOrder) (*models.AugmontRedeemOrder, error) {
+	var newOrder models.AugmontRedeemOrder
+	err := conn(ctx, r.db).
 		Where(order).
 		First(&newOrder).
 		Error
@@ -73,11 +97,11 @@ func (r *augmontOrdersRepo) FindSell(order *models.AugmontSellOrder) (*models.Au
 	return &newOrder, err
 }
 
-func (r *augmontOrdersRepo) FindSells(order *models.AugmontSellOrder) ([]*models.AugmontSellOrder, error) {
-	var orders []*models.AugmontSellOrder
-	err := r.db.
+func (r *augmontOrdersRepo) FindRedeems(ctx context.Context, order *models.AugmontRedeemOrder) ([]*models.AugmontRedeemOrder, error) {
+	var orders []*models.AugmontRedeemOrder
+	err := conn(ctx, r.db).
 		Where(order).
-		Find(orders).
+		Find(&orders).
 		Error
 	if err != nil {
 		return nil, err
@@ -85,52 +109,205 @@ func (r *augmontOrdersRepo) FindSells(order *models.AugmontSellOrder) ([]*models
 	return orders, err
 }
 
-func (r *augmontOrdersRepo) FindAllSells() ([]*models.AugmontSellOrder, error) {
-	var orders []*models.AugmontSellOrder
-	err := r.db.
-		Find(orders).
+// ---- Orders of all types ----
+
+// orderColumns are the columns of the orders union
+var orderColumns = queryColumns{
+	"createdAt": "augmont_orders.created_at",
+	"type":      "augmont_orders.type",
+	"status":    "augmont_orders.status",
+	"metalType": "augmont_orders.metal_type",
+	"quantity":  "augmont_orders.quantity",
+	"amount":    "augmont_orders.amount",
+}
+
+// orderTableColumns are selected from each order table
+const orderTableColumns = "id, created_at, updated_at, merchant_txn_id, augmont_user_id, " +
+	"status, metal_type, quantity, amount, rate"
+
+// orders returns the union of the order tables of the user as augmont_orders,
+// merchant transaction ids are unique across the tables, the rows of a
+// redeem order of several metals share one
+func (r *augmontOrdersRepo) orders(ctx context.Context, augmontUserID uint64) *gorm.DB {
+	return r.allOrders(ctx).Where("augmont_orders.augmont_user_id = ?", augmontUserID)
+}
+
+// allOrders returns the union of the order tables of every user
+func (r *augmontOrdersRepo) allOrders(ctx context.Context) *gorm.DB {
+	db := conn(ctx, r.db)
+	return union(db, orderTables(db)...)
+}
+
+// movements returns the orders with the ledger entries of the user as
+// completed orders of their type, the grams moved are positive
+func (r *augmontOrdersRepo) movements(ctx context.Context, augmontUserID uint64) *gorm.DB {
+	db := conn(ctx, r.db)
+	ledger := db.Model(&models.AugmontLedgerEntry{}).
+		Select("type, id, created_at, created_at AS updated_at, merchant_txn_id, augmont_user_id, "+
+			"CAST(? AS varchar(20)) AS status, metal_type, ABS(quantity) AS quantity, "+
+			"CAST(NULL AS numeric) AS amount, CAST(NULL AS numeric) AS rate", models.OrderCompleted)
+	return union(db, append(orderTables(db), ledger)...).
+		Where("augmont_orders.augmont_user_id = ?", augmontUserID)
+}
+
+// orderTables returns the queries of the order tables, postgres pushes
+// the conditions on the union down to them
+func orderTables(db *gorm.DB) []interface{} {
+	table := func(model interface{}, orderType string) *gorm.DB {
+		return db.Model(model).
+			Select("CAST(? AS varchar(20)) AS type, "+orderTableColumns, orderType)
+	}
+	// A redeem order is a row for each metal delivered, its amount is on
+	// the row of the metal of the order, orders without metals keep theirs
+	redeems := db.Table("augmont_redeem_orders AS r").
+		Select("CAST(? AS varchar(20)) AS type, r.id, r.created_at, r.updated_at, "+
+			"r.merchant_txn_id, r.augmont_user_id, r.status, "+
+			"COALESCE(m.metal_type, r.metal_type) AS metal_type, "+
+			"COALESCE(m.quantity, r.quantity) AS quantity, "+
+			"CASE WHEN m.id IS NULL OR m.metal_type = r.metal_type THEN r.amount END AS amount, "+
+			"r.rate", models.OrderRedeem).
+		Joins("LEFT JOIN augmont_redeem_metals AS m ON m.redeem_order_id = r.id")
+	return []interface{}{
+		table(&models.AugmontBuyOrder{}, models.OrderBuy),
+		table(&models.AugmontSellOrder{}, models.OrderSell),
+		redeems,
+	}
+}
+
+// union returns the union of the queries as augmont_orders
+func union(db *gorm.DB, queries ...interface{}) *gorm.DB {
+	sql := strings.TrimSuffix(strings.Repeat("? UNION ALL ", len(queries)), " UNION ALL ")
+	return db.Table("(?) AS augmont_orders", db.Raw(sql, queries...))
+}
+
+// orderKey orders the rows of the union last, the rows of a redeem
+// order share its merchant transaction id
+const orderKey = "augmont_orders.merchant_txn_id, augmont_orders.metal_type"
+
+func (r *augmontOrdersRepo) FindOrder(ctx context.Context, augmontUserID uint64, txnID string) (*models.AugmontOrder, error) {
+	var order models.AugmontOrder
+	err := r.orders(ctx, augmontUserID).
+		Where("augmont_orders.merchant_txn_id = ?", txnID).
+		Order("augmont_orders.metal_type").
+		Take(&order).
 		Error
 	if err != nil {
 		return nil, err
 	}
-	return orders, err
+	return &order, nil
 }
 
-func (r *augmontOrdersRepo) CreateRedeem(order *models.AugmontRedeemOrder) error {
-	return r.db.Create(order).Error
+func (r *augmontOrdersRepo) ListOrders(
+	ctx context.Context,
+	augmontUserID uint64,
+	q *utils.ListQuery,
+) ([]*models.AugmontOrder, *utils.Page, error) {
+	return listOrders(ctx, r.orders(ctx, augmontUserID), q)
 }
 
-func (r *augmontOrdersRepo) FindRedeem(order *models.AugmontRedeemOrder) (*models.AugmontRedeemOrder, error) {
-	var newOrder models.AugmontRedeemOrder
-	err := r.db.
-		Where(order).
-		First(&newOrder).
-		Error
+func (r *augmontOrdersRepo) ListAllOrders(
+	ctx context.Context,
+	q *utils.ListQuery,
+) ([]*models.AugmontOrder, *utils.Page, error) {
+	return listOrders(ctx, r.allOrders(ctx), q)
+}
+
+// listOrders returns a page of the orders of the union
+func listOrders(ctx context.Context, db *gorm.DB, q *utils.ListQuery) ([]*models.AugmontOrder, *utils.Page, error) {
+	// Newest first, ids repeat across the order tables
+	if q.Sort == "" {
+		q.Sort = "-createdAt"
+	}
+
+	var orders []*models.AugmontOrder
+	page, err := paginateBy(ctx, db, q, orderColumns, "augmont_orders", orderKey, &orders)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return &newOrder, err
+	return orders, page, nil
 }
 
-func (r *augmontOrdersRepo) FindRedeems(order *models.AugmontRedeemOrder) ([]*models.AugmontRedeemOrder, error) {
-	var orders []*models.AugmontRedeemOrder
-	err := r.db.
-		Where(order).
-		Find(orders).
+func (r *augmontOrdersRepo) FindOrdersBetween(
+	ctx context.Context,
+	augmontUserID uint64,
+	from, to time.Time,
+) ([]*models.AugmontOrder, error) {
+	var orders []*models.AugmontOrder
+	err := r.movements(ctx, augmontUserID).
+		Where("augmont_orders.created_at BETWEEN ? AND ?", from, to).
+		Order("augmont_orders.created_at").
+		Order(orderKey).
+		Find(&orders).
 		Error
 	if err != nil {
 		return nil, err
 	}
-	return orders, err
+	return orders, nil
 }
 
-func (r *augmontOrdersRepo) FindAllRedeems() ([]*models.AugmontRedeemOrder, error) {
-	var orders []*models.AugmontRedeemOrder
-	err := r.db.
-		Find(orders).
+// orderModels are the tables of each order type
+var orderModels = map[string]interface{}{
+	models.OrderBuy:    &models.AugmontBuyOrder{},
+	models.OrderSell:   &models.AugmontSellOrder{},
+	models.OrderRedeem: &models.AugmontRedeemOrder{},
+}
+
+func (r *augmontOrdersRepo) FindIncompleteOrders(ctx context.Context, augmontUserID uint64) ([]*models.AugmontOrder, error) {
+	db := conn(ctx, r.db)
+	table := func(orderType, missing string) *gorm.DB {
+		return db.Model(orderModels[orderType]).
+			Select("CAST(? AS varchar(20)) AS type, "+orderTableColumns, orderType).
+			Where("augmont_user_id = ? AND ("+missing+")", augmontUserID)
+	}
+	// Redeems have no rate, their amount is not always sent
+	prices := "metal_type IS NULL OR quantity IS NULL OR amount IS NULL OR rate IS NULL"
+	var orders []*models.AugmontOrder
+	err := union(db,
+		table(models.OrderBuy, prices),
+		table(models.OrderSell, prices),
+		table(models.OrderRedeem, "metal_type IS NULL OR quantity IS NULL"),
+	).
+		Order("augmont_orders.created_at").
+		Find(&orders).
 		Error
 	if err != nil {
 		return nil, err
 	}
-	return orders, err
+	return orders, nil
+}
+
+func (r *augmontOrdersRepo) FillOrder(ctx context.Context, order *models.AugmontOrder) error {
+	model, ok := orderModels[*order.Type]
+	if !ok {
+		return errors.Newf("no table of %q orders", *order.Type)
+	}
+	return conn(ctx, r.db).
+		Model(model).
+		Where("id = ?", order.ID).
+		Updates(map[string]interface{}{
+			"metal_type": gorm.Expr("COALESCE(metal_type, ?)", order.MetalType),
+			"quantity":   gorm.Expr("COALESCE(quantity, ?)", order.Quantity),
+			"amount":     gorm.Expr("COALESCE(amount, ?)", order.Amount),
+			"rate":       gorm.Expr("COALESCE(rate, ?)", order.Rate),
+		}).
+		Error
+}
+
+func (r *augmontOrdersRepo) RerunOrder(ctx context.Context, order *models.AugmontOrder) (bool, error) {
+	if *order.Type != models.OrderBuy && *order.Type != models.OrderSell {
+		return false, errors.Newf("%q orders can not be rerun", *order.Type)
+	}
+	result := conn(ctx, r.db).
+		Model(orderModels[*order.Type]).
+		Where("id = ? AND status = ?", order.ID, models.OrderFailed).
+		Updates(map[string]interface{}{
+			"status":     models.OrderRerun,
+			"updated_at": gorm.Expr("now()"),
+		})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false, result.Error
+	}
+	status := models.OrderRerun
+	order.Status = &status
+	return true, nil
 }
@@ -0,0 +1,103 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+type augmontProductRepo struct {
+	db *gorm.DB
+}
+
+// NewAugmontProductRepo creates a new Augmont product repo
+func NewAugmontProductRepo(db *gorm.DB) interfaces.AugmontProductRepo {
+	return &augmontProductRepo{
+		db: db,
+	}
+}
+
+func (r *augmontProductRepo) Upsert(ctx context.Context, products []*models.AugmontProduct) error {
+	if len(products) == 0 {
+		return nil
+	}
+	return conn(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "sku"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"updated_at", "name", "metal_type", "purity", "weight",
+				"making_charges", "images", "stock", "active", "synced_at",
+			}),
+		}).
+		Create(&products).
+		Error
+}
+
+func (r *augmontProductRepo) Deactivate(ctx context.Context, keep []string) (int64, error) {
+	db := conn(ctx, r.db).
+		Model(&models.AugmontProduct{}).
+		Where("active")
+	if len(keep) > 0 {
+		db = db.Where("sku NOT IN ?", keep)
+	}
+	result := db.Updates(map[string]interface{}{
+		"active":     false,
+		"updated_at": time.Now(),
+	})
+	return result.RowsAffected, result.Error
+}
+
+func (r *augmontProductRepo) FindBySKU(ctx context.Context, sku string) (*models.AugmontProduct, error) {
+	var found models.AugmontProduct
+	err := conn(ctx, r.db).
+		Where(&models.AugmontProduct{SKU: &sku}).
+		First(&found).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &found, nil
+}
+
+func (r *augmontProductRepo) FindBySKUs(ctx context.Context, skus []string) ([]*models.AugmontProduct, error) {
+	var found []*models.AugmontProduct
+	if len(skus) == 0 {
+		return found, nil
+	}
+	err := conn(ctx, r.db).
+		Where("sku IN ?", skus).
+		Find(&found).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// productColumns are the product fields clients may sort and filter on
+var productColumns = queryColumns{
+	"createdAt": "augmont_products.created_at",
+	"sku":       "augmont_products.sku",
+	"metalType": "augmont_products.metal_type",
+	"weight":    "augmont_products.weight",
+}
+
+// List lists the products in the catalogue, inactive ones are left out
+func (r *augmontProductRepo) List(
+	ctx context.Context,
+	q *utils.ListQuery,
+) ([]*models.AugmontProduct, *utils.Page, error) {
+	var products []*models.AugmontProduct
+	db := conn(ctx, r.db).Model(&models.AugmontProduct{}).Where("augmont_products.active")
+	page, err := paginate(ctx, db, q, productColumns, "augmont_products", &products)
+	if err != nil {
+		return nil, nil, err
+	}
+	return products, page, nil
+}
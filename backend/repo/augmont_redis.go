@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
 )
 
 type AugmontInMemRepo struct {
@@ -21,8 +23,8 @@ func NewAugmontInMemRepo(db *redis.Client) interfaces.AugmontInMemRepo {
 }
 
 // GetToken returns augmont auth token
-func (r *AugmontInMemRepo) GetToken() (string, error) {
-	token, err := r.db.Get(context.TODO(), "augmont-token").Result()
+func (r *AugmontInMemRepo) GetToken(ctx context.Context) (string, error) {
+	token, err := r.db.Get(ctx, "augmont-token").Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
 			return "", nil
@@ -33,10 +35,10 @@ func (r *AugmontInMemRepo) GetToken() (string, error) {
 }
 
 //SetToken  Sets auth token with expiry time
-func (r *AugmontInMemRepo) SetToken(token string, expireAt time.Time) error {
+func (r *AugmontInMemRepo) SetToken(ctx context.Context, token string, expireAt time.Time) error {
 	log.WithField("expireAt", expireAt).Info("new augmont token added")
 	_, err := r.db.Set(
-		context.TODO(),
+		ctx,
 		"augmont-token",
 		token,
 		time.Until(expireAt),
@@ -46,3 +48,45 @@ func (r *AugmontInMemRepo) SetToken(token string, expireAt time.Time) error {
 	}
 	return nil
 }
+
+// TokenTTL returns the remaining life of the stored auth token
+func (r *AugmontInMemRepo) TokenTTL(ctx context.Context) (time.Duration, error) {
+	ttl, err := r.db.TTL(ctx, "augmont-token").Result()
+	if err != nil {
+		return 0, err
+	}
+	// Negative values are returned for missing keys
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+func orderDetailKey(txnID string) string {
+	return "augmont-order:" + txnID
+}
+
+// GetOrderDetail returns the cached augmont detail of an order
+func (r *AugmontInMemRepo) GetOrderDetail(ctx context.Context, txnID string) (utils.Any, error) {
+	data, err := r.db.Get(ctx, orderDetailKey(txnID)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var detail utils.Any
+	if err := json.Unmarshal(data, &detail); err != nil {
+		return nil, err
+	}
+	return detail, nil
+}
+
+// SetOrderDetail caches the augmont detail of an order
+func (r *AugmontInMemRepo) SetOrderDetail(ctx context.Context, txnID string, detail utils.Any, ttl time.Duration) error {
+	data, err := json.Marshal(detail)
+	if err != nil {
+		return err
+	}
+	return r.db.Set(ctx, orderDetailKey(txnID), data, ttl).Err()
+}
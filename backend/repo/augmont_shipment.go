@@ -0,0 +1,99 @@
+package repo
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+type augmontShipmentRepo struct {
+	db *gorm.DB
+}
+
+// NewAugmontShipmentRepo creates a new Augmont shipment repo
+func NewAugmontShipmentRepo(db *gorm.DB) interfaces.AugmontShipmentRepo {
+	return &augmontShipmentRepo{
+		db: db,
+	}
+}
+
+func (r *augmontShipmentRepo) FindRedeem(ctx context.Context, txnID string) (*models.AugmontRedeemOrder, error) {
+	var found []*models.AugmontRedeemOrder
+	err := conn(ctx, r.db).
+		Preload("AugmontUser").
+		Where(&models.AugmontRedeemOrder{MerchantTxnID: &txnID}).
+		Limit(1).
+		Find(&found).
+		Error
+	if err != nil || len(found) == 0 {
+		return nil, err
+	}
+	return found[0], nil
+}
+
+func (r *augmontShipmentRepo) FindOpenShipments(ctx context.Context) ([]*models.AugmontRedeemOrder, error) {
+	var found []*models.AugmontRedeemOrder
+	err := conn(ctx, r.db).
+		Preload("AugmontUser").
+		Where("status <> ?", models.OrderFailed).
+		Where("shipment_status IS NULL OR shipment_status NOT IN ?",
+			[]string{models.ShipmentDelivered, models.ShipmentReturned}).
+		Order("id").
+		Find(&found).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+func (r *augmontShipmentRepo) UpdateShipment(
+	ctx context.Context,
+	order *models.AugmontRedeemOrder,
+	from *string,
+	event *models.AugmontShipmentEvent,
+) (bool, error) {
+	moved := false
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{
+			"shipment_status": event.Status,
+			"updated_at":      gorm.Expr("now()"),
+		}
+		if order.Courier != nil {
+			updates["courier"] = order.Courier
+		}
+		if order.TrackingNumber != nil {
+			updates["tracking_number"] = order.TrackingNumber
+		}
+		// Polls and callbacks race, only one of them moves the status
+		result := tx.Model(&models.AugmontRedeemOrder{}).
+			Where("id = ? AND shipment_status IS NOT DISTINCT FROM ?", order.ID, from).
+			Updates(updates)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		event.RedeemOrderID = order.ID
+		if err := tx.Create(event).Error; err != nil {
+			return err
+		}
+		moved = true
+		return nil
+	})
+	return moved, err
+}
+
+func (r *augmontShipmentRepo) FindEvents(ctx context.Context, redeemOrderID uint64) ([]*models.AugmontShipmentEvent, error) {
+	var events []*models.AugmontShipmentEvent
+	err := conn(ctx, r.db).
+		Where(&models.AugmontShipmentEvent{RedeemOrderID: &redeemOrderID}).
+		Order("at, id").
+		Find(&events).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
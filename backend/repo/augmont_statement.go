@@ -0,0 +1,41 @@
+package repo
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+type augmontStatementRepo struct {
+	db *gorm.DB
+}
+
+// NewAugmontStatementRepo creates a new Augmont statement repo
+func NewAugmontStatementRepo(db *gorm.DB) interfaces.AugmontStatementRepo {
+	return &augmontStatementRepo{
+		db: db,
+	}
+}
+
+func (r *augmontStatementRepo) Create(ctx context.Context, statement *models.AugmontStatement) error {
+	return conn(ctx, r.db).Create(statement).Error
+}
+
+func (r *augmontStatementRepo) Update(ctx context.Context, statement *models.AugmontStatement) error {
+	return conn(ctx, r.db).Updates(statement).Error
+}
+
+func (r *augmontStatementRepo) FindOne(ctx context.Context, statement *models.AugmontStatement) (*models.AugmontStatement, error) {
+	var found models.AugmontStatement
+	err := conn(ctx, r.db).
+		Where(statement).
+		First(&found).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &found, nil
+}
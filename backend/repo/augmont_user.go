@@ -1,10 +1,13 @@
 package repo
 
 import (
+	"context"
+
 	"gorm.io/gorm"
 
 	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
 	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
 )
 
 type augmontUserRepo struct {
@@ -15,16 +18,7 @@ type augmontUserRepo struct {
 func NewAugmontUserRepo(db *gorm.DB) interfaces.AugmontUserRepo {
 
 	// Migrate all Augmont related tables
-	db.AutoMigrate(
-		&models.AugmontUser{},
-
-		&models.AugmontUserBank{},
-		&models.AugmontUserAddress{},
-
-		&models.AugmontBuyOrder{},
-		&models.AugmontSellOrder{},
-		&models.AugmontRedeemOrder{},
-	)
+	db.AutoMigrate(augmontModels...)
 
 	return &augmontUserRepo{
 		db: db,
@@ -33,17 +27,17 @@ func NewAugmontUserRepo(db *gorm.DB) interfaces.AugmontUserRepo {
 
 // ---- Augmont User ----
 
-func (r *augmontUserRepo) CreateUser(user *models.AugmontUser) error {
-	return r.db.Create(user).Error
+func (r *augmontUserRepo) CreateUser(ctx context.Context, user *models.AugmontUser) error {
+	return conn(ctx, r.db).Create(user).Error
 }
 
-func (r *augmontUserRepo) UpdateUser(user *models.AugmontUser) error {
-	return r.db.Model(&models.AugmontUser{ID: user.ID}).Updates(user).Error
+func (r *augmontUserRepo) UpdateUser(ctx context.Context, user *models.AugmontUser) error {
+	return conn(ctx, r.db).Model(&models.AugmontUser{ID: user.ID}).Updates(user).Error
 }
 
-func (r *augmontUserRepo) FindUser(user *models.AugmontUser) (*models.AugmontUser, error) {
+func (r *augmontUserRepo) FindUser(ctx context.Context, user *models.AugmontUser) (*models.AugmontUser, error) {
 	var userFound models.AugmontUser
-	err := r.db.
+	err := conn(ctx, r.db).
 		Where(user).
 		First(&userFound).
 		Error
@@ -53,9 +47,9 @@ func (r *augmontUserRepo) FindUser(user *models.AugmontUser) (*models.AugmontUse
 	return &userFound, nil
 }
 
-func (r *augmontUserRepo) FindUsers(user *models.AugmontUser) ([]*models.AugmontUser, error) {
+func (r *augmontUserRepo) FindUsers(ctx context.Context, user *models.AugmontUser) ([]*models.AugmontUser, error) {
 	var users []*models.AugmontUser
-	err := r.db.
+	err := conn(ctx, r.db).
 		Where(user).
 		Find(&users).Error
 	if err != nil {
@@ -64,34 +58,44 @@ func (r *augmontUserRepo) FindUsers(user *models.AugmontUser) ([]*models.Augmont
 	return users, nil
 }
 
-func (r *augmontUserRepo) FindAllUsers() ([]*models.AugmontUser, error) {
+// augmontUserColumns are the sortable and filterable augmont user fields
+var augmontUserColumns = queryColumns{
+	"id":        "augmont_users.id",
+	"createdAt": "augmont_users.created_at",
+	"userId":    "augmont_users.user_id",
+	"kycStatus": "augmont_users.kyc_status",
+}
+
+func (r *augmontUserRepo) ListUsers(
+	ctx context.Context,
+	q *utils.ListQuery,
+) ([]*models.AugmontUser, *utils.Page, error) {
 	var users []*models.AugmontUser
-	err := r.db.
-		Find(&users).
-		Error
+	db := conn(ctx, r.db).Model(&models.AugmontUser{})
+	page, err := paginate(ctx, db, q, augmontUserColumns, "augmont_users", &users)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return users, nil
+	return users, page, nil
 }
 
 // ---- Augmont User Bank ----
 
-func (r *augmontUserRepo) CreateBank(bank *models.AugmontUserBank) error {
-	return r.db.Create(bank).Error
+func (r *augmontUserRepo) CreateBank(ctx context.Context, bank *models.AugmontUserBank) error {
+	return conn(ctx, r.db).Create(bank).Error
 }
 
-func (r *augmontUserRepo) DeleteBank(bank *models.AugmontUserBank) error {
-	return r.db.
+func (r *augmontUserRepo) DeleteBank(ctx context.Context, bank *models.AugmontUserBank) error {
+	return conn(ctx, r.db).
 		Where(bank).
 		Limit(1).
 		Delete(models.AugmontUserBank{}).
 		Error
 }
 
-func (r *augmontUserRepo) FindBank(bank *models.AugmontUserBank) (*models.AugmontUserBank, error) {
+func (r *augmontUserRepo) FindBank(ctx context.Context, bank *models.AugmontUserBank) (*models.AugmontUserBank, error) {
 	var userBank models.AugmontUserBank
-	err := r.db.
+	err := conn(ctx, r.db).
 		Where(bank).
 		First(&userBank).
 		Error
@@ -101,9 +105,9 @@ func (r *augmontUserRepo) FindBank(bank *models.AugmontUserBank) (*models.Augmon
 	return &userBank, nil
 }
 
-func (r *augmontUserRepo) FindBanks(bank *models.AugmontUserBank) ([]*models.AugmontUserBank, error) {
+func (r *augmontUserRepo) FindBanks(ctx context.Context, bank *models.AugmontUserBank) ([]*models.AugmontUserBank, error) {
 	var userBanks []*models.AugmontUserBank
-	err := r.db.
+	err := conn(ctx, r.db).
 		Where(bank).
 		Find(&userBanks).
 		Error
@@ -113,34 +117,23 @@ func (r *augmontUserRepo) FindBanks(bank *models.AugmontUserBank) ([]*models.Aug
 	return userBanks, nil
 }
 
-func (r *augmontUserRepo) FindAllBanks() ([]*models.AugmontUserBank, error) {
-	var userBanks []*models.AugmontUserBank
-	err := r.db.
-		First(&userBanks).
-		Error
-	if err != nil {
-		return nil, err
-	}
-	return userBanks, nil
-}
-
 // ---- Augmont User Address ----
 
-func (r *augmontUserRepo) CreateAddress(address *models.AugmontUserAddress) error {
-	return r.db.Create(address).Error
+func (r *augmontUserRepo) CreateAddress(ctx context.Context, address *models.AugmontUserAddress) error {
+	return conn(ctx, r.db).Create(address).Error
 }
 
-func (r *augmontUserRepo) DeleteAddress(address *models.AugmontUserAddress) error {
-	return r.db.
+func (r *augmontUserRepo) DeleteAddress(ctx context.Context, address *models.AugmontUserAddress) error {
+	return conn(ctx, r.db).
 		Where(address).
 		Limit(1).
 		Delete(models.AugmontUserAddress{}).
 		Error
 }
 
-func (r *augmontUserRepo) FindAddress(address *models.AugmontUserAddress) (*models.AugmontUserAddress, error) {
+func (r *augmontUserRepo) FindAddress(ctx context.Context, address *models.AugmontUserAddress) (*models.AugmontUserAddress, error) {
 	var userAddress models.AugmontUserAddress
-	err := r.db.
+	err := conn(ctx, r.db).
 		Where(address).
 		Find(&userAddress).
 		Error
@@ -150,9 +143,9 @@ func (r *augmontUserRepo) FindAddress(address *models.AugmontUserAddress) (*mode
 	return &userAddress, nil
 }
 
-func (r *augmontUserRepo) FindAddresses(address *models.AugmontUserAddress) ([]*models.AugmontUserAddress, error) {
+func (r *augmontUserRepo) FindAddresses(ctx context.Context, address *models.AugmontUserAddress) ([]*models.AugmontUserAddress, error) {
 	var userAddress []*models.AugmontUserAddress
-	err := r.db.
+	err := conn(ctx, r.db).
 		Where(address).
 		Find(&userAddress).
 		Error
@@ -161,14 +154,3 @@ func (r *augmontUserRepo) FindAddresses(address *models.AugmontUserAddress) ([]*
 	}
 	return userAddress, nil
 }
-
-func (r *augmontUserRepo) FindAllAddress() ([]*models.AugmontUserAddress, error) {
-	var userAddress []*models.AugmontUserAddress
-	err := r.db.
-		Find(&userAddress).
-		Error
-	if err != nil {
-		return nil, err
-	}
-	return userAddress, nil
-}
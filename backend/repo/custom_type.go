@@ -2,8 +2,8 @@ package repo
 
 import (
 	"fmt"
-	"log"
 
+	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
@@ -1,9 +1,8 @@
 package repo
 
 import (
-	"log"
-
 	"github.com/go-redis/redis/v8"
+	log "github.com/sirupsen/logrus"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -32,12 +31,22 @@ func NewPgDB() *gorm.DB {
 
 	// Create GORM DB
 	db, err := gorm.Open(postgres.Open(url), &gorm.Config{
-		Logger: logger.Default.LogMode(logLevel),
+		Logger: newGormLogger(logLevel),
 	})
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	// Observe query durations and trace queries
+	err = db.Use(metricsPlugin{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = db.Use(tracingPlugin{})
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// Create Custom Field Types
 	CreateCustomTypes(db)
 
@@ -46,9 +55,10 @@ func NewPgDB() *gorm.DB {
 
 // Provide Redis Client
 func NewRedisClient() *redis.Client {
-	return redis.NewClient(&redis.Options{
+	client := redis.NewClient(&redis.Options{
 		Addr:     domain.Config().Database.RedisUrl,
 		Password: domain.Config().Database.RedisPassword,
 	})
-
+	client.AddHook(redisTracingHook{})
+	return client
 }
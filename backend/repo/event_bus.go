@@ -0,0 +1,234 @@
+package repo
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-redis/redis/v8"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/events"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+const (
+	// Events read at once by a consumer
+	consumeBatch = 20
+	// How long a read waits for new events
+	consumeBlock = 5 * time.Second
+	// Wait after a failed read before trying again
+	consumeRetry = time.Second
+)
+
+type redisEventBus struct {
+	db *redis.Client
+}
+
+// NewRedisEventBus creates an EventBus on a Redis stream, each consumer
+// group of the stream gets every event, events handled are acknowledged
+// and the ones left pending are claimed again by the group
+func NewRedisEventBus(db *redis.Client) interfaces.EventBus {
+	return &redisEventBus{db}
+}
+
+func (b *redisEventBus) Publish(ctx context.Context, evs []*models.OutboxEvent) error {
+	conf := domain.Config().Events
+	_, err := b.db.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, e := range evs {
+			pipe.XAdd(ctx, &redis.XAddArgs{
+				Stream: conf.Stream,
+				MaxLen: conf.MaxLen,
+				Approx: true,
+				Values: map[string]interface{}{
+					"id":        *e.ID,
+					"type":      *e.Type,
+					"payload":   *e.Payload,
+					"createdAt": e.CreatedAt.Format(time.RFC3339Nano),
+				},
+			})
+		}
+		return nil
+	})
+	return err
+}
+
+func (b *redisEventBus) Consume(ctx context.Context, group, consumer string, handler interfaces.EventHandler) error {
+	stream := domain.Config().Events.Stream
+	logger := domain.Logger(ctx).WithFields(log.Fields{"group": group, "consumer": consumer})
+
+	// Groups start from the oldest event still in the stream
+	err := b.db.XGroupCreateMkStream(ctx, stream, group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return errors.Wrap(err, "create consumer group")
+	}
+
+	// Where the next claim of pending events starts, the claims go
+	// through the pending events and start over
+	cursor := "0-0"
+	for ctx.Err() == nil {
+		if err := b.consumeOnce(ctx, stream, group, consumer, &cursor, handler); err != nil && ctx.Err() == nil {
+			logger.WithError(err).Warn("events not read")
+			select {
+			case <-ctx.Done():
+			case <-time.After(consumeRetry):
+			}
+		}
+	}
+	return nil
+}
+
+// consumeOnce handles a batch of the events left pending by the
+// consumers of the group for too long, from the cursor, then the new ones
+func (b *redisEventBus) consumeOnce(
+	ctx context.Context,
+	stream, group, consumer string,
+	cursor *string,
+	handler interfaces.EventHandler,
+) error {
+	claimed, next, err := b.db.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   stream,
+		Group:    group,
+		MinIdle:  domain.Config().Events.ClaimIdle,
+		Start:    *cursor,
+		Count:    consumeBatch,
+		Consumer: consumer,
+	}).Result()
+	if err != nil {
+		return errors.Wrap(err, "claim pending events")
+	}
+	*cursor = next
+	if len(claimed) > 0 {
+		deliveries, err := b.deliveries(ctx, stream, group, consumer, claimed)
+		if err != nil {
+			return err
+		}
+		b.handle(ctx, stream, group, claimed, deliveries, handler)
+	}
+
+	streams, err := b.db.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    group,
+		Consumer: consumer,
+		Streams:  []string{stream, ">"},
+		Count:    consumeBatch,
+		Block:    consumeBlock,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "read events")
+	}
+	for _, s := range streams {
+		b.handle(ctx, stream, group, s.Messages, nil, handler)
+	}
+	return nil
+}
+
+// deliveries returns how many times each claimed message was delivered
+// to the group, the claim included
+func (b *redisEventBus) deliveries(
+	ctx context.Context,
+	stream, group, consumer string,
+	msgs []redis.XMessage,
+) (map[string]int64, error) {
+	pending, err := b.db.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream:   stream,
+		Group:    group,
+		Start:    msgs[0].ID,
+		End:      msgs[len(msgs)-1].ID,
+		Count:    int64(len(msgs)),
+		Consumer: consumer,
+	}).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "read event deliveries")
+	}
+	counts := make(map[string]int64, len(pending))
+	for _, p := range pending {
+		counts[p.ID] = p.RetryCount
+	}
+	return counts, nil
+}
+
+// handle passes the messages to the handler and acknowledges the ones
+// handled, the others stay pending to be claimed again. The ones that
+// can not be decoded, and the ones delivered more than the max times,
+// are moved to the dead stream
+func (b *redisEventBus) handle(
+	ctx context.Context,
+	stream, group string,
+	msgs []redis.XMessage,
+	deliveries map[string]int64,
+	handler interfaces.EventHandler,
+) {
+	maxDeliveries := domain.Config().Events.MaxDeliveries
+	for _, msg := range msgs {
+		logger := domain.Logger(ctx).WithFields(log.Fields{"group": group, "streamID": msg.ID})
+		env, err := decodeMessage(msg)
+		if err == nil && deliveries[msg.ID] > maxDeliveries {
+			err = errors.Newf("not handled in %d deliveries", maxDeliveries)
+		}
+		if err != nil {
+			// Unknown types too, claimed again they would never be handled
+			if err := b.deadLetter(ctx, group, msg, err); err != nil {
+				logger.WithError(err).Warn("event not moved to the dead stream")
+				continue
+			}
+			logger.WithError(err).Error("event moved to the dead stream")
+		} else {
+			logger = logger.WithFields(log.Fields{"eventID": env.ID, "eventType": env.Type})
+			if err := handler(ctx, env); err != nil {
+				logger.WithError(err).Warn("event not handled")
+				continue
+			}
+		}
+		if err := b.db.XAck(ctx, stream, group, msg.ID).Err(); err != nil {
+			// Delivered again once claimed, see interfaces.EventHandler
+			logger.WithError(err).Warn("event not acknowledged")
+		}
+	}
+}
+
+// deadLetter adds the message to the dead stream with the group that
+// could not handle it and why
+func (b *redisEventBus) deadLetter(ctx context.Context, group string, msg redis.XMessage, cause error) error {
+	conf := domain.Config().Events
+	values := make(map[string]interface{}, len(msg.Values)+3)
+	for k, v := range msg.Values {
+		values[k] = v
+	}
+	values["streamID"], values["group"], values["error"] = msg.ID, group, cause.Error()
+	return b.db.XAdd(ctx, &redis.XAddArgs{
+		Stream: conf.DeadStream,
+		MaxLen: conf.MaxLen,
+		Approx: true,
+		Values: values,
+	}).Err()
+}
+
+// decodeMessage returns the event of a stream message
+func decodeMessage(msg redis.XMessage) (*events.Envelope, error) {
+	value := func(key string) string {
+		v, _ := msg.Values[key].(string)
+		return v
+	}
+	id, err := strconv.ParseUint(value("id"), 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse event id")
+	}
+	createdAt, _ := time.Parse(time.RFC3339Nano, value("createdAt"))
+	e, err := events.Decode(value("type"), []byte(value("payload")))
+	if err != nil {
+		return nil, err
+	}
+	return &events.Envelope{
+		ID:        id,
+		Type:      value("type"),
+		CreatedAt: createdAt,
+		Event:     e,
+	}, nil
+}
@@ -0,0 +1,83 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/configtest"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/events"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+// testRedis connects to the redis of TEST_REDIS_URL, host:port like
+// REDIS_URL, the tests are skipped without one
+func testRedis(t *testing.T) *redis.Client {
+	addr := os.Getenv("TEST_REDIS_URL")
+	if addr == "" {
+		t.Skip("TEST_REDIS_URL not set")
+	}
+	rdb := redis.NewClient(&redis.Options{Addr: addr})
+	if err := rdb.Ping(context.Background()).Err(); err != nil {
+		t.Skipf("redis unavailable: %v", err)
+	}
+	t.Cleanup(func() { rdb.Close() })
+	return rdb
+}
+
+func TestRedisEventBus(t *testing.T) {
+	rdb := testRedis(t)
+	configtest.Use(t)
+	conf := &domain.Config().Events
+
+	ctx := context.Background()
+	prefix := fmt.Sprintf("test:events:%d", time.Now().UnixNano())
+	conf.Stream, conf.DeadStream = prefix, prefix+":dead"
+	conf.ClaimIdle, conf.MaxDeliveries = 0, 2
+	t.Cleanup(func() { rdb.Del(ctx, conf.Stream, conf.DeadStream) })
+
+	b := &redisEventBus{rdb}
+	publish := func(id uint64) {
+		typ, payload, now := events.TypeBuyCompleted, fmt.Sprintf(`{"merchantTxnID":"B%d"}`, id), time.Now()
+		err := b.Publish(ctx, []*models.OutboxEvent{{ID: &id, Type: &typ, Payload: &payload, CreatedAt: &now}})
+		assert.NoError(t, err)
+	}
+	group, consumer := "test", "a"
+	assert.NoError(t, rdb.XGroupCreateMkStream(ctx, conf.Stream, group, "0").Err())
+
+	t.Run("should move events failing every delivery to the dead stream", func(t *testing.T) {
+		handled := map[uint64]int{}
+		handler := func(ctx context.Context, env *events.Envelope) error {
+			handled[env.ID]++
+			if env.ID == 1 {
+				return errors.New("failed")
+			}
+			return nil
+		}
+		cursor := "0-0"
+		// An event published before each read, so that reads do not wait
+		for id := uint64(1); id <= 4; id++ {
+			publish(id)
+			assert.NoError(t, b.consumeOnce(ctx, conf.Stream, group, consumer, &cursor, handler))
+		}
+		assert.Equal(t, 2, handled[1])
+		assert.Equal(t, 1, handled[4])
+
+		pending, err := rdb.XPending(ctx, conf.Stream, group).Result()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), pending.Count)
+		dead, err := rdb.XRange(ctx, conf.DeadStream, "-", "+").Result()
+		assert.NoError(t, err)
+		if assert.Len(t, dead, 1) {
+			assert.Equal(t, "1", dead[0].Values["id"])
+			assert.Equal(t, group, dead[0].Values["group"])
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package repo
+
+import (
+	"context"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+// localStorage stores the files on the local disk,
+// downloads are served by the files controller
+type localStorage struct {
+	dir     string
+	baseURL string
+	key     []byte
+}
+
+// NewLocalStorage returns a FileStorage in the STORAGE_DIR directory
+func NewLocalStorage() (interfaces.FileStorage, error) {
+	cfg := domain.Config()
+	key := cfg.SigningKey()
+	if len(key) == 0 {
+		return nil, errors.New("STORAGE_SIGNING_KEY is required outside dev")
+	}
+	if err := os.MkdirAll(cfg.Storage.Dir, 0o750); err != nil {
+		return nil, errors.Wrap(err, "create storage dir")
+	}
+	return &localStorage{
+		dir:     cfg.Storage.Dir,
+		baseURL: strings.TrimSuffix(cfg.Url.BackEndUrl, "/"),
+		key:     key,
+	}, nil
+}
+
+// filePath returns the path of the key, keys leaving the directory are rejected
+func (s *localStorage) filePath(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if key == "" || clean != "/"+key {
+		return "", domain.NewError(errors.Newf("invalid file key %q", key), domain.ErrInvalidArgument)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(clean)), nil
+}
+
+// Put writes the file to a temporary file first,
+// readers never see a partial file
+func (s *localStorage) Put(ctx context.Context, key string, r io.Reader) error {
+	name, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+func (s *localStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	name, err := s.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(name)
+	if os.IsNotExist(err) {
+		return nil, domain.NewError(err, domain.ErrNotFound, "file not found")
+	}
+	return f, err
+}
+
+func (s *localStorage) Delete(ctx context.Context, key string) error {
+	name, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *localStorage) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	if _, err := s.filePath(key); err != nil {
+		return "", err
+	}
+	p := utils.FilesPath + key
+	query := utils.SignPath(s.key, p, time.Now().Add(expiry))
+	return s.baseURL + p + "?" + query.Encode(), nil
+}
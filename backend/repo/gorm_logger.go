@@ -0,0 +1,74 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+)
+
+// Queries slower than this are logged as warnings
+const slowQueryThreshold = 200 * time.Millisecond
+
+// gormLogger writes gorm logs to the request scoped logrus entry,
+// so the queries carry the request id of the request that made them
+type gormLogger struct {
+	level logger.LogLevel
+}
+
+func newGormLogger(level logger.LogLevel) logger.Interface {
+	return &gormLogger{level: level}
+}
+
+func (l *gormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return &gormLogger{level: level}
+}
+
+func (l *gormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Info {
+		domain.Logger(ctx).Infof(msg, args...)
+	}
+}
+
+func (l *gormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Warn {
+		domain.Logger(ctx).Warnf(msg, args...)
+	}
+}
+
+func (l *gormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Error {
+		domain.Logger(ctx).Errorf(msg, args...)
+	}
+}
+
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	fields := func() log.Fields {
+		sql, rows := fc()
+		return log.Fields{
+			"sql":       sql,
+			"rows":      rows,
+			"latencyMs": float64(elapsed.Microseconds()) / 1000,
+		}
+	}
+
+	switch {
+	case err != nil && l.level >= logger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		domain.Logger(ctx).WithFields(fields()).WithError(err).Error("query failed")
+	case elapsed > slowQueryThreshold && l.level >= logger.Warn:
+		domain.Logger(ctx).WithFields(fields()).Warn(fmt.Sprintf("slow query over %v", slowQueryThreshold))
+	case l.level >= logger.Info:
+		domain.Logger(ctx).WithFields(fields()).Info("query")
+	}
+}
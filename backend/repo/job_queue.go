@@ -0,0 +1,238 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-redis/redis/v8"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+// Dead letters are dropped after this long
+const deadJobTTL = 30 * 24 * time.Hour
+
+type redisJobRepo struct {
+	db *redis.Client
+}
+
+// NewRedisJobRepo returns a new JobRepo, each job is a JSON string and
+// each queue a list of ready job IDs with sorted sets of the scheduled
+// and in flight ones by time and a list of dead letters
+func NewRedisJobRepo(db *redis.Client) interfaces.JobRepo {
+	return &redisJobRepo{db}
+}
+
+func jobKey(id string) string {
+	return "jobs:job:" + id
+}
+
+func queueKey(queue, state string) string {
+	return "jobs:" + queue + ":" + state
+}
+
+func millis(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10)
+}
+
+func (r *redisJobRepo) save(ctx context.Context, pipe redis.Pipeliner, job *models.Job, ttl time.Duration) error {
+	b, err := json.Marshal(job)
+	if err != nil {
+		return errors.Wrap(err, "encode job")
+	}
+	pipe.Set(ctx, jobKey(job.ID), b, ttl)
+	return nil
+}
+
+func (r *redisJobRepo) Enqueue(ctx context.Context, job *models.Job) error {
+	_, err := r.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if err := r.save(ctx, pipe, job, 0); err != nil {
+			return err
+		}
+		if job.RunAt.After(time.Now()) {
+			pipe.ZAdd(ctx, queueKey(job.Queue, "scheduled"), &redis.Z{
+				Score:  float64(job.RunAt.UnixNano() / int64(time.Millisecond)),
+				Member: job.ID,
+			})
+			return nil
+		}
+		pipe.LPush(ctx, queueKey(job.Queue, "ready"), job.ID)
+		return nil
+	})
+	return err
+}
+
+// dequeueScript readies the due scheduled jobs and the in flight ones
+// whose lease ended, then takes the oldest ready job in flight
+var dequeueScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+for _, key in ipairs({KEYS[2], KEYS[3]}) do
+	local ids = redis.call('ZRANGEBYSCORE', key, '-inf', now, 'LIMIT', 0, 100)
+	for _, id in ipairs(ids) do
+		redis.call('ZREM', key, id)
+		redis.call('LPUSH', KEYS[1], id)
+	end
+end
+local id = redis.call('RPOP', KEYS[1])
+if not id then
+	return false
+end
+redis.call('ZADD', KEYS[3], now + tonumber(ARGV[2]), id)
+return id
+`)
+
+func (r *redisJobRepo) Dequeue(ctx context.Context, queue string, lease time.Duration) (*models.Job, error) {
+	for {
+		id, err := dequeueScript.Run(ctx, r.db,
+			[]string{queueKey(queue, "ready"), queueKey(queue, "scheduled"), queueKey(queue, "inflight")},
+			millis(time.Now()), lease.Milliseconds(),
+		).Text()
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := r.db.Get(ctx, jobKey(id)).Bytes()
+		if errors.Is(err, redis.Nil) {
+			// Completed by a worker whose lease had ended
+			r.db.ZRem(ctx, queueKey(queue, "inflight"), id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		job := &models.Job{}
+		if err := json.Unmarshal(b, job); err != nil {
+			// Ready again once the lease ends it would never be run
+			err = errors.Wrapf(err, "decode job %v", id)
+			if err := r.buryRaw(ctx, queue, id); err != nil {
+				return nil, err
+			}
+			domain.Logger(ctx).WithError(err).WithField("jobID", id).Error("job dead")
+			continue
+		}
+
+		job.Attempts++
+		_, err = r.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			return r.save(ctx, pipe, job, 0)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return job, nil
+	}
+}
+
+func (r *redisJobRepo) Complete(ctx context.Context, job *models.Job) error {
+	_, err := r.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, queueKey(job.Queue, "inflight"), job.ID)
+		pipe.Del(ctx, jobKey(job.ID))
+		return nil
+	})
+	return err
+}
+
+func (r *redisJobRepo) Retry(ctx context.Context, job *models.Job) error {
+	_, err := r.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if err := r.save(ctx, pipe, job, 0); err != nil {
+			return err
+		}
+		pipe.ZRem(ctx, queueKey(job.Queue, "inflight"), job.ID)
+		pipe.ZAdd(ctx, queueKey(job.Queue, "scheduled"), &redis.Z{
+			Score:  float64(job.RunAt.UnixNano() / int64(time.Millisecond)),
+			Member: job.ID,
+		})
+		return nil
+	})
+	return err
+}
+
+func (r *redisJobRepo) Bury(ctx context.Context, job *models.Job) error {
+	_, err := r.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if err := r.save(ctx, pipe, job, deadJobTTL); err != nil {
+			return err
+		}
+		pipe.ZRem(ctx, queueKey(job.Queue, "inflight"), job.ID)
+		pipe.LPush(ctx, queueKey(job.Queue, "dead"), job.ID)
+		pipe.LTrim(ctx, queueKey(job.Queue, "dead"), 0, 9999)
+		return nil
+	})
+	return err
+}
+
+// buryRaw moves the job in flight to the dead letters as it is saved,
+// for jobs that can not be decoded
+func (r *redisJobRepo) buryRaw(ctx context.Context, queue, id string) error {
+	_, err := r.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Expire(ctx, jobKey(id), deadJobTTL)
+		pipe.ZRem(ctx, queueKey(queue, "inflight"), id)
+		pipe.LPush(ctx, queueKey(queue, "dead"), id)
+		pipe.LTrim(ctx, queueKey(queue, "dead"), 0, 9999)
+		return nil
+	})
+	return err
+}
+
+func (r *redisJobRepo) ListDead(ctx context.Context, queue string, limit int) ([]*models.Job, error) {
+	ids, err := r.db.LRange(ctx, queueKey(queue, "dead"), 0, int64(limit-1)).Result()
+	if err != nil || len(ids) == 0 {
+		return []*models.Job{}, err
+	}
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = jobKey(id)
+	}
+	values, err := r.db.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	dead := make([]*models.Job, 0, len(values))
+	for i, v := range values {
+		// Dead letters past their TTL are gone
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		job := &models.Job{}
+		if err := json.Unmarshal([]byte(s), job); err != nil {
+			// Listed with why, to be dropped by hand
+			job = &models.Job{ID: ids[i], Queue: queue, LastError: errors.Wrap(err, "decode job").Error()}
+		}
+		dead = append(dead, job)
+	}
+	return dead, nil
+}
+
+func (r *redisJobRepo) Requeue(ctx context.Context, queue, id string) error {
+	b, err := r.db.Get(ctx, jobKey(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return domain.NewError(errors.Newf("job %v not found", id), domain.ErrNotFound, "job not found")
+	}
+	if err != nil {
+		return err
+	}
+	job := &models.Job{}
+	if err := json.Unmarshal(b, job); err != nil {
+		return errors.Wrapf(err, "decode job %v", id)
+	}
+
+	removed, err := r.db.LRem(ctx, queueKey(queue, "dead"), 1, id).Result()
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		return domain.NewError(errors.Newf("job %v is not dead in %v", id, queue), domain.ErrNotFound, "job not found")
+	}
+	job.Attempts = 0
+	job.LastError = ""
+	job.RunAt = time.Now()
+	return r.Enqueue(ctx, job)
+}
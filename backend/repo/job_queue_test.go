@@ -0,0 +1,46 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+func TestRedisJobRepo(t *testing.T) {
+	rdb := testRedis(t)
+	ctx := context.Background()
+	r := &redisJobRepo{rdb}
+	queue := fmt.Sprintf("test%d", time.Now().UnixNano())
+	t.Cleanup(func() {
+		keys, _ := rdb.Keys(ctx, "jobs:"+queue+":*").Result()
+		rdb.Del(ctx, append(keys, jobKey(queue+"-ok"), jobKey(queue+"-bad"))...)
+	})
+
+	t.Run("should bury the jobs that can not be decoded", func(t *testing.T) {
+		assert.NoError(t, rdb.Set(ctx, jobKey(queue+"-bad"), "{not json", 0).Err())
+		assert.NoError(t, rdb.LPush(ctx, queueKey(queue, "ready"), queue+"-bad").Err())
+		ok := &models.Job{ID: queue + "-ok", Type: "test", Queue: queue, MaxAttempts: 1, RunAt: time.Now()}
+		assert.NoError(t, r.Enqueue(ctx, ok))
+
+		job, err := r.Dequeue(ctx, queue, time.Minute)
+		assert.NoError(t, err)
+		if assert.NotNil(t, job) {
+			assert.Equal(t, ok.ID, job.ID)
+		}
+		inflight, err := rdb.ZRange(ctx, queueKey(queue, "inflight"), 0, -1).Result()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{ok.ID}, inflight)
+
+		dead, err := r.ListDead(ctx, queue, 10)
+		assert.NoError(t, err)
+		if assert.Len(t, dead, 1) {
+			assert.Equal(t, queue+"-bad", dead[0].ID)
+			assert.Contains(t, dead[0].LastError, "decode job")
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/go-redis/redis/v8"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+)
+
+// Key of the scheduler lease, holding the holder until it expires
+const leaderKey = "scheduler:leader"
+
+type redisLeaderLease struct {
+	db *redis.Client
+}
+
+// NewRedisLeaderLease creates a LeaderLease on a Redis key expiring
+// with the lease
+func NewRedisLeaderLease(db *redis.Client) interfaces.LeaderLease {
+	return &redisLeaderLease{db}
+}
+
+// acquireScript sets the free key to the holder or extends the one of
+// the holder
+var acquireScript = redis.NewScript(`
+local holder = redis.call('GET', KEYS[1])
+if holder == false then
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+	return 1
+end
+if holder == ARGV[1] then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+	return 1
+end
+return 0
+`)
+
+// releaseScript deletes the key if it is the holder's
+var releaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+func (l *redisLeaderLease) Acquire(ctx context.Context, holder string, ttl time.Duration) (bool, error) {
+	held, err := acquireScript.Run(ctx, l.db, []string{leaderKey}, holder, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, errors.Wrap(err, "acquire lease")
+	}
+	return held == 1, nil
+}
+
+func (l *redisLeaderLease) Release(ctx context.Context, holder string) error {
+	return errors.Wrap(releaseScript.Run(ctx, l.db, []string{leaderKey}, holder).Err(), "release lease")
+}
+
+func (l *redisLeaderLease) Holder(ctx context.Context) (string, error) {
+	holder, err := l.db.Get(ctx, leaderKey).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	return holder, err
+}
@@ -0,0 +1,89 @@
+package repo
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+// Models owned by the user repo
+var userModels = []interface{}{
+	&models.User{},
+}
+
+// Models owned by the augmont repos, in creation order
+var augmontModels = []interface{}{
+	&models.AugmontUser{},
+
+	&models.AugmontUserBank{},
+	&models.AugmontUserAddress{},
+
+	&models.AugmontBuyOrder{},
+	&models.AugmontSellOrder{},
+	&models.AugmontRedeemOrder{},
+	&models.AugmontRedeemMetal{},
+	&models.AugmontShipmentEvent{},
+
+	&models.AugmontStatement{},
+	&models.AugmontInvoice{},
+	&models.AugmontProduct{},
+
+	&models.AugmontGift{},
+	&models.AugmontLedgerEntry{},
+}
+
+// Models owned by the notification repo
+var notificationModels = []interface{}{
+	&models.NotificationPreference{},
+	&models.Notification{},
+}
+
+// Models owned by the outbox repo
+var outboxModels = []interface{}{
+	&models.OutboxEvent{},
+}
+
+// Models owned by the schedule repo
+var scheduleModels = []interface{}{
+	&models.Schedule{},
+	&models.ScheduleRun{},
+}
+
+// allModels returns every model migrated by the repos
+func allModels() []interface{} {
+	var all []interface{}
+	all = append(all, userModels...)
+	all = append(all, augmontModels...)
+	all = append(all, notificationModels...)
+	all = append(all, outboxModels...)
+	all = append(all, scheduleModels...)
+	return all
+}
+
+// Migrate creates the custom types and migrates all the tables
+func Migrate(db *gorm.DB) error {
+	CreateCustomTypes(db)
+	return db.AutoMigrate(allModels()...)
+}
+
+// TableStatus is the migration state of a single model table
+type TableStatus struct {
+	Table  string `json:"table"`
+	Exists bool   `json:"exists"`
+}
+
+// MigrationStatus reports whether the table of each model exists
+func MigrationStatus(db *gorm.DB) ([]*TableStatus, error) {
+	var status []*TableStatus
+	for _, model := range allModels() {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, err
+		}
+		status = append(status, &TableStatus{
+			Table:  stmt.Schema.Table,
+			Exists: db.Migrator().HasTable(model),
+		})
+	}
+	return status, nil
+}
@@ -0,0 +1,128 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+type notificationRepo struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepo creates a new NotificationRepo
+func NewNotificationRepo(db *gorm.DB) interfaces.NotificationRepo {
+	// Migrate Notification Models
+	db.AutoMigrate(notificationModels...)
+
+	return &notificationRepo{
+		db: db,
+	}
+}
+
+func (r *notificationRepo) FindPreference(ctx context.Context, userID uint64) (*models.NotificationPreference, error) {
+	var prefs []*models.NotificationPreference
+	err := conn(ctx, r.db).
+		Where("user_id = ?", userID).
+		Limit(1).
+		Find(&prefs).
+		Error
+	if err != nil || len(prefs) == 0 {
+		return nil, err
+	}
+	return prefs[0], nil
+}
+
+func (r *notificationRepo) SavePreference(ctx context.Context, pref *models.NotificationPreference) error {
+	return conn(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"updated_at", "sms", "email", "push",
+				"email_address", "push_token", "quiet_start", "quiet_end",
+			}),
+		}).
+		Create(pref).
+		Error
+}
+
+func (r *notificationRepo) Create(ctx context.Context, notifications []*models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	// One at a time, the ids of a batch with skipped rows would not match
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, n := range notifications {
+			err := tx.
+				Clauses(clause.OnConflict{
+					Columns:   []clause.Column{{Name: "event_id"}, {Name: "channel"}},
+					DoNothing: true,
+				}).
+				Create(n).
+				Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *notificationRepo) Update(ctx context.Context, n *models.Notification) error {
+	return conn(ctx, r.db).
+		Model(n).
+		Select("status", "attempts", "last_error", "send_after", "sent_at").
+		Updates(n).
+		Error
+}
+
+func (r *notificationRepo) ClaimDue(ctx context.Context, now, stale time.Time, limit int) ([]*models.Notification, error) {
+	var notifications []*models.Notification
+	err := conn(ctx, r.db).Raw(`
+		UPDATE notifications SET status = ?, updated_at = now()
+		WHERE id IN (
+			SELECT id FROM notifications
+			WHERE (status = ? AND send_after <= ?) OR (status = ? AND updated_at < ?)
+			ORDER BY send_after, id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		models.NotificationSending,
+		models.NotificationPending, now, models.NotificationSending, stale,
+		limit,
+	).Scan(&notifications).Error
+	if err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// notificationColumns are the notification fields clients may sort and filter on
+var notificationColumns = queryColumns{
+	"createdAt": "notifications.created_at",
+	"status":    "notifications.status",
+	"channel":   "notifications.channel",
+	"name":      "notifications.name",
+}
+
+func (r *notificationRepo) List(
+	ctx context.Context,
+	userID uint64,
+	q *utils.ListQuery,
+) ([]*models.Notification, *utils.Page, error) {
+	var notifications []*models.Notification
+	db := conn(ctx, r.db).Model(&models.Notification{}).
+		Where("notifications.user_id = ?", userID)
+	page, err := paginate(ctx, db, q, notificationColumns, "notifications", &notifications)
+	if err != nil {
+		return nil, nil, err
+	}
+	return notifications, page, nil
+}
@@ -0,0 +1,83 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/events"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+type outboxRepo struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepo creates a new OutboxRepo
+func NewOutboxRepo(db *gorm.DB) interfaces.OutboxRepo {
+	// Migrate Outbox Models
+	db.AutoMigrate(outboxModels...)
+
+	return &outboxRepo{
+		db: db,
+	}
+}
+
+func (r *outboxRepo) Add(ctx context.Context, evs ...events.Event) error {
+	if len(evs) == 0 {
+		return nil
+	}
+	rows := make([]*models.OutboxEvent, 0, len(evs))
+	for _, e := range evs {
+		payload, err := events.Encode(e)
+		if err != nil {
+			return err
+		}
+		eventType, p := e.EventType(), string(payload)
+		rows = append(rows, &models.OutboxEvent{
+			Type:    &eventType,
+			Payload: &p,
+		})
+	}
+	return conn(ctx, r.db).Create(&rows).Error
+}
+
+func (r *outboxRepo) Relay(ctx context.Context, limit int, publish func(events []*models.OutboxEvent) error) (int, error) {
+	relayed := 0
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var rows []*models.OutboxEvent
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("published_at IS NULL").
+			Order("id").
+			Limit(limit).
+			Find(&rows).
+			Error
+		if err != nil || len(rows) == 0 {
+			return err
+		}
+		if err := publish(rows); err != nil {
+			return err
+		}
+
+		ids := make([]uint64, 0, len(rows))
+		for _, row := range rows {
+			ids = append(ids, *row.ID)
+		}
+		err = tx.Model(&models.OutboxEvent{}).
+			Where("id IN ?", ids).
+			Update("published_at", time.Now()).
+			Error
+		if err != nil {
+			return err
+		}
+		relayed = len(rows)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return relayed, nil
+}
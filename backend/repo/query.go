@@ -0,0 +1,152 @@
+package repo
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/i18n"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+// queryColumns maps the API field names that may be sorted
+// and filtered on to their columns
+type queryColumns map[string]string
+
+// filterScope applies the filters of the query, unknown fields
+// and operators are rejected by paginate before
+func filterScope(q *utils.ListQuery, columns queryColumns) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, f := range q.Filters {
+			column := columns[f.Field]
+			switch f.Op {
+			case utils.FilterEq:
+				db = db.Where(column+" = ?", f.Value)
+			case utils.FilterPrefix:
+				db = db.Where(column+" LIKE ? ESCAPE '\\'", escapeLike(f.Value)+"%")
+			case utils.FilterGte:
+				db = db.Where(column+" >= ?", f.Value)
+			case utils.FilterLte:
+				db = db.Where(column+" <= ?", f.Value)
+			case utils.FilterNull:
+				db = db.Where(column + " IS NULL")
+			}
+		}
+		return db
+	}
+}
+
+// pageScope applies the sort, the offset and the limit of the query,
+// rows are ordered by id last to keep pages stable
+func pageScope(q *utils.ListQuery, columns queryColumns, idColumn string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		fields, desc := q.SortFields()
+		if len(fields) == 0 {
+			// Newest first
+			return db.Order(clause.OrderByColumn{
+				Column: clause.Column{Name: idColumn, Raw: true},
+				Desc:   true,
+			}).Offset(q.Offset).Limit(q.Limit)
+		}
+		for i, field := range fields {
+			db = db.Order(clause.OrderByColumn{
+				Column: clause.Column{Name: columns[field], Raw: true},
+				Desc:   desc[i],
+			})
+		}
+		return db.Order(idColumn).Offset(q.Offset).Limit(q.Limit)
+	}
+}
+
+// paginate finds the page of rows of the table in dest, the
+// total counts all rows matching the filters
+func paginate(
+	ctx context.Context,
+	db *gorm.DB,
+	q *utils.ListQuery,
+	columns queryColumns,
+	table string,
+	dest interface{},
+) (*utils.Page, error) {
+	return paginateBy(ctx, db, q, columns, table, table+".id", dest)
+}
+
+// paginateBy is paginate for tables where id is not unique,
+// the key column orders the rows last
+func paginateBy(
+	ctx context.Context,
+	db *gorm.DB,
+	q *utils.ListQuery,
+	columns queryColumns,
+	table string,
+	keyColumn string,
+	dest interface{},
+) (*utils.Page, error) {
+	if err := validateQuery(q, columns); err != nil {
+		return nil, err
+	}
+	db = db.WithContext(ctx).Scopes(filterScope(q, columns))
+
+	var total int64
+	if err := db.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+	// Only the rows of the table, filters may join others
+	result := db.Session(&gorm.Session{}).
+		Select(table + ".*").
+		Scopes(pageScope(q, columns, keyColumn)).
+		Find(dest)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return q.NewPage(total, int(result.RowsAffected)), nil
+}
+
+// validateQuery rejects sort and filter fields without a column
+func validateQuery(q *utils.ListQuery, columns queryColumns) error {
+	fields, _ := q.SortFields()
+	for _, field := range fields {
+		if _, ok := columns[field]; !ok {
+			return invalidQueryField("sort", field, columns)
+		}
+	}
+	for _, f := range q.Filters {
+		if _, ok := columns[f.Field]; !ok {
+			return invalidQueryField(f.Field, f.Field, columns)
+		}
+	}
+	return nil
+}
+
+func invalidQueryField(param, field string, columns queryColumns) error {
+	allowed := make([]string, 0, len(columns))
+	for name := range columns {
+		allowed = append(allowed, name)
+	}
+	sort.Strings(allowed)
+	args := map[string]string{
+		"field": param,
+		"param": strings.Join(allowed, " "),
+	}
+
+	err := errors.Newf("unknown query field %q", field)
+	e, _ := domain.AsError(domain.NewError(err, domain.ErrInvalidArgument))
+	return e.WithCode("validation_failed", "Please correct the highlighted fields").
+		WithFields(domain.FieldError{
+			Field:   param,
+			Code:    "oneof",
+			Param:   args["param"],
+			Message: i18n.Translate(i18n.English, "validation.oneof", "invalid "+param, args),
+		})
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern
+func escapeLike(v interface{}) string {
+	s, _ := v.(string)
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
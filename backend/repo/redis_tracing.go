@@ -0,0 +1,66 @@
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/tracing"
+)
+
+// redisTracingHook creates a client span for every redis command
+type redisTracingHook struct{}
+
+var _ redis.Hook = redisTracingHook{}
+
+func (redisTracingHook) start(ctx context.Context, name string, attrs ...attribute.KeyValue) context.Context {
+	ctx, _ = tracing.Tracer(tracerName).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemRedis),
+		trace.WithAttributes(attrs...),
+	)
+	return ctx
+}
+
+func (redisTracingHook) end(ctx context.Context, err error) {
+	span := trace.SpanFromContext(ctx)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (h redisTracingHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	return h.start(ctx, "redis."+cmd.Name(),
+		semconv.DBOperationKey.String(cmd.Name()),
+	), nil
+}
+
+func (h redisTracingHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	h.end(ctx, cmd.Err())
+	return nil
+}
+
+func (h redisTracingHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	return h.start(ctx, "redis.pipeline",
+		attribute.Int("db.redis.num_cmd", len(cmds)),
+	), nil
+}
+
+func (h redisTracingHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	var err error
+	for _, cmd := range cmds {
+		if cmdErr := cmd.Err(); cmdErr != nil && !errors.Is(cmdErr, redis.Nil) {
+			err = cmdErr
+			break
+		}
+	}
+	h.end(ctx, err)
+	return nil
+}
@@ -0,0 +1,163 @@
+package repo
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+type scheduleRepo struct {
+	db *gorm.DB
+}
+
+// NewScheduleRepo creates a new ScheduleRepo
+func NewScheduleRepo(db *gorm.DB) interfaces.ScheduleRepo {
+	// Migrate Schedule Models
+	db.AutoMigrate(scheduleModels...)
+
+	return &scheduleRepo{
+		db: db,
+	}
+}
+
+func (r *scheduleRepo) List(ctx context.Context) ([]*models.Schedule, error) {
+	var schedules []*models.Schedule
+	err := conn(ctx, r.db).Order("name").Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (r *scheduleRepo) Save(ctx context.Context, s *models.Schedule) error {
+	return conn(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"updated_at", "spec", "next_run_at"}),
+		}).
+		Create(s).
+		Error
+}
+
+// saveLastRun saves the last run fields of the schedule
+func saveLastRun(tx *gorm.DB, s *models.Schedule) error {
+	return tx.Model(s).
+		Select("last_run_at", "last_outcome", "last_error", "next_run_at").
+		Updates(s).
+		Error
+}
+
+func (r *scheduleRepo) StartRun(ctx context.Context, s *models.Schedule, run *models.ScheduleRun) (bool, error) {
+	started := false
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Starts of the task wait for each other on the schedule
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("name = ?", s.Name).
+			Take(&models.Schedule{}).
+			Error
+		if err != nil {
+			return err
+		}
+		if err := interruptRuns(tx, *s.Name); err != nil {
+			return err
+		}
+		var running int64
+		err = tx.Model(&models.ScheduleRun{}).
+			Where("name = ? AND outcome = ?", s.Name, models.ScheduleRunning).
+			Count(&running).
+			Error
+		if err != nil || running > 0 {
+			return err
+		}
+
+		if err := tx.Create(run).Error; err != nil {
+			return err
+		}
+		if err := saveLastRun(tx, s); err != nil {
+			return err
+		}
+		started = true
+		return nil
+	})
+	return started && err == nil, err
+}
+
+func (r *scheduleRepo) RenewRun(ctx context.Context, run *models.ScheduleRun) error {
+	return conn(ctx, r.db).
+		Model(run).
+		Update("lease_until", run.LeaseUntil).
+		Error
+}
+
+func (r *scheduleRepo) FinishRun(ctx context.Context, s *models.Schedule, run *models.ScheduleRun) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(run).
+			Select("finished_at", "outcome", "error").
+			Updates(run).
+			Error
+		if err != nil {
+			return err
+		}
+		return saveLastRun(tx, s)
+	})
+}
+
+func (r *scheduleRepo) InterruptRuns(ctx context.Context) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return interruptRuns(tx, "")
+	})
+}
+
+// interruptRuns fails the runs of the task, or of every task when name
+// is empty, that are running past their lease, their replica stopped
+func interruptRuns(tx *gorm.DB, name string) error {
+	runs := tx.Model(&models.ScheduleRun{}).
+		Where("outcome = ? AND (lease_until IS NULL OR lease_until < now())", models.ScheduleRunning)
+	schedules := tx.Model(&models.Schedule{}).
+		Where("last_outcome = ?", models.ScheduleRunning).
+		Where("NOT EXISTS (SELECT 1 FROM schedule_runs r WHERE r.name = schedules.name AND r.outcome = ?)",
+			models.ScheduleRunning)
+	if name != "" {
+		runs = runs.Where("name = ?", name)
+		schedules = schedules.Where("name = ?", name)
+	}
+
+	err := runs.
+		Updates(map[string]interface{}{
+			"finished_at": gorm.Expr("now()"),
+			"outcome":     models.ScheduleFailed,
+			"error":       "interrupted",
+		}).
+		Error
+	if err != nil {
+		return err
+	}
+	return schedules.
+		Updates(map[string]interface{}{
+			"last_outcome": models.ScheduleFailed,
+			"last_error":   "interrupted",
+		}).
+		Error
+}
+
+// scheduleRunColumns are the run fields clients may sort and filter on
+var scheduleRunColumns = queryColumns{
+	"name":      "schedule_runs.name",
+	"startedAt": "schedule_runs.started_at",
+	"outcome":   "schedule_runs.outcome",
+}
+
+func (r *scheduleRepo) ListRuns(ctx context.Context, q *utils.ListQuery) ([]*models.ScheduleRun, *utils.Page, error) {
+	var runs []*models.ScheduleRun
+	db := conn(ctx, r.db).Model(&models.ScheduleRun{})
+	page, err := paginate(ctx, db, q, scheduleRunColumns, "schedule_runs", &runs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return runs, page, nil
+}
@@ -0,0 +1,86 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+func TestScheduleRepo(t *testing.T) {
+	db := testDB(t)
+	if err := db.AutoMigrate(scheduleModels...); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	r := &scheduleRepo{db}
+
+	schedule := func(t *testing.T) *models.Schedule {
+		name := fmt.Sprintf("task-%d", time.Now().UnixNano())
+		spec := "@hourly"
+		t.Cleanup(func() {
+			db.Where("name = ?", name).Delete(&models.ScheduleRun{})
+			db.Where("name = ?", name).Delete(&models.Schedule{})
+		})
+		s := &models.Schedule{Name: &name, Spec: &spec}
+		assert.NoError(t, r.Save(ctx, s))
+		return s
+	}
+	run := func(s *models.Schedule, lease time.Duration) *models.ScheduleRun {
+		now, host, outcome := time.Now(), "a", models.ScheduleRunning
+		until := now.Add(lease)
+		s.LastRunAt, s.LastOutcome = &now, &outcome
+		return &models.ScheduleRun{Name: s.Name, Host: &host, StartedAt: &now, Outcome: &outcome, LeaseUntil: &until}
+	}
+	outcome := func(t *testing.T, run *models.ScheduleRun) string {
+		var saved models.ScheduleRun
+		assert.NoError(t, db.First(&saved, run.ID).Error)
+		return *saved.Outcome
+	}
+
+	t.Run("should not start a task twice at once", func(t *testing.T) {
+		s := schedule(t)
+		started, err := r.StartRun(ctx, s, run(s, time.Minute))
+		assert.NoError(t, err)
+		assert.True(t, started)
+
+		started, err = r.StartRun(ctx, s, run(s, time.Minute))
+		assert.NoError(t, err)
+		assert.False(t, started)
+	})
+
+	t.Run("should start a task whose run is past its lease", func(t *testing.T) {
+		s := schedule(t)
+		stale := run(s, -time.Second)
+		_, err := r.StartRun(ctx, s, stale)
+		assert.NoError(t, err)
+
+		started, err := r.StartRun(ctx, s, run(s, time.Minute))
+		assert.NoError(t, err)
+		assert.True(t, started)
+		assert.Equal(t, models.ScheduleFailed, outcome(t, stale))
+	})
+
+	t.Run("should only interrupt the runs past their lease", func(t *testing.T) {
+		live, stale := schedule(t), schedule(t)
+		liveRun, staleRun := run(live, time.Minute), run(stale, -time.Second)
+		_, err := r.StartRun(ctx, live, liveRun)
+		assert.NoError(t, err)
+		_, err = r.StartRun(ctx, stale, staleRun)
+		assert.NoError(t, err)
+
+		assert.NoError(t, r.InterruptRuns(ctx))
+		assert.Equal(t, models.ScheduleRunning, outcome(t, liveRun))
+		assert.Equal(t, models.ScheduleFailed, outcome(t, staleRun))
+
+		expired := time.Now().Add(-time.Second)
+		liveRun.LeaseUntil = &expired
+		assert.NoError(t, r.RenewRun(ctx, liveRun))
+		assert.NoError(t, r.InterruptRuns(ctx))
+		assert.Equal(t, models.ScheduleFailed, outcome(t, liveRun))
+	})
+}
@@ -0,0 +1,39 @@
+package repo
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+)
+
+// txKey is the context key of the transaction of a unit of work
+type txKey struct{}
+
+// conn returns the transaction of the unit of work running in ctx, or
+// db outside of one, the postgres repos run every query on it
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+type unitOfWork struct {
+	db *gorm.DB
+}
+
+// NewUnitOfWork creates a UnitOfWork on the postgres database
+func NewUnitOfWork(db *gorm.DB) interfaces.UnitOfWork {
+	return &unitOfWork{
+		db: db,
+	}
+}
+
+func (u *unitOfWork) Do(ctx context.Context, work func(ctx context.Context) error) error {
+	// Nested in a transaction, gorm runs it in a savepoint
+	return conn(ctx, u.db).Transaction(func(tx *gorm.DB) error {
+		return work(context.WithValue(ctx, txKey{}, tx))
+	})
+}
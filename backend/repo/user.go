@@ -1,10 +1,13 @@
 package repo
 
 import (
+	"context"
+
 	"gorm.io/gorm"
 
 	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
 	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
 )
 
 type userRepo struct {
@@ -14,32 +17,32 @@ type userRepo struct {
 // NewUserRepo creates a new UserRepo
 func NewUserRepo(db *gorm.DB) interfaces.UserRepo {
 	// Migrate User Model
-	db.AutoMigrate(&models.User{})
+	db.AutoMigrate(userModels...)
 
 	return &userRepo{
 		db: db,
 	}
 }
 
-func (r *userRepo) Create(user *models.User) error {
-	return r.db.Create(user).Error
+func (r *userRepo) Create(ctx context.Context, user *models.User) error {
+	return conn(ctx, r.db).Create(user).Error
 }
 
-func (r *userRepo) Update(user *models.User) error {
-	return r.db.
+func (r *userRepo) Update(ctx context.Context, user *models.User) error {
+	return conn(ctx, r.db).
 		Where(models.User{
 			ID: user.ID,
 		}).
 		Updates(user).Error
 }
 
-func (r *userRepo) Delete(user *models.User) error {
-	return r.db.Where(user).Delete(&models.User{}).Error
+func (r *userRepo) Delete(ctx context.Context, user *models.User) error {
+	return conn(ctx, r.db).Where(user).Delete(&models.User{}).Error
 }
 
-func (r *userRepo) FindOne(user *models.User) (*models.User, error) {
+func (r *userRepo) FindOne(ctx context.Context, user *models.User) (*models.User, error) {
 	var u models.User
-	err := r.db.Where(user).First(&u).Error
+	err := conn(ctx, r.db).Where(user).First(&u).Error
 	if err != nil {
 		return nil, err
 	}
@@ -47,18 +50,18 @@ func (r *userRepo) FindOne(user *models.User) (*models.User, error) {
 
 }
 
-func (r *userRepo) FindMany(user *models.User) ([]*models.User, error) {
+func (r *userRepo) FindMany(ctx context.Context, user *models.User) ([]*models.User, error) {
 	var (
 		users []*models.User
 		err   error
 	)
 
 	if user != nil {
-		err = r.db.
+		err = conn(ctx, r.db).
 			Where(user).
 			Find(&users).Error
 	} else {
-		err = r.db.
+		err = conn(ctx, r.db).
 			Find(&users).Error
 	}
 
@@ -67,3 +70,23 @@ func (r *userRepo) FindMany(user *models.User) ([]*models.User, error) {
 	}
 	return users, nil
 }
+
+// userColumns are the sortable and filterable user fields
+var userColumns = queryColumns{
+	"id":        "users.id",
+	"createdAt": "users.created_at",
+	"name":      "users.name",
+	"mobile":    "users.mobile",
+	"kycStatus": "augmont_users.kyc_status",
+}
+
+func (r *userRepo) List(ctx context.Context, q *utils.ListQuery) ([]*models.User, *utils.Page, error) {
+	var users []*models.User
+	db := conn(ctx, r.db).Model(&models.User{}).
+		Joins("LEFT JOIN augmont_users ON augmont_users.user_id = users.id")
+	page, err := paginate(ctx, db, q, userColumns, "users", &users)
+	if err != nil {
+		return nil, nil, err
+	}
+	return users, page, nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+)
+
+// runServer serves the router until SIGINT or SIGTERM,
+// then drains in-flight requests, stops the background workers
+// and closes the database and redis connections, in that order.
+// The workers are stopped and the connections closed as well if the
+// server fails
+func runServer(
+	router *gin.Engine,
+	lifecycle *domain.Lifecycle,
+	db *gorm.DB,
+	rdb *redis.Client,
+) error {
+	cfg := domain.Config().Server
+	srv := &http.Server{
+		Addr:         net.JoinHostPort(cfg.Host, cfg.Port),
+		Handler:      router,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+	}
+
+	// Serve in background, ListenAndServe only returns
+	// ErrServerClosed after Shutdown is called
+	serveErr := make(chan error, 1)
+	go func() {
+		log.WithField("addr", srv.Addr).Info("server started")
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- err
+		}
+		close(serveErr)
+	}()
+
+	// Wait for a stop signal or a failure to serve,
+	// the workers are stopped either way
+	var errs error
+	serving := true
+	select {
+	case <-stopSignal():
+	case err := <-serveErr:
+		errs = errors.Wrap(err, "server failed")
+		serving = false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	// Stop accepting requests and wait for in-flight ones
+	if serving {
+		if err := srv.Shutdown(ctx); err != nil {
+			errs = errors.CombineErrors(errs, errors.Wrap(err, "server shutdown"))
+		}
+	}
+	errs = errors.CombineErrors(errs, shutdown(ctx, lifecycle, db, rdb))
+
+	if errs == nil {
+		log.Info("server stopped")
+	}
+	return errs
+}
+
+// runWorker runs the background workers until SIGINT or SIGTERM,
+// then stops them and closes the database and redis connections
+func runWorker(
+	lifecycle *domain.Lifecycle,
+	db *gorm.DB,
+	rdb *redis.Client,
+) error {
+	log.Info("worker started")
+	<-stopSignal()
+
+	ctx, cancel := context.WithTimeout(context.Background(), domain.Config().Server.ShutdownTimeout)
+	defer cancel()
+	if err := shutdown(ctx, lifecycle, db, rdb); err != nil {
+		return err
+	}
+	log.Info("worker stopped")
+	return nil
+}
+
+// stopSignal is closed on the first SIGINT or SIGTERM
+func stopSignal() <-chan struct{} {
+	stop := make(chan struct{})
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-quit
+		signal.Stop(quit)
+		log.WithField("signal", sig.String()).Info("shutting down")
+		close(stop)
+	}()
+	return stop
+}
+
+// shutdown stops the background workers before closing their connections
+func shutdown(ctx context.Context, lifecycle *domain.Lifecycle, db *gorm.DB, rdb *redis.Client) error {
+	var errs error
+	if err := lifecycle.Stop(ctx); err != nil {
+		errs = errors.CombineErrors(errs, err)
+	}
+
+	// Close the connections
+	if sqlDB, err := db.DB(); err != nil {
+		errs = errors.CombineErrors(errs, errors.Wrap(err, "get sql db"))
+	} else if err := sqlDB.Close(); err != nil {
+		errs = errors.CombineErrors(errs, errors.Wrap(err, "close postgres"))
+	}
+	if err := rdb.Close(); err != nil {
+		errs = errors.CombineErrors(errs, errors.Wrap(err, "close redis"))
+	}
+	return errs
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/nleeper/goment"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/metrics"
+)
+
+// augmontAuthService logs in with augmont merchant credentials
+// and caches the access token in the in-memory repo
+type augmontAuthService struct {
+	inMem interfaces.AugmontInMemRepo
+
+	client *http.Client
+}
+
+type AugmontLogInResp struct {
+	StatusCode int    `json:"statusCode"`
+	Message    string `json:"message"`
+
+	Result struct {
+		Data struct {
+			MerchantID int    `json:"merchantId"`
+			Token      string `json:"accessToken"`
+			ExpireAt   string `json:"expireAt"`
+		} `json:"data"`
+	} `json:"result"`
+}
+
+// NewAugmontAuthService creates new Augmont Auth Service
+func NewAugmontAuthService(inMem interfaces.AugmontInMemRepo) interfaces.AugmontAuthService {
+	return &augmontAuthService{
+		inMem: inMem,
+
+		client: newAugmontClient(),
+	}
+}
+
+// Login authenticates user with augmont
+func (s *augmontAuthService) logIn(ctx context.Context) (*AugmontLogInResp, error) {
+	// get config
+	email := domain.Config().Augmont.Email
+	password := domain.Config().Augmont.Password
+	host := domain.Config().Augmont.Host
+	// parse augmont login augUrl
+
+	augUrl := host + "/merchant/v1/auth/login"
+
+	form := url.Values{
+		"email":    {email},
+		"password": {password},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, augUrl, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+	respData := AugmontLogInResp{}
+	err = decodeAugmont(resp, &respData)
+	if err != nil {
+		return nil, err
+	}
+	if respData.StatusCode != 200 {
+		err = augmontError(respData.StatusCode, respData.Message, nil)
+		if !domain.ErrIs(err, domain.ErrUnavailable) {
+			// Rejected merchant credentials are nothing the user can fix
+			err = domain.NewError(domain.ErrToWrap(err), domain.ErrInternalError)
+		}
+		return nil, err
+	}
+	return &respData, nil
+}
+
+// AuthToken returns authenication token for augmont
+func (s *augmontAuthService) AuthToken(ctx context.Context) (string, error) {
+	// Retive the stored token
+	token, err := s.inMem.GetToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// If token is not expired, return it
+	if token != "" {
+		return token, nil
+	}
+
+	// If token is expired, generate new token
+	return s.RotateToken(ctx)
+}
+
+// RotateToken logs in again and replaces the stored token,
+// even if the stored one is not expired yet
+func (s *augmontAuthService) RotateToken(ctx context.Context) (token string, err error) {
+	defer func() {
+		metrics.AugmontTokenRefreshes.WithLabelValues(metrics.Outcome(err)).Inc()
+	}()
+
+	data, err := s.logIn(ctx)
+	if err != nil {
+		return "", err
+	}
+	token = data.Result.Data.Token
+	gom, err := goment.New(data.Result.Data.ExpireAt, "YYYY-MM-DD HH-mm-ss")
+	if err != nil {
+		return "", err
+	}
+	err = s.inMem.SetToken(ctx, token, gom.ToTime())
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/tax"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+type augmontCartService struct {
+	cart    interfaces.AugmontCartRepo
+	product interfaces.AugmontProductRepo
+	order   interfaces.AugmontOrderRepo
+	gold    interfaces.AugmontService
+}
+
+// NewAugmontCartService creates a new AugmontCartService
+func NewAugmontCartService(
+	cart interfaces.AugmontCartRepo,
+	product interfaces.AugmontProductRepo,
+	order interfaces.AugmontOrderRepo,
+	gold interfaces.AugmontService,
+) interfaces.AugmontCartService {
+	return &augmontCartService{
+		cart:    cart,
+		product: product,
+		order:   order,
+		gold:    gold,
+	}
+}
+
+func (s *augmontCartService) Get(ctx context.Context, user *models.AugmontUser) (*models.Cart, error) {
+	cart, _, err := s.load(ctx, user)
+	return cart, err
+}
+
+// load returns the cart and the SKUs in it that are no longer in the
+// catalogue, items are ordered by SKU
+func (s *augmontCartService) load(ctx context.Context, user *models.AugmontUser) (*models.Cart, []string, error) {
+	quantities, err := s.cart.GetCart(ctx, *user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	skus := make([]string, 0, len(quantities))
+	for sku := range quantities {
+		skus = append(skus, sku)
+	}
+	sort.Strings(skus)
+
+	products, err := s.product.FindBySKUs(ctx, skus)
+	if err != nil {
+		return nil, nil, err
+	}
+	bySKU := make(map[string]*models.AugmontProduct, len(products))
+	for _, p := range products {
+		bySKU[*p.SKU] = p
+	}
+
+	cart := &models.Cart{
+		Items:   []*models.CartItem{},
+		Weights: map[string]decimal.Decimal{},
+	}
+	var dropped []string
+	for _, sku := range skus {
+		p := bySKU[sku]
+		if p == nil || p.Active == nil || !*p.Active {
+			dropped = append(dropped, sku)
+			continue
+		}
+		quantity := decimal.NewFromInt(quantities[sku])
+		weight, _ := decimal.NewFromString(deref(p.Weight))
+		charges, _ := decimal.NewFromString(deref(p.MakingCharges))
+		item := &models.CartItem{
+			SKU:           sku,
+			Quantity:      quantities[sku],
+			Product:       p,
+			Weight:        weight.Mul(quantity),
+			MakingCharges: charges.Mul(quantity),
+		}
+		cart.Items = append(cart.Items, item)
+		metal := deref(p.MetalType)
+		cart.Weights[metal] = cart.Weights[metal].Add(item.Weight)
+		cart.MakingCharges = cart.MakingCharges.Add(item.MakingCharges)
+	}
+	return cart, dropped, nil
+}
+
+func (s *augmontCartService) SetItem(
+	ctx context.Context,
+	user *models.AugmontUser,
+	sku string,
+	quantity int64,
+) (*models.Cart, error) {
+	if quantity > 0 {
+		product, err := s.product.FindBySKU(ctx, sku)
+		if err != nil {
+			return nil, err
+		}
+		if product.Active == nil || !*product.Active {
+			return nil, invalidField(errors.Newf("product %v is inactive", sku), "sku", "product", "")
+		}
+		if err := checkStock(product, "quantity", quantity); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.cart.SetCartItem(ctx, *user.ID, sku, quantity); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, user)
+}
+
+func (s *augmontCartService) Clear(ctx context.Context, user *models.AugmontUser) error {
+	return s.cart.DeleteCart(ctx, *user.ID)
+}
+
+// checkStock reports the field when Augmont has fewer pieces in stock,
+// products without a stock count are not checked
+func checkStock(product *models.AugmontProduct, field string, quantity int64) error {
+	if product.Stock == nil || quantity <= *product.Stock {
+		return nil
+	}
+	stock := strconv.FormatInt(*product.Stock, 10)
+	err := errors.Newf("only %v of %v in stock", stock, *product.SKU)
+	return invalidField(err, field, "stock", stock)
+}
+
+func (s *augmontCartService) Checkout(
+	ctx context.Context,
+	user *models.AugmontUser,
+	info *utils.AugmontCheckoutInfo,
+) (utils.Any, error) {
+	cart, dropped, err := s.load(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if len(dropped) > 0 {
+		return nil, invalidField(errors.Newf("products %v are inactive", dropped), "items."+dropped[0], "product", "")
+	}
+	if len(cart.Items) == 0 {
+		return nil, invalidField(errors.New("cart is empty"), "items", "cart_empty", "")
+	}
+	for _, item := range cart.Items {
+		if err := checkStock(item.Product, "items."+item.SKU, item.Quantity); err != nil {
+			return nil, err
+		}
+	}
+
+	// The metal delivered comes out of the balance held
+	orders, err := s.order.FindOrdersBetween(ctx, *user.ID, time.Time{}, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	held := tax.Holdings(orders)
+	for _, metal := range utils.Metals {
+		grams := cart.Weights[string(metal)]
+		balance := held[string(metal)].Quantity
+		if grams.GreaterThan(balance) {
+			err := errors.Newf("%v g of %v to deliver, %v g held", grams, metal, balance)
+			return nil, invalidField(err, "items", "balance", balance.String())
+		}
+	}
+
+	addresses, err := s.gold.GetUserAddresses(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	var address *utils.AugmontUserAddressInfo
+	for _, a := range addresses {
+		if a.UserAddressID == info.UserAddressID {
+			address = a
+			break
+		}
+	}
+	if address == nil {
+		err := errors.Newf("address %v not found", info.UserAddressID)
+		return nil, invalidField(err, "userAddressId", "address", "")
+	}
+	serviceable, err := s.gold.Serviceable(ctx, address.Pincode)
+	if err != nil {
+		return nil, err
+	}
+	if !serviceable {
+		err := errors.Newf("pincode %v not serviceable", address.Pincode)
+		return nil, invalidField(err, "userAddressId", "serviceable", address.Pincode)
+	}
+
+	redeemInfo := &utils.AugmontRedeemInfo{
+		UserAddressID: address.UserAddressID,
+		MobileNo:      info.MobileNo,
+	}
+	if redeemInfo.MobileNo == "" {
+		redeemInfo.MobileNo = address.MobileNo
+	}
+	for _, item := range cart.Items {
+		redeemInfo.Product = append(redeemInfo.Product, utils.AugmontProductInfo{
+			SKU:      item.SKU,
+			Quantity: strconv.FormatInt(item.Quantity, 10),
+		})
+	}
+	result, err := s.gold.Redeem(ctx, user, redeemInfo, cart.Weights)
+	if err != nil {
+		return nil, err
+	}
+
+	// The order is placed, a cart left behind is only stale
+	if err := s.cart.DeleteCart(ctx, *user.ID); err != nil {
+		domain.Logger(ctx).WithError(err).Warn("cart not cleared")
+	}
+	return result, nil
+}
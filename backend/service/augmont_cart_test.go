@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/configtest"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/events"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+type fakeCartRepo struct {
+	interfaces.AugmontCartRepo
+	items map[string]int64
+}
+
+func (r *fakeCartRepo) GetCart(ctx context.Context, augmontUserID uint64) (map[string]int64, error) {
+	return r.items, nil
+}
+
+func (r *fakeCartRepo) DeleteCart(ctx context.Context, augmontUserID uint64) error {
+	r.items = map[string]int64{}
+	return nil
+}
+
+type fakeProductRepo struct {
+	interfaces.AugmontProductRepo
+	products []*models.AugmontProduct
+}
+
+func (r *fakeProductRepo) FindBySKUs(ctx context.Context, skus []string) ([]*models.AugmontProduct, error) {
+	return r.products, nil
+}
+
+// fakeRedeemGold redeems with the embedded service when it is set
+type fakeRedeemGold struct {
+	interfaces.AugmontService
+	serviceable bool
+	redeemed    *utils.AugmontRedeemInfo
+	weights     map[string]decimal.Decimal
+}
+
+func (g *fakeRedeemGold) GetUserAddresses(ctx context.Context, user *models.AugmontUser) ([]*utils.AugmontUserAddressInfo, error) {
+	return []*utils.AugmontUserAddressInfo{
+		{UserAddressID: "A1", MobileNo: "9876543210", Pincode: "400001"},
+	}, nil
+}
+
+func (g *fakeRedeemGold) Serviceable(ctx context.Context, pincode string) (bool, error) {
+	return g.serviceable, nil
+}
+
+func (g *fakeRedeemGold) Redeem(
+	ctx context.Context,
+	user *models.AugmontUser,
+	info *utils.AugmontRedeemInfo,
+	weights map[string]decimal.Decimal,
+) (utils.Any, error) {
+	g.redeemed, g.weights = info, weights
+	if g.AugmontService != nil {
+		return g.AugmontService.Redeem(ctx, user, info, weights)
+	}
+	info.MerchantTnxID = "R1"
+	return map[string]interface{}{"merchantTransactionId": "R1"}, nil
+}
+
+// fakeAuth returns a fixed augmont token
+type fakeAuth struct {
+	interfaces.AugmontAuthService
+}
+
+func (fakeAuth) AuthToken(ctx context.Context) (string, error) {
+	return "token", nil
+}
+
+// augmontServer serves the augmont api with the handler for the tests
+func augmontServer(t *testing.T, handler http.HandlerFunc) *http.Client {
+	configtest.Use(t)
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	conf := &domain.Config().Augmont
+	conf.Host = srv.URL
+	return srv.Client()
+}
+
+func product(sku, metal, weight string, stock *int64) *models.AugmontProduct {
+	active := true
+	charges := "250.00"
+	return &models.AugmontProduct{
+		SKU:           &sku,
+		MetalType:     &metal,
+		Weight:        &weight,
+		MakingCharges: &charges,
+		Stock:         stock,
+		Active:        &active,
+	}
+}
+
+func heldGold(quantity string) []*models.AugmontOrder {
+	orderType, status, metal, amount := models.OrderBuy, models.OrderCompleted, "gold", "5000"
+	created := time.Now().AddDate(0, -1, 0)
+	return []*models.AugmontOrder{{
+		CreatedAt: &created,
+		Type:      &orderType,
+		AugmontOrderInfo: models.AugmontOrderInfo{
+			Status:    &status,
+			MetalType: &metal,
+			Quantity:  &quantity,
+			Amount:    &amount,
+		},
+	}}
+}
+
+func fieldCode(t *testing.T, err error) string {
+	e, ok := domain.AsError(err)
+	if !assert.True(t, ok) || !assert.Len(t, e.Fields(), 1) {
+		return ""
+	}
+	return e.Fields()[0].Code
+}
+
+func TestAugmontCartServiceCheckout(t *testing.T) {
+	id := uint64(1)
+	user := &models.AugmontUser{ID: &id}
+	info := &utils.AugmontCheckoutInfo{UserAddressID: "A1"}
+	two := int64(2)
+
+	newService := func(items map[string]int64, held string, gold *fakeRedeemGold) interfaces.AugmontCartService {
+		products := &fakeProductRepo{products: []*models.AugmontProduct{
+			product("GC1", "gold", "1", nil),
+			product("GC5", "gold", "5", &two),
+		}}
+		return NewAugmontCartService(&fakeCartRepo{items: items}, products, &fakeOrderRepo{orders: heldGold(held)}, gold)
+	}
+
+	t.Run("should redeem the cart and clear it", func(t *testing.T) {
+		gold := &fakeRedeemGold{serviceable: true}
+		s := newService(map[string]int64{"GC1": 2, "GC5": 1}, "7", gold)
+
+		_, err := s.Checkout(context.Background(), user, info)
+		assert.NoError(t, err)
+		assert.Equal(t, "9876543210", gold.redeemed.MobileNo)
+		assert.Equal(t, []utils.AugmontProductInfo{
+			{SKU: "GC1", Quantity: "2"},
+			{SKU: "GC5", Quantity: "1"},
+		}, gold.redeemed.Product)
+		assert.Equal(t, "7", gold.weights["gold"].String())
+
+		cart, err := s.Get(context.Background(), user)
+		assert.NoError(t, err)
+		assert.Empty(t, cart.Items)
+	})
+
+	t.Run("should reject an empty cart", func(t *testing.T) {
+		s := newService(map[string]int64{}, "7", &fakeRedeemGold{serviceable: true})
+		_, err := s.Checkout(context.Background(), user, info)
+		assert.Equal(t, "cart_empty", fieldCode(t, err))
+	})
+
+	t.Run("should reject products no longer sold", func(t *testing.T) {
+		s := newService(map[string]int64{"GB100": 1}, "200", &fakeRedeemGold{serviceable: true})
+		_, err := s.Checkout(context.Background(), user, info)
+		assert.Equal(t, "product", fieldCode(t, err))
+	})
+
+	t.Run("should reject more pieces than in stock", func(t *testing.T) {
+		s := newService(map[string]int64{"GC5": 3}, "20", &fakeRedeemGold{serviceable: true})
+		_, err := s.Checkout(context.Background(), user, info)
+		assert.Equal(t, "stock", fieldCode(t, err))
+	})
+
+	t.Run("should reject more grams than held", func(t *testing.T) {
+		gold := &fakeRedeemGold{serviceable: true}
+		s := newService(map[string]int64{"GC1": 2, "GC5": 1}, "6.5", gold)
+		_, err := s.Checkout(context.Background(), user, info)
+		assert.Equal(t, "balance", fieldCode(t, err))
+		assert.Nil(t, gold.redeemed)
+	})
+
+	t.Run("should take earlier redeems from the balance", func(t *testing.T) {
+		client := augmontServer(t, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"statusCode": 200, "result": {"data": {"merchantTransactionId": "R1"}}}`)
+		})
+		orders, outbox := &fakeOrderRepo{orders: heldGold("7")}, &fakeOutboxRepo{}
+		gold := &fakeRedeemGold{
+			serviceable: true,
+			AugmontService: &augmontService{
+				order:  orders,
+				outbox: outbox,
+				uow:    fakeUnitOfWork{},
+				auth:   fakeAuth{},
+				client: client,
+			},
+		}
+		products := &fakeProductRepo{products: []*models.AugmontProduct{product("GC5", "gold", "5", nil)}}
+		cart := &fakeCartRepo{items: map[string]int64{"GC5": 1}}
+		s := NewAugmontCartService(cart, products, orders, gold)
+
+		_, err := s.Checkout(context.Background(), user, info)
+		assert.NoError(t, err)
+		if assert.Len(t, orders.orders, 2) {
+			redeemed := orders.orders[1]
+			assert.Equal(t, models.OrderRedeem, *redeemed.Type)
+			assert.Equal(t, "gold", *redeemed.MetalType)
+			assert.Equal(t, "5", *redeemed.Quantity)
+		}
+		if assert.Len(t, outbox.added, 1) {
+			ev := outbox.added[0].(*events.RedeemCompleted)
+			assert.Equal(t, []events.Metal{{MetalType: "gold", Quantity: "5"}}, ev.Metals)
+		}
+
+		cart.items = map[string]int64{"GC5": 1}
+		_, err = s.Checkout(context.Background(), user, info)
+		assert.Equal(t, "balance", fieldCode(t, err))
+	})
+
+	t.Run("should redeem gifts received", func(t *testing.T) {
+		gold := &fakeRedeemGold{serviceable: true}
+		orders := heldGold("2")
+		gift := heldGold("5")[0]
+		gift.Type = strPtr(models.LedgerGiftReceived)
+		products := &fakeProductRepo{products: []*models.AugmontProduct{product("GC5", "gold", "5", nil)}}
+		s := NewAugmontCartService(&fakeCartRepo{items: map[string]int64{"GC5": 1}}, products,
+			&fakeOrderRepo{orders: append(orders, gift)}, gold)
+
+		_, err := s.Checkout(context.Background(), user, info)
+		assert.NoError(t, err)
+		assert.NotNil(t, gold.redeemed)
+	})
+
+	t.Run("should reject unknown addresses", func(t *testing.T) {
+		s := newService(map[string]int64{"GC1": 1}, "7", &fakeRedeemGold{serviceable: true})
+		_, err := s.Checkout(context.Background(), user, &utils.AugmontCheckoutInfo{UserAddressID: "A2"})
+		assert.Equal(t, "address", fieldCode(t, err))
+	})
+
+	t.Run("should reject pincodes not served", func(t *testing.T) {
+		gold := &fakeRedeemGold{serviceable: false}
+		s := newService(map[string]int64{"GC1": 1}, "7", gold)
+		_, err := s.Checkout(context.Background(), user, info)
+		assert.Equal(t, "serviceable", fieldCode(t, err))
+		assert.Nil(t, gold.redeemed)
+	})
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/metrics"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+// Timeout of a single augmont api call
+const augmontTimeout = 30 * time.Second
+
+// Static segments of augmont merchant api paths,
+// every other segment is an id and is dropped from the labels
+var augmontPathSegments = map[string]bool{
+	"merchant": true, "v1": true,
+	"auth": true, "login": true,
+	"users": true, "kyc": true, "banks": true, "address": true,
+	"buy": true, "sell": true, "order": true,
+	"invoice": true, "rates": true,
+	"products": true, "pincode": true, "transfer": true,
+}
+
+// newAugmontClient returns the http client used for augmont calls,
+// each call gets a client span and its metrics recorded
+func newAugmontClient() *http.Client {
+	transport := &augmontTransport{
+		base: http.DefaultTransport,
+	}
+	return &http.Client{
+		Timeout: augmontTimeout,
+		Transport: otelhttp.NewTransport(transport,
+			otelhttp.WithSpanNameFormatter(func(_ string, req *http.Request) string {
+				return "augmont " + req.Method + " " + augmontEndpoint(req.URL.Path)
+			}),
+		),
+	}
+}
+
+// augmontTransport records metrics for each augmont api call
+type augmontTransport struct {
+	base http.RoundTripper
+}
+
+func (t *augmontTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := augmontEndpoint(req.URL.Path)
+	start := time.Now()
+
+	// Tie the augmont call to the request that caused it,
+	// RoundTrip must not modify the caller's request
+	if id := domain.RequestID(req.Context()); id != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set(domain.RequestIDHeader, id)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+
+	statusCode := "error"
+	if err == nil {
+		statusCode = strconv.Itoa(resp.StatusCode)
+	}
+
+	entry := domain.Logger(req.Context()).WithFields(log.Fields{
+		"augmontMethod":   req.Method,
+		"augmontEndpoint": endpoint,
+		"augmontStatus":   statusCode,
+		"latencyMs":       float64(time.Since(start).Microseconds()) / 1000,
+	})
+	switch {
+	case err != nil:
+		entry.WithError(err).Error("augmont call failed")
+	case resp.StatusCode >= 400:
+		entry.Warn("augmont call failed")
+	default:
+		entry.Debug("augmont call")
+	}
+	metrics.AugmontRequests.
+		WithLabelValues(req.Method, endpoint, statusCode).
+		Inc()
+	metrics.AugmontRequestDuration.
+		WithLabelValues(req.Method, endpoint).
+		Observe(time.Since(start).Seconds())
+
+	return resp, err
+}
+
+// augmontEndpoint replaces the ids in the path with placeholders,
+// /merchant/v1/users/abc/banks -> /merchant/v1/users/:id/banks
+func augmontEndpoint(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		if !augmontPathSegments[segment] {
+			segments[i] = ":id"
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// metalLabel bounds the metal label to the metals augmont supports
+func metalLabel(metal string) string {
+	if metal, ok := utils.ParseMetal(metal); ok {
+		return string(metal)
+	}
+	return "unknown"
+}
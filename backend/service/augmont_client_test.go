@@ -0,0 +1,25 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAugmontEndpoint(t *testing.T) {
+	t.Run("should keep static paths", func(t *testing.T) {
+		assert.Equal(t, "/merchant/v1/auth/login", augmontEndpoint("/merchant/v1/auth/login"))
+		assert.Equal(t, "/merchant/v1/buy", augmontEndpoint("/merchant/v1/buy"))
+	})
+
+	t.Run("should replace ids with placeholders", func(t *testing.T) {
+		assert.Equal(t,
+			"/merchant/v1/users/:id/banks/:id",
+			augmontEndpoint("/merchant/v1/users/aB3-x_9/banks/42"),
+		)
+		assert.Equal(t,
+			"/merchant/v1/:id/buy",
+			augmontEndpoint("/merchant/v1/aB3-x_9/buy"),
+		)
+	})
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/i18n"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+// augmontRule maps a known Augmont failure to a typed domain error
+type augmontRule struct {
+	// Augmont field the rule applies to, empty matches the message
+	field string
+	// Substrings of the Augmont error code or message, any of them matches
+	match []string
+
+	errType int
+	code    string
+	uiMsg   string
+}
+
+// augmontRules are checked in order, the first match wins
+var augmontRules = []augmontRule{
+	{
+		field:   "mobileNumber",
+		match:   []string{"unique", "already", "exists"},
+		errType: domain.ErrConflict,
+		code:    "augmont.duplicate_mobile",
+		uiMsg:   "This mobile number is already registered",
+	},
+	{
+		field:   "ifscCode",
+		match:   []string{""},
+		errType: domain.ErrInvalidArgument,
+		code:    "augmont.invalid_ifsc",
+		uiMsg:   "Enter a valid IFSC code",
+	},
+	{
+		match:   []string{"kyc required", "kyc is required", "kyc not", "complete kyc", "kyc pending"},
+		errType: domain.ErrForbidden,
+		code:    "augmont.kyc_required",
+		uiMsg:   "Complete your KYC to continue",
+	},
+	{
+		match:   []string{"insufficient", "not enough"},
+		errType: domain.ErrInvalidArgument,
+		code:    "augmont.insufficient_balance",
+		uiMsg:   "You do not have enough balance for this transaction",
+	},
+	{
+		match:   []string{"block id", "blockid", "rate expired", "price expired"},
+		errType: domain.ErrConflict,
+		code:    "augmont.rate_expired",
+		uiMsg:   "The rate has expired, Please try again",
+	},
+}
+
+// augmontInternalFields are set by us, not by the client,
+// Augmont rejecting them is a bug on our side
+var augmontInternalFields = map[string]bool{
+	"uniqueId":              true,
+	"merchantTransactionId": true,
+	"modeOfPayment":         true,
+	"referenceType":         true,
+	"referenceId":           true,
+}
+
+// augmontFieldMsgs are user friendly messages of Augmont fields
+var augmontFieldMsgs = map[string]string{
+	"mobileNumber":  "Enter a valid mobile number",
+	"emailId":       "Enter a valid email address",
+	"userPincode":   "Enter a valid pincode",
+	"pincode":       "Enter a valid pincode",
+	"dateOfBirth":   "Enter your date of birth as DD-MM-YYYY",
+	"panNumber":     "Enter a valid PAN",
+	"panAttachment": "Upload a clear image of your PAN card",
+	"nameAsPerPan":  "Enter your name as printed on your PAN card",
+	"accountNumber": "Enter a valid account number",
+	"ifscCode":      "Enter a valid IFSC code",
+	"quantity":      "Enter a valid quantity",
+	"amount":        "Enter a valid amount",
+}
+
+// decodeAugmont decodes the Augmont response body, a body that is not
+// JSON, e.g. the error page of a gateway, leaves the outcome unknown
+func decodeAugmont(res *http.Response, v interface{}) error {
+	if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+		err = errors.Wrapf(err, "augmont: decode %d response", res.StatusCode)
+		return domain.NewError(err, domain.ErrUnavailable)
+	}
+	return nil
+}
+
+// augmontResponseError translates a failed Augmont response to a domain error
+func augmontResponseError(resp *utils.AugmontResponse) error {
+	if !resp.IsError() {
+		return nil
+	}
+	return augmontError(resp.StatusCode, resp.Message, map[string]interface{}(resp.Errors))
+}
+
+// augmontDataError translates a failed Augmont response decoded to a map
+func augmontDataError(data map[string]interface{}) error {
+	status, _ := data["statusCode"].(float64)
+	msg, _ := data["message"].(string)
+	errs, _ := data["errors"].(map[string]interface{})
+	return augmontError(int(status), msg, errs)
+}
+
+// augmontError translates the Augmont status code, message and per field
+// errors to a domain error with a code and a message the app can render,
+// the raw Augmont errors are kept in the wrapped error for the logs
+func augmontError(status int, msg string, errs map[string]interface{}) error {
+	fields := augmontFieldErrors(errs)
+	err := fmt.Errorf("augmont: %d %s: %v", status, msg, errs)
+
+	// Known failures first, matched on the raw Augmont codes and messages
+	all := strings.ToLower(msg)
+	for _, f := range fields {
+		all += " " + strings.ToLower(f.Code+" "+f.Message)
+	}
+	for _, rule := range augmontRules {
+		if rule.field == "" {
+			if containsAny(all, rule.match) {
+				e, _ := domain.AsError(domain.NewError(err, rule.errType))
+				return e.WithCode(rule.code, rule.uiMsg).WithFields(friendlyFieldErrors(fields)...)
+			}
+			continue
+		}
+		for i, f := range fields {
+			if f.Field == rule.field && containsAny(strings.ToLower(f.Code+" "+f.Message), rule.match) {
+				fields = friendlyFieldErrors(fields)
+				fields[i].Code = rule.code
+				fields[i].Message = rule.uiMsg
+				e, _ := domain.AsError(domain.NewError(err, rule.errType))
+				return e.WithCode(rule.code, rule.uiMsg).WithFields(fields...)
+			}
+		}
+	}
+
+	if len(fields) > 0 {
+		for _, f := range fields {
+			if !augmontInternalFields[f.Field] {
+				e, _ := domain.AsError(domain.NewError(err, domain.ErrInvalidArgument))
+				return e.WithCode("validation_failed", "Please correct the highlighted fields").
+					WithFields(friendlyFieldErrors(fields)...)
+			}
+		}
+		e, _ := domain.AsError(domain.NewError(err, domain.ErrInternalError))
+		// A retry with the id of a transaction Augmont already made, the
+		// callers reusing ids tell it apart by the code
+		for _, f := range fields {
+			if f.Field == "merchantTransactionId" && containsAny(strings.ToLower(f.Code+" "+f.Message), []string{"unique", "already", "exists", "duplicate"}) {
+				return e.WithCode("augmont.duplicate_transaction", "This transaction is already done")
+			}
+		}
+		return e
+	}
+
+	switch {
+	case status == http.StatusNotFound:
+		return domain.NewError(err, domain.ErrNotFound)
+	case status == http.StatusUnauthorized, status == http.StatusForbidden:
+		// Our merchant credentials are rejected, nothing the user can fix
+		return domain.NewError(err, domain.ErrInternalError)
+	case status == 0, status == http.StatusTooManyRequests, status >= http.StatusInternalServerError:
+		// No status is a body Augmont did not send
+		return domain.NewError(err, domain.ErrUnavailable)
+	}
+	return domain.NewError(err, domain.ErrBadRequest)
+}
+
+// augmontFieldErrors flattens the Augmont errors object, Augmont sends
+// either a list of {code, message} objects, a list of strings or a
+// string per field
+func augmontFieldErrors(errs map[string]interface{}) []domain.FieldError {
+	fields := make([]domain.FieldError, 0, len(errs))
+	for field, v := range errs {
+		code, msg := augmontFieldDetail(v)
+		if code == "" {
+			code = "invalid"
+		}
+		fields = append(fields, domain.FieldError{
+			Field:   field,
+			Code:    code,
+			Message: msg,
+		})
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Field < fields[j].Field
+	})
+	return fields
+}
+
+// friendlyFieldErrors replaces the Augmont messages of known fields
+// with messages that can be shown in the app
+func friendlyFieldErrors(fields []domain.FieldError) []domain.FieldError {
+	friendly := make([]domain.FieldError, len(fields))
+	for i, f := range fields {
+		if uiMsg, ok := augmontFieldMsgs[f.Field]; ok {
+			f.Message = uiMsg
+		}
+		friendly[i] = f
+	}
+	return friendly
+}
+
+// augmontFieldDetail returns the code and the message of the first error of a field
+func augmontFieldDetail(v interface{}) (string, string) {
+	switch t := v.(type) {
+	case string:
+		return "", t
+	case []interface{}:
+		if len(t) > 0 {
+			return augmontFieldDetail(t[0])
+		}
+	case map[string]interface{}:
+		code, _ := t["code"].(string)
+		msg, _ := t["message"].(string)
+		return code, msg
+	case utils.Dict:
+		return augmontFieldDetail(map[string]interface{}(t))
+	}
+	return "", fmt.Sprint(v)
+}
+
+func containsAny(s string, subs []string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// invalidField reports err as a validation failure of a request field,
+// the message is translated again by the code for the client locale
+func invalidField(err error, field, code, param string) error {
+	msg, _ := i18n.T(i18n.DefaultLocale, "validation."+code, map[string]string{"field": field, "param": param})
+	e, _ := domain.AsError(domain.NewError(err, domain.ErrInvalidArgument))
+	return e.WithCode("validation_failed", "Please correct the highlighted fields").
+		WithFields(domain.FieldError{
+			Field:   field,
+			Code:    code,
+			Param:   param,
+			Message: msg,
+		})
+}
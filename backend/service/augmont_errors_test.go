@@ -0,0 +1,77 @@
+package service
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+func TestAugmontError(t *testing.T) {
+	t.Run("should map duplicate mobile to conflict", func(t *testing.T) {
+		err := augmontError(422, "Validation error", map[string]interface{}{
+			"mobileNumber": []interface{}{
+				map[string]interface{}{"code": "unique", "message": "The mobile number has already been taken."},
+			},
+		})
+		e, ok := domain.AsError(err)
+		assert.True(t, ok)
+		assert.Equal(t, domain.ErrConflict, e.Type())
+		assert.Equal(t, "augmont.duplicate_mobile", e.Code())
+		assert.Equal(t, []domain.FieldError{{
+			Field:   "mobileNumber",
+			Code:    "augmont.duplicate_mobile",
+			Message: "This mobile number is already registered",
+		}}, e.Fields())
+	})
+
+	t.Run("should map insufficient balance from the message", func(t *testing.T) {
+		e, _ := domain.AsError(augmontError(400, "Insufficient balance", nil))
+		assert.Equal(t, domain.ErrInvalidArgument, e.Type())
+		assert.Equal(t, "augmont.insufficient_balance", e.Code())
+	})
+
+	t.Run("should return unknown field errors as validation errors", func(t *testing.T) {
+		e, _ := domain.AsError(augmontError(422, "Validation error", map[string]interface{}{
+			"userPincode": "The user pincode must be 6 digits.",
+			"nomineeName": []interface{}{"The nominee name is invalid."},
+		}))
+		assert.Equal(t, domain.ErrInvalidArgument, e.Type())
+		assert.Equal(t, "validation_failed", e.Code())
+		assert.Equal(t, []domain.FieldError{
+			{Field: "nomineeName", Code: "invalid", Message: "The nominee name is invalid."},
+			{Field: "userPincode", Code: "invalid", Message: "Enter a valid pincode"},
+		}, e.Fields())
+	})
+
+	t.Run("should hide errors of fields set by us", func(t *testing.T) {
+		err := augmontError(422, "Validation error", map[string]interface{}{
+			"merchantTransactionId": "The merchant transaction id has already been taken.",
+		})
+		assert.True(t, domain.ErrIs(err, domain.ErrInternalError))
+		e, _ := domain.AsError(err)
+		assert.Equal(t, "augmont.duplicate_transaction", e.Code())
+	})
+
+	t.Run("should classify by status code", func(t *testing.T) {
+		assert.True(t, domain.ErrIs(augmontError(404, "User not found", nil), domain.ErrNotFound))
+		assert.True(t, domain.ErrIs(augmontError(401, "Unauthenticated", nil), domain.ErrInternalError))
+		assert.True(t, domain.ErrIs(augmontError(502, "Bad gateway", nil), domain.ErrUnavailable))
+		assert.True(t, domain.ErrIs(augmontDataError(map[string]interface{}{}), domain.ErrUnavailable))
+	})
+
+	t.Run("should leave bodies that are not JSON unknown", func(t *testing.T) {
+		res := &http.Response{
+			StatusCode: http.StatusBadGateway,
+			Body:       io.NopCloser(strings.NewReader("<html>502 Bad Gateway</html>")),
+		}
+		err := decodeAugmont(res, &utils.AugmontResponse{})
+		assert.True(t, domain.ErrIs(err, domain.ErrUnavailable))
+		assert.False(t, transferRejected(err))
+	})
+}
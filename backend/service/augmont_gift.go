@@ -0,0 +1,570 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/events"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/tax"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+type augmontGiftService struct {
+	gift        interfaces.AugmontGiftRepo
+	augmontUser interfaces.AugmontUserRepo
+	user        interfaces.UserRepo
+	gold        interfaces.AugmontService
+	order       interfaces.AugmontOrderRepo
+	notifier    interfaces.Notifier
+	outbox      interfaces.OutboxRepo
+	uow         interfaces.UnitOfWork
+}
+
+// NewAugmontGiftService creates a new AugmontGiftService
+func NewAugmontGiftService(
+	gift interfaces.AugmontGiftRepo,
+	augmontUser interfaces.AugmontUserRepo,
+	user interfaces.UserRepo,
+	gold interfaces.AugmontService,
+	order interfaces.AugmontOrderRepo,
+	notifier interfaces.Notifier,
+	outbox interfaces.OutboxRepo,
+	uow interfaces.UnitOfWork,
+) interfaces.AugmontGiftService {
+	return &augmontGiftService{
+		gift:        gift,
+		augmontUser: augmontUser,
+		user:        user,
+		gold:        gold,
+		order:       order,
+		notifier:    notifier,
+		outbox:      outbox,
+		uow:         uow,
+	}
+}
+
+func kycApproved(user *models.AugmontUser) bool {
+	return user != nil && user.KYCStatus != nil && *user.KYCStatus == models.KYCApproved
+}
+
+// firstName is all of a name shown to other users
+func firstName(name *string) string {
+	fields := strings.Fields(deref(name))
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// sender returns the Pinch user of the sender, gifts to their own
+// mobile number are refused
+func (s *augmontGiftService) sender(ctx context.Context, sender *models.AugmontUser, mobile string) (*models.User, error) {
+	user, err := s.user.FindOne(ctx, &models.User{ID: sender.UserID})
+	if err != nil {
+		return nil, errors.Wrap(err, "find sender")
+	}
+	if deref(user.Mobile) == mobile {
+		return nil, invalidField(errors.New("gift to own mobile number"), "mobileNumber", "self_gift", "")
+	}
+	return user, nil
+}
+
+// recipient returns the Pinch and Augmont users of the mobile number,
+// each nil if there is none
+func (s *augmontGiftService) recipient(ctx context.Context, mobile string) (*models.User, *models.AugmontUser, error) {
+	users, err := s.user.FindMany(ctx, &models.User{Mobile: &mobile})
+	if err != nil || len(users) == 0 {
+		return nil, nil, err
+	}
+	agUsers, err := s.augmontUser.FindUsers(ctx, &models.AugmontUser{UserID: users[0].ID})
+	if err != nil || len(agUsers) == 0 {
+		return users[0], nil, err
+	}
+	return users[0], agUsers[0], nil
+}
+
+func (s *augmontGiftService) FindRecipient(
+	ctx context.Context,
+	sender *models.AugmontUser,
+	mobile string,
+) (*models.GiftRecipient, error) {
+	if _, err := s.sender(ctx, sender, mobile); err != nil {
+		return nil, err
+	}
+	user, agUser, err := s.recipient(ctx, mobile)
+	if err != nil {
+		return nil, err
+	}
+	recipient := &models.GiftRecipient{
+		Mobile:   mobile,
+		OnPinch:  user != nil,
+		Escrowed: !kycApproved(agUser),
+	}
+	if user != nil {
+		recipient.Name = firstName(user.Name)
+	}
+	return recipient, nil
+}
+
+func ledgerEntry(augmontUserID *uint64, entryType, metal string, quantity decimal.Decimal, txnID string) *models.AugmontLedgerEntry {
+	q := quantity.String()
+	return &models.AugmontLedgerEntry{
+		AugmontUserID: augmontUserID,
+		Type:          &entryType,
+		MetalType:     &metal,
+		Quantity:      &q,
+		MerchantTxnID: &txnID,
+	}
+}
+
+// escrowUID returns the account holding the gifts to users without KYC
+func escrowUID() (string, error) {
+	uid := domain.Config().Augmont.EscrowUID
+	if uid == "" {
+		return "", domain.NewError(errors.New("no escrow account"), domain.ErrUnavailable, "gifts to users without KYC are not available")
+	}
+	return uid, nil
+}
+
+// kycRequired is the error of users without an approved KYC
+func kycRequired() error {
+	e, _ := domain.AsError(domain.NewError(errors.New("kyc not approved"), domain.ErrForbidden))
+	return e.WithCode("augmont.kyc_required", "Complete your KYC to continue")
+}
+
+// checkBalance refuses gifts of more metal than the sender holds, less
+// the gifts still sending
+func (s *augmontGiftService) checkBalance(
+	ctx context.Context,
+	sender *models.AugmontUser,
+	metal string,
+	quantity decimal.Decimal,
+	sending []*models.AugmontGift,
+) error {
+	orders, err := s.order.FindOrdersBetween(ctx, *sender.ID, time.Time{}, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "find sender orders")
+	}
+	held := tax.Holdings(orders)[metal].Quantity
+	for _, gift := range sending {
+		if deref(gift.MetalType) == metal {
+			q, _ := decimal.NewFromString(deref(gift.Quantity))
+			held = held.Sub(q)
+		}
+	}
+	if quantity.GreaterThan(held) {
+		err := errors.Newf("gift of %v g of %v with %v g held", quantity, metal, held)
+		e, _ := domain.AsError(domain.NewError(err, domain.ErrInvalidArgument))
+		return e.WithCode("augmont.insufficient_balance", "You do not have enough balance for this transaction")
+	}
+	return nil
+}
+
+func (s *augmontGiftService) Send(
+	ctx context.Context,
+	sender *models.AugmontUser,
+	info *utils.AugmontGiftInfo,
+) (*models.AugmontGift, error) {
+	if !kycApproved(sender) {
+		return nil, kycRequired()
+	}
+	senderUser, err := s.sender(ctx, sender, info.MobileNo)
+	if err != nil {
+		return nil, err
+	}
+	_, recipient, err := s.recipient(ctx, info.MobileNo)
+	if err != nil {
+		return nil, err
+	}
+	quantity, err := decimal.NewFromString(info.Quantity)
+	if err != nil || !quantity.IsPositive() {
+		return nil, invalidField(errors.Newf("invalid gift quantity %q", info.Quantity), "quantity", "invalid_quantity", "")
+	}
+
+	sending, err := s.gift.FindSendingGifts(ctx, *sender.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, gift := range sending {
+		// A retry of a gift Augmont has not answered yet may send it twice
+		if deref(gift.RecipientMobile) == info.MobileNo {
+			e, _ := domain.AsError(domain.NewError(errors.New("gift to the mobile number still sending"), domain.ErrConflict))
+			return nil, e.WithCode("gift.sending", "Your last gift to this number is still being sent")
+		}
+	}
+	if err := s.checkBalance(ctx, sender, info.MetalType, quantity, sending); err != nil {
+		return nil, err
+	}
+	if !kycApproved(recipient) {
+		if _, err := escrowUID(); err != nil {
+			return nil, err
+		}
+		recipient = nil
+	}
+
+	// The id is saved before the transfer so that a retry can not move
+	// the metal twice
+	txnID, status := utils.NewUniqueString(giftTxnIDLen), models.GiftSending
+	gift := &models.AugmontGift{
+		SenderID:        sender.ID,
+		RecipientMobile: &info.MobileNo,
+		MetalType:       &info.MetalType,
+		Quantity:        &info.Quantity,
+		Status:          &status,
+		TransferTxnID:   &txnID,
+	}
+	if info.Message != "" {
+		gift.Message = &info.Message
+	}
+	if recipient != nil {
+		gift.RecipientID = recipient.ID
+	}
+	if err := s.gift.CreateGift(ctx, gift); err != nil {
+		return nil, err
+	}
+	if err := s.send(ctx, gift, sender, senderUser, recipient); err != nil {
+		return nil, err
+	}
+	return gift, nil
+}
+
+// send transfers the sending gift from the sender to the recipient, or
+// to the escrow without one, with its transfer transaction id, a transfer
+// Augmont rejected fails the gift, one that may have moved the metal
+// leaves it sending for the sweep
+func (s *augmontGiftService) send(
+	ctx context.Context,
+	gift *models.AugmontGift,
+	sender *models.AugmontUser,
+	senderUser *models.User,
+	recipient *models.AugmontUser,
+) error {
+	logger := domain.Logger(ctx).WithFields(log.Fields{"giftID": *gift.ID, "txnID": *gift.TransferTxnID})
+	transfer := &utils.AugmontTransferInfo{
+		MerchantTnxID: *gift.TransferTxnID,
+		SenderUID:     *sender.UID,
+		MetalType:     *gift.MetalType,
+		Quantity:      *gift.Quantity,
+	}
+	status := models.GiftCompleted
+	if recipient != nil {
+		transfer.ReceiverUID = *recipient.UID
+	} else {
+		escrow, err := escrowUID()
+		if err != nil {
+			return err
+		}
+		transfer.ReceiverUID, status = escrow, models.GiftEscrowed
+	}
+	_, err := s.gold.Transfer(ctx, transfer)
+	switch {
+	case err == nil:
+	case transferDone(err):
+		logger.Info("gift already transferred")
+	case transferRejected(err):
+		// Nothing moved
+		if _, err := s.gift.FailSend(ctx, gift); err != nil {
+			logger.WithError(err).Error("rejected gift not failed")
+		}
+		return err
+	default:
+		logger.WithError(err).Warn("gift left for the sweep")
+		return err
+	}
+
+	gift.Status = &status
+	quantity, _ := decimal.NewFromString(*gift.Quantity)
+	entries := []*models.AugmontLedgerEntry{
+		ledgerEntry(sender.ID, models.LedgerGiftSent, *gift.MetalType, quantity.Neg(), *gift.TransferTxnID),
+	}
+	if recipient != nil {
+		entries = append(entries,
+			ledgerEntry(recipient.ID, models.LedgerGiftReceived, *gift.MetalType, quantity, *gift.TransferTxnID))
+	}
+	completed := false
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		ok, err := s.gift.CompleteSend(ctx, gift, entries...)
+		if err != nil || !ok {
+			return err
+		}
+		completed = true
+		return s.outbox.Add(ctx, &events.GiftSent{
+			Gift:     giftEvent(gift, *gift.TransferTxnID),
+			Escrowed: recipient == nil,
+		})
+	})
+	if err != nil {
+		// The metal has moved, the sweep records the gift
+		logger.WithError(err).Error("transferred gift not recorded")
+		return err
+	}
+	if !completed {
+		logger.Info("gift already recorded")
+		return nil
+	}
+
+	if recipient != nil && recipient.UserID != nil {
+		s.notify(ctx, *recipient.UserID, "gift_received", map[string]string{
+			"sender":   nameOrMobile(senderUser),
+			"quantity": *gift.Quantity,
+			"metal":    *gift.MetalType,
+		})
+	}
+	return nil
+}
+
+// giftEvent returns the gift of the events of the transfer
+func giftEvent(gift *models.AugmontGift, txnID string) events.Gift {
+	return events.Gift{
+		GiftID:          derefID(gift.ID),
+		SenderID:        derefID(gift.SenderID),
+		RecipientID:     derefID(gift.RecipientID),
+		RecipientMobile: deref(gift.RecipientMobile),
+		MerchantTxnID:   txnID,
+		MetalType:       deref(gift.MetalType),
+		Quantity:        deref(gift.Quantity),
+	}
+}
+
+func nameOrMobile(user *models.User) string {
+	if name := firstName(user.Name); name != "" {
+		return name
+	}
+	return deref(user.Mobile)
+}
+
+func (s *augmontGiftService) notify(ctx context.Context, userID uint64, name string, args map[string]string) {
+	if err := s.notifier.Notify(ctx, userID, name, args); err != nil {
+		domain.Logger(ctx).WithError(err).Warn("gift notification not sent")
+	}
+}
+
+func (s *augmontGiftService) Claim(ctx context.Context, user *models.AugmontUser) ([]*models.AugmontGift, error) {
+	if !kycApproved(user) {
+		return nil, kycRequired()
+	}
+	pinchUser, err := s.user.FindOne(ctx, &models.User{ID: user.UserID})
+	if err != nil {
+		return nil, errors.Wrap(err, "find user")
+	}
+	gifts, err := s.gift.FindEscrowedGifts(ctx, deref(pinchUser.Mobile))
+	if err != nil || len(gifts) == 0 {
+		return nil, err
+	}
+	if _, err := escrowUID(); err != nil {
+		return nil, err
+	}
+
+	claimed := make([]*models.AugmontGift, 0, len(gifts))
+	var transferErr error
+	for _, gift := range gifts {
+		// The id is saved before the transfer so that a retry can not move
+		// the metal twice, of claims racing for a gift only one starts
+		txnID := utils.NewUniqueString(giftTxnIDLen)
+		gift.ClaimTxnID, gift.RecipientID = &txnID, user.ID
+		ok, err := s.gift.StartClaim(ctx, gift)
+		if err != nil {
+			return claimed, err
+		}
+		if !ok {
+			continue
+		}
+
+		if err := s.claim(ctx, gift, user, pinchUser); err != nil {
+			if transferErr == nil {
+				transferErr = err
+			}
+			continue
+		}
+		claimed = append(claimed, gift)
+	}
+	if len(claimed) == 0 && transferErr != nil {
+		return nil, transferErr
+	}
+	return claimed, nil
+}
+
+// Length of the claim transaction ids, like the augmont ones
+const giftTxnIDLen = 30
+
+// How long a send or a claim may be in progress before the sweep
+// retries it, longer than an augmont call takes
+const giftTransferTimeout = 10 * time.Minute
+
+// claim transfers the claiming gift from the escrow to the recipient with
+// its claim transaction id, a transfer Augmont rejected puts the gift back
+// in escrow, one that may have moved the metal leaves it claiming for the
+// sweep
+func (s *augmontGiftService) claim(
+	ctx context.Context,
+	gift *models.AugmontGift,
+	recipient *models.AugmontUser,
+	recipientUser *models.User,
+) error {
+	logger := domain.Logger(ctx).WithFields(log.Fields{"giftID": *gift.ID, "txnID": *gift.ClaimTxnID})
+	escrow, err := escrowUID()
+	if err != nil {
+		return err
+	}
+	transfer := &utils.AugmontTransferInfo{
+		MerchantTnxID: *gift.ClaimTxnID,
+		SenderUID:     escrow,
+		ReceiverUID:   *recipient.UID,
+		MetalType:     *gift.MetalType,
+		Quantity:      *gift.Quantity,
+	}
+	_, err = s.gold.Transfer(ctx, transfer)
+	switch {
+	case err == nil:
+	case transferDone(err):
+		logger.Info("gift claim already transferred")
+	case transferRejected(err):
+		// Nothing moved, back in escrow to be claimed again
+		if _, err := s.gift.CancelClaim(ctx, gift); err != nil {
+			logger.WithError(err).Error("failed claim not put back in escrow")
+		}
+		return err
+	default:
+		logger.WithError(err).Warn("gift claim left for the sweep")
+		return err
+	}
+
+	now, status := time.Now(), models.GiftClaimed
+	gift.Status = &status
+	gift.ClaimedAt = &now
+	quantity, _ := decimal.NewFromString(*gift.Quantity)
+	entry := ledgerEntry(recipient.ID, models.LedgerGiftReceived, *gift.MetalType, quantity, *gift.ClaimTxnID)
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.gift.CompleteClaim(ctx, gift, entry); err != nil {
+			return err
+		}
+		return s.outbox.Add(ctx, &events.GiftClaimed{Gift: giftEvent(gift, *gift.ClaimTxnID)})
+	})
+	if err != nil {
+		// The metal has moved, the sweep records the claim
+		logger.WithError(err).Error("claimed gift not recorded")
+		return err
+	}
+
+	if gift.Sender != nil && gift.Sender.UserID != nil {
+		s.notify(ctx, *gift.Sender.UserID, "gift_claimed", map[string]string{
+			"recipient": nameOrMobile(recipientUser),
+			"quantity":  *gift.Quantity,
+			"metal":     *gift.MetalType,
+		})
+	}
+	return nil
+}
+
+// transferDone reports if Augmont refused the transfer as one it already made
+func transferDone(err error) bool {
+	e, ok := domain.AsError(err)
+	return ok && e.Code() == "augmont.duplicate_transaction"
+}
+
+// transferRejected reports if Augmont answered that it did not make the
+// transfer, failures without an answer may have moved the metal
+func transferRejected(err error) bool {
+	for _, errType := range []int{domain.ErrInvalidArgument, domain.ErrBadRequest, domain.ErrForbidden, domain.ErrNotFound} {
+		if domain.ErrIs(err, errType) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *augmontGiftService) SweepClaims(ctx context.Context) (int, error) {
+	gifts, err := s.gift.FindStaleClaims(ctx, time.Now().Add(-giftTransferTimeout))
+	if err != nil {
+		return 0, err
+	}
+	completed := 0
+	for _, gift := range gifts {
+		if ctx.Err() != nil {
+			return completed, ctx.Err()
+		}
+		logger := domain.Logger(ctx).WithField("giftID", *gift.ID)
+		if gift.ClaimTxnID == nil || gift.RecipientID == nil {
+			logger.Error("claiming gift without a claim transaction, to be resolved by hand")
+			continue
+		}
+		recipient, err := s.augmontUser.FindUser(ctx, &models.AugmontUser{ID: gift.RecipientID})
+		if err != nil {
+			logger.WithError(err).Error("claiming gift without a recipient")
+			continue
+		}
+		recipientUser, err := s.user.FindOne(ctx, &models.User{ID: recipient.UserID})
+		if err != nil {
+			logger.WithError(err).Error("claiming gift without a recipient")
+			continue
+		}
+		// Logged by claim, the gift is retried on the next sweep
+		if err := s.claim(ctx, gift, recipient, recipientUser); err == nil {
+			completed++
+		}
+	}
+	return completed, nil
+}
+
+func (s *augmontGiftService) SweepSends(ctx context.Context) (int, error) {
+	gifts, err := s.gift.FindStaleSends(ctx, time.Now().Add(-giftTransferTimeout))
+	if err != nil {
+		return 0, err
+	}
+	completed := 0
+	for _, gift := range gifts {
+		if ctx.Err() != nil {
+			return completed, ctx.Err()
+		}
+		logger := domain.Logger(ctx).WithField("giftID", *gift.ID)
+		if gift.Sender == nil {
+			logger.Error("sending gift without a sender, to be resolved by hand")
+			continue
+		}
+		senderUser, err := s.user.FindOne(ctx, &models.User{ID: gift.Sender.UserID})
+		if err != nil {
+			logger.WithError(err).Error("sending gift without a sender")
+			continue
+		}
+		var recipient *models.AugmontUser
+		if gift.RecipientID != nil {
+			if recipient, err = s.augmontUser.FindUser(ctx, &models.AugmontUser{ID: gift.RecipientID}); err != nil {
+				logger.WithError(err).Error("sending gift without a recipient")
+				continue
+			}
+		}
+		// Logged by send, the gift is retried on the next sweep
+		if err := s.send(ctx, gift, gift.Sender, senderUser, recipient); err == nil {
+			completed++
+		}
+	}
+	return completed, nil
+}
+
+func (s *augmontGiftService) List(
+	ctx context.Context,
+	user *models.AugmontUser,
+	q *utils.ListQuery,
+) ([]*models.AugmontGift, *utils.Page, error) {
+	pinchUser, err := s.user.FindOne(ctx, &models.User{ID: user.UserID})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "find user")
+	}
+	return s.gift.ListGifts(ctx, *user.ID, deref(pinchUser.Mobile), q)
+}
+
+func (s *augmontGiftService) Ledger(
+	ctx context.Context,
+	user *models.AugmontUser,
+	q *utils.ListQuery,
+) ([]*models.AugmontLedgerEntry, *utils.Page, error) {
+	return s.gift.ListLedger(ctx, *user.ID, q)
+}
@@ -0,0 +1,436 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/configtest"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/events"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+type fakeGiftRepo struct {
+	interfaces.AugmontGiftRepo
+	gifts   []*models.AugmontGift
+	entries []*models.AugmontLedgerEntry
+	// Events added in the units of work of the gifts
+	outbox fakeOutboxRepo
+}
+
+func (r *fakeGiftRepo) CreateGift(ctx context.Context, gift *models.AugmontGift, entries ...*models.AugmontLedgerEntry) error {
+	id := uint64(len(r.gifts) + 1)
+	gift.ID = &id
+	r.gifts = append(r.gifts, gift)
+	r.entries = append(r.entries, entries...)
+	return nil
+}
+
+func (r *fakeGiftRepo) CompleteSend(ctx context.Context, gift *models.AugmontGift, entries ...*models.AugmontLedgerEntry) (bool, error) {
+	for _, e := range r.entries {
+		if *e.MerchantTxnID == *gift.TransferTxnID {
+			return false, nil
+		}
+	}
+	r.entries = append(r.entries, entries...)
+	return true, nil
+}
+
+func (r *fakeGiftRepo) FailSend(ctx context.Context, gift *models.AugmontGift) (bool, error) {
+	if *gift.Status != models.GiftSending {
+		return false, nil
+	}
+	status := models.GiftFailed
+	gift.Status = &status
+	return true, nil
+}
+
+func (r *fakeGiftRepo) FindSendingGifts(ctx context.Context, senderID uint64) ([]*models.AugmontGift, error) {
+	var found []*models.AugmontGift
+	for _, g := range r.gifts {
+		if *g.SenderID == senderID && *g.Status == models.GiftSending {
+			found = append(found, g)
+		}
+	}
+	return found, nil
+}
+
+func (r *fakeGiftRepo) FindStaleSends(ctx context.Context, before time.Time) ([]*models.AugmontGift, error) {
+	var found []*models.AugmontGift
+	for _, g := range r.gifts {
+		if *g.Status == models.GiftSending {
+			found = append(found, g)
+		}
+	}
+	return found, nil
+}
+
+func (r *fakeGiftRepo) StartClaim(ctx context.Context, gift *models.AugmontGift) (bool, error) {
+	if *gift.Status != models.GiftEscrowed {
+		return false, nil
+	}
+	status := models.GiftClaiming
+	gift.Status = &status
+	return true, nil
+}
+
+func (r *fakeGiftRepo) CancelClaim(ctx context.Context, gift *models.AugmontGift) (bool, error) {
+	if *gift.Status != models.GiftClaiming {
+		return false, nil
+	}
+	status := models.GiftEscrowed
+	gift.Status, gift.ClaimTxnID, gift.RecipientID = &status, nil, nil
+	return true, nil
+}
+
+func (r *fakeGiftRepo) CompleteClaim(ctx context.Context, gift *models.AugmontGift, entry *models.AugmontLedgerEntry) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *fakeGiftRepo) FindEscrowedGifts(ctx context.Context, mobile string) ([]*models.AugmontGift, error) {
+	var found []*models.AugmontGift
+	for _, g := range r.gifts {
+		if *g.RecipientMobile == mobile && *g.Status == models.GiftEscrowed {
+			found = append(found, g)
+		}
+	}
+	return found, nil
+}
+
+func (r *fakeGiftRepo) FindStaleClaims(ctx context.Context, before time.Time) ([]*models.AugmontGift, error) {
+	var found []*models.AugmontGift
+	for _, g := range r.gifts {
+		if *g.Status == models.GiftClaiming {
+			found = append(found, g)
+		}
+	}
+	return found, nil
+}
+
+// fakeGiftUsers are the Pinch and Augmont users, by index
+type fakeGiftUsers struct {
+	interfaces.UserRepo
+	interfaces.AugmontUserRepo
+	users   []*models.User
+	agUsers []*models.AugmontUser
+}
+
+func (u *fakeGiftUsers) FindOne(ctx context.Context, user *models.User) (*models.User, error) {
+	for _, found := range u.users {
+		if *found.ID == *user.ID {
+			return found, nil
+		}
+	}
+	return nil, errors.New("record not found")
+}
+
+func (u *fakeGiftUsers) FindMany(ctx context.Context, user *models.User) ([]*models.User, error) {
+	for _, found := range u.users {
+		if *found.Mobile == *user.Mobile {
+			return []*models.User{found}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (u *fakeGiftUsers) FindUsers(ctx context.Context, user *models.AugmontUser) ([]*models.AugmontUser, error) {
+	for _, found := range u.agUsers {
+		if *found.UserID == *user.UserID {
+			return []*models.AugmontUser{found}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (u *fakeGiftUsers) FindUser(ctx context.Context, user *models.AugmontUser) (*models.AugmontUser, error) {
+	for _, found := range u.agUsers {
+		if *found.ID == *user.ID {
+			return found, nil
+		}
+	}
+	return nil, errors.New("record not found")
+}
+
+func (u *fakeGiftUsers) add(id uint64, mobile, name, kyc string) *models.AugmontUser {
+	uid := "U" + mobile
+	u.users = append(u.users, &models.User{ID: &id, Mobile: &mobile, Name: &name})
+	agUser := &models.AugmontUser{ID: &id, UserID: &id, UID: &uid}
+	if kyc != "" {
+		agUser.KYCStatus = &kyc
+	}
+	u.agUsers = append(u.agUsers, agUser)
+	return agUser
+}
+
+// fakeGiftOrders holds 1 g of gold for every user
+type fakeGiftOrders struct {
+	interfaces.AugmontOrderRepo
+}
+
+func (r *fakeGiftOrders) FindOrdersBetween(ctx context.Context, augmontUserID uint64, from, to time.Time) ([]*models.AugmontOrder, error) {
+	created, buy := time.Now().AddDate(0, -1, 0), models.OrderBuy
+	metal, quantity, amount, status := "gold", "1", "5000", models.OrderCompleted
+	return []*models.AugmontOrder{{
+		CreatedAt:     &created,
+		Type:          &buy,
+		AugmontUserID: &augmontUserID,
+		AugmontOrderInfo: models.AugmontOrderInfo{
+			MetalType: &metal,
+			Quantity:  &quantity,
+			Amount:    &amount,
+			Status:    &status,
+		},
+	}}, nil
+}
+
+type fakeTransferGold struct {
+	interfaces.AugmontService
+	transfers []*utils.AugmontTransferInfo
+	err       error
+}
+
+func (g *fakeTransferGold) Transfer(ctx context.Context, info *utils.AugmontTransferInfo) (utils.Any, error) {
+	if info.MerchantTnxID == "" {
+		info.MerchantTnxID = "T" + string(rune('0'+len(g.transfers)))
+	}
+	g.transfers = append(g.transfers, info)
+	return nil, g.err
+}
+
+func TestAugmontGiftService(t *testing.T) {
+	configtest.Use(t)
+	augmont := &domain.Config().Augmont
+	augmont.EscrowUID = "ESCROW"
+
+	ctx := context.Background()
+	setup := func() (*fakeGiftUsers, *fakeGiftRepo, *fakeTransferGold, *fakeNotifier, interfaces.AugmontGiftService) {
+		users, gifts, gold, notifier := &fakeGiftUsers{}, &fakeGiftRepo{}, &fakeTransferGold{}, &fakeNotifier{}
+		return users, gifts, gold, notifier, NewAugmontGiftService(gifts, users, users, gold, &fakeGiftOrders{}, notifier, &gifts.outbox, fakeUnitOfWork{})
+	}
+	gift := func(mobile string) *utils.AugmontGiftInfo {
+		return &utils.AugmontGiftInfo{MobileNo: mobile, MetalType: "gold", Quantity: "0.5", Message: "Happy Diwali"}
+	}
+
+	t.Run("should transfer to recipients with KYC", func(t *testing.T) {
+		users, gifts, gold, notifier, s := setup()
+		sender := users.add(1, "9000000001", "Asha Rao", models.KYCApproved)
+		recipient := users.add(2, "9000000002", "Ravi", models.KYCApproved)
+
+		sent, err := s.Send(ctx, sender, gift("9000000002"))
+		assert.NoError(t, err)
+		assert.Equal(t, models.GiftCompleted, *sent.Status)
+		assert.Equal(t, *recipient.UID, gold.transfers[0].ReceiverUID)
+		if assert.Len(t, gifts.entries, 2) {
+			assert.Equal(t, "-0.5", *gifts.entries[0].Quantity)
+			assert.Equal(t, "0.5", *gifts.entries[1].Quantity)
+			assert.Equal(t, *recipient.ID, *gifts.entries[1].AugmontUserID)
+		}
+		assert.Equal(t, []string{"gift_received"}, notifier.sent)
+		assert.Equal(t, []events.Event{&events.GiftSent{Gift: events.Gift{
+			GiftID:          1,
+			SenderID:        *sender.ID,
+			RecipientID:     *recipient.ID,
+			RecipientMobile: "9000000002",
+			MerchantTxnID:   gold.transfers[0].MerchantTnxID,
+			MetalType:       "gold",
+			Quantity:        "0.5",
+		}}}, gifts.outbox.added)
+	})
+
+	t.Run("should escrow gifts until claimed", func(t *testing.T) {
+		users, gifts, gold, notifier, s := setup()
+		sender := users.add(1, "9000000001", "Asha", models.KYCApproved)
+
+		sent, err := s.Send(ctx, sender, gift("9000000003"))
+		assert.NoError(t, err)
+		assert.Equal(t, models.GiftEscrowed, *sent.Status)
+		assert.Equal(t, "ESCROW", gold.transfers[0].ReceiverUID)
+		assert.Len(t, gifts.entries, 1)
+		sent.Sender = sender
+
+		recipient := users.add(3, "9000000003", "Meena", models.KYCPending)
+		_, err = s.Claim(ctx, recipient)
+		assert.True(t, domain.ErrIs(err, domain.ErrForbidden))
+
+		approved := models.KYCApproved
+		recipient.KYCStatus = &approved
+		claimed, err := s.Claim(ctx, recipient)
+		assert.NoError(t, err)
+		if assert.Len(t, claimed, 1) {
+			assert.Equal(t, models.GiftClaimed, *claimed[0].Status)
+			assert.Equal(t, *recipient.ID, *claimed[0].RecipientID)
+		}
+		assert.Equal(t, "ESCROW", gold.transfers[1].SenderUID)
+		assert.Equal(t, *claimed[0].ClaimTxnID, gold.transfers[1].MerchantTnxID)
+		assert.Len(t, gifts.entries, 2)
+		assert.Equal(t, []string{"gift_claimed"}, notifier.sent)
+		if assert.Len(t, gifts.outbox.added, 2) {
+			assert.True(t, gifts.outbox.added[0].(*events.GiftSent).Escrowed)
+			claim := gifts.outbox.added[1].(*events.GiftClaimed)
+			assert.Equal(t, *recipient.ID, claim.RecipientID)
+			assert.Equal(t, *claimed[0].ClaimTxnID, claim.MerchantTxnID)
+		}
+
+		// Nothing is left to claim
+		claimed, err = s.Claim(ctx, recipient)
+		assert.NoError(t, err)
+		assert.Empty(t, claimed)
+	})
+
+	escrowed := func(t *testing.T) (*fakeGiftUsers, *fakeGiftRepo, *fakeTransferGold, interfaces.AugmontGiftService, *models.AugmontGift) {
+		users, gifts, gold, _, s := setup()
+		sender := users.add(1, "9000000001", "Asha", models.KYCApproved)
+		sent, err := s.Send(ctx, sender, gift("9000000003"))
+		assert.NoError(t, err)
+		return users, gifts, gold, s, sent
+	}
+
+	t.Run("should put rejected claims back in escrow", func(t *testing.T) {
+		users, _, gold, s, sent := escrowed(t)
+		recipient := users.add(3, "9000000003", "Meena", models.KYCApproved)
+
+		gold.err = augmontError(400, "Insufficient balance", nil)
+		_, err := s.Claim(ctx, recipient)
+		assert.Error(t, err)
+		assert.Equal(t, models.GiftEscrowed, *sent.Status)
+		assert.Nil(t, sent.ClaimTxnID)
+	})
+
+	t.Run("should leave unanswered claims to the sweep", func(t *testing.T) {
+		users, gifts, gold, s, sent := escrowed(t)
+		recipient := users.add(3, "9000000003", "Meena", models.KYCApproved)
+
+		gold.err = domain.NewError(errors.New("augmont down"), domain.ErrUnavailable)
+		_, err := s.Claim(ctx, recipient)
+		assert.Error(t, err)
+		assert.Equal(t, models.GiftClaiming, *sent.Status)
+		txnID := *sent.ClaimTxnID
+
+		// Not claimed again while the transfer may have happened
+		claimed, err := s.Claim(ctx, recipient)
+		assert.NoError(t, err)
+		assert.Empty(t, claimed)
+
+		gold.err = nil
+		completed, err := s.SweepClaims(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, completed)
+		assert.Equal(t, models.GiftClaimed, *sent.Status)
+		assert.Len(t, gold.transfers, 3)
+		assert.Equal(t, txnID, gold.transfers[2].MerchantTnxID)
+		assert.Len(t, gifts.entries, 2)
+	})
+
+	t.Run("should complete claims augmont already made", func(t *testing.T) {
+		users, gifts, gold, s, sent := escrowed(t)
+		recipient := users.add(3, "9000000003", "Meena", models.KYCApproved)
+
+		gold.err = errors.New("timeout")
+		_, err := s.Claim(ctx, recipient)
+		assert.Error(t, err)
+
+		gold.err = augmontError(400, "Validation failed", map[string]interface{}{
+			"merchantTransactionId": "merchantTransactionId already exists",
+		})
+		completed, err := s.SweepClaims(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, completed)
+		assert.Equal(t, models.GiftClaimed, *sent.Status)
+		assert.Len(t, gifts.entries, 2)
+	})
+
+	t.Run("should refuse senders without KYC or balance", func(t *testing.T) {
+		users, _, gold, _, s := setup()
+		sender := users.add(1, "9000000001", "Asha", models.KYCPending)
+		_, err := s.Send(ctx, sender, gift("9000000002"))
+		assert.True(t, domain.ErrIs(err, domain.ErrForbidden))
+
+		approved := models.KYCApproved
+		sender.KYCStatus = &approved
+		info := gift("9000000002")
+		info.Quantity = "1.5"
+		_, err = s.Send(ctx, sender, info)
+		e, _ := domain.AsError(err)
+		assert.Equal(t, "augmont.insufficient_balance", e.Code())
+		assert.Empty(t, gold.transfers)
+	})
+
+	t.Run("should fail gifts augmont rejects", func(t *testing.T) {
+		users, gifts, gold, _, s := setup()
+		sender := users.add(1, "9000000001", "Asha", models.KYCApproved)
+		users.add(2, "9000000002", "Ravi", models.KYCApproved)
+
+		gold.err = augmontError(400, "Insufficient balance", nil)
+		_, err := s.Send(ctx, sender, gift("9000000002"))
+		assert.Error(t, err)
+		if assert.Len(t, gifts.gifts, 1) {
+			assert.Equal(t, models.GiftFailed, *gifts.gifts[0].Status)
+		}
+		assert.Empty(t, gifts.entries)
+		assert.Empty(t, gifts.outbox.added)
+	})
+
+	t.Run("should leave unanswered gifts to the sweep", func(t *testing.T) {
+		users, gifts, gold, notifier, s := setup()
+		sender := users.add(1, "9000000001", "Asha", models.KYCApproved)
+		users.add(2, "9000000002", "Ravi", models.KYCApproved)
+
+		gold.err = domain.NewError(errors.New("augmont down"), domain.ErrUnavailable)
+		_, err := s.Send(ctx, sender, gift("9000000002"))
+		assert.Error(t, err)
+		if !assert.Len(t, gifts.gifts, 1) {
+			return
+		}
+		sent := gifts.gifts[0]
+		assert.Equal(t, models.GiftSending, *sent.Status)
+		assert.Equal(t, *sent.TransferTxnID, gold.transfers[0].MerchantTnxID)
+		assert.Empty(t, gifts.entries)
+
+		// Not sent again while the transfer may have happened
+		_, err = s.Send(ctx, sender, gift("9000000002"))
+		assert.True(t, domain.ErrIs(err, domain.ErrConflict))
+		assert.Len(t, gold.transfers, 1)
+
+		gold.err = nil
+		sent.Sender = sender
+		completed, err := s.SweepSends(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, completed)
+		assert.Equal(t, models.GiftCompleted, *sent.Status)
+		assert.Equal(t, *sent.TransferTxnID, gold.transfers[1].MerchantTnxID)
+		assert.Len(t, gifts.entries, 2)
+		assert.Len(t, gifts.outbox.added, 1)
+		assert.Equal(t, []string{"gift_received"}, notifier.sent)
+	})
+
+	t.Run("should refuse gifts to the own mobile number", func(t *testing.T) {
+		users, _, gold, _, s := setup()
+		sender := users.add(1, "9000000001", "Asha", models.KYCApproved)
+
+		_, err := s.Send(ctx, sender, gift("9000000001"))
+		assert.Equal(t, "self_gift", fieldCode(t, err))
+		assert.Empty(t, gold.transfers)
+	})
+
+	t.Run("should look up recipients", func(t *testing.T) {
+		users, _, _, _, s := setup()
+		sender := users.add(1, "9000000001", "Asha", models.KYCApproved)
+		users.add(2, "9000000002", "Ravi Kumar", models.KYCApproved)
+
+		r, err := s.FindRecipient(ctx, sender, "9000000002")
+		assert.NoError(t, err)
+		assert.Equal(t, &models.GiftRecipient{Mobile: "9000000002", Name: "Ravi", OnPinch: true}, r)
+
+		r, err = s.FindRecipient(ctx, sender, "9000000009")
+		assert.NoError(t, err)
+		assert.False(t, r.OnPinch)
+		assert.True(t, r.Escrowed)
+	})
+}
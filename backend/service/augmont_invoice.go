@@ -0,0 +1,135 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/document"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/invoice"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+type augmontInvoiceService struct {
+	invoice interfaces.AugmontInvoiceRepo
+	order   interfaces.AugmontOrderRepo
+	gold    interfaces.AugmontService
+	user    interfaces.UserRepo
+	storage interfaces.FileStorage
+}
+
+// NewAugmontInvoiceService creates a new AugmontInvoiceService
+func NewAugmontInvoiceService(
+	invoiceRepo interfaces.AugmontInvoiceRepo,
+	order interfaces.AugmontOrderRepo,
+	gold interfaces.AugmontService,
+	user interfaces.UserRepo,
+	storage interfaces.FileStorage,
+) interfaces.AugmontInvoiceService {
+	return &augmontInvoiceService{
+		invoice: invoiceRepo,
+		order:   order,
+		gold:    gold,
+		user:    user,
+		storage: storage,
+	}
+}
+
+func (s *augmontInvoiceService) Fetch(
+	ctx context.Context,
+	user *models.AugmontUser,
+	txnID string,
+) (*models.AugmontInvoice, error) {
+	stored, err := s.invoice.FindByTxnID(ctx, *user.ID, txnID)
+	if err != nil || stored != nil {
+		return stored, err
+	}
+
+	order, err := s.order.FindOrder(ctx, *user.ID, txnID)
+	if err != nil {
+		return nil, err
+	}
+	if *order.Type != models.OrderBuy && *order.Type != models.OrderRedeem {
+		return nil, domain.NewError(errors.Newf("no invoice for %v orders", *order.Type), domain.ErrInvalidArgument, "only buy and redeem orders have invoices")
+	}
+	if order.Status == nil || *order.Status != models.OrderCompleted {
+		return nil, domain.NewError(errors.Newf("order %v is not completed", txnID), domain.ErrInvalidArgument, "the order is not completed")
+	}
+
+	result, err := s.gold.Invoice(ctx, txnID)
+	if err != nil {
+		return nil, err
+	}
+	data, ok := result.(map[string]interface{})
+	if !ok {
+		return nil, domain.NewError(errors.Newf("no invoice data for %v", txnID), domain.ErrUnavailable, "the invoice is not available yet")
+	}
+
+	pinchUser, err := s.user.FindOne(ctx, &models.User{ID: user.UserID})
+	if err != nil {
+		return nil, errors.Wrap(err, "find user")
+	}
+	holder := document.Holder{
+		Name:      deref(pinchUser.Name),
+		Mobile:    deref(pinchUser.Mobile),
+		AccountID: deref(user.UID),
+	}
+	inv := invoice.Parse(data, txnID)
+
+	// The data is kept as sent, the PDF can be rendered again from it
+	dir := fmt.Sprintf("invoices/%d/%v/", *user.ID, txnID)
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "encode invoice data")
+	}
+	dataKey := dir + "invoice.json"
+	if err := s.storage.Put(ctx, dataKey, bytes.NewReader(raw)); err != nil {
+		return nil, errors.Wrap(err, "store invoice data")
+	}
+
+	buf := &bytes.Buffer{}
+	if err := invoice.WritePDF(buf, holder, inv); err != nil {
+		return nil, errors.Wrap(err, "render invoice")
+	}
+	fileKey := dir + "pinch-invoice-" + txnID + ".pdf"
+	if err := s.storage.Put(ctx, fileKey, buf); err != nil {
+		return nil, errors.Wrap(err, "store invoice")
+	}
+
+	err = s.invoice.Create(ctx, &models.AugmontInvoice{
+		AugmontUserID: user.ID,
+		MerchantTxnID: &txnID,
+		OrderType:     order.Type,
+		InvoiceNumber: &inv.Number,
+		InvoiceDate:   &inv.Date,
+		DataKey:       &dataKey,
+		FileKey:       &fileKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Read back, the invoice may have been stored concurrently
+	return s.invoice.FindByTxnID(ctx, *user.ID, txnID)
+}
+
+func (s *augmontInvoiceService) Find(
+	ctx context.Context,
+	user *models.AugmontUser,
+	txnID string,
+) (*models.AugmontInvoice, string, error) {
+	inv, err := s.Fetch(ctx, user, txnID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	url, err := s.storage.SignedURL(ctx, *inv.FileKey, domain.Config().Storage.URLExpiry)
+	if err != nil {
+		return nil, "", err
+	}
+	return inv, url, nil
+}
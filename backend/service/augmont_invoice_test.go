@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+type fakeInvoiceRepo struct {
+	interfaces.AugmontInvoiceRepo
+	invoices map[string]*models.AugmontInvoice
+}
+
+func (r *fakeInvoiceRepo) Create(ctx context.Context, invoice *models.AugmontInvoice) error {
+	r.invoices[*invoice.MerchantTxnID] = invoice
+	return nil
+}
+
+func (r *fakeInvoiceRepo) FindByTxnID(ctx context.Context, augmontUserID uint64, txnID string) (*models.AugmontInvoice, error) {
+	return r.invoices[txnID], nil
+}
+
+type fakeInvoiceGold struct {
+	interfaces.AugmontService
+	calls int
+}
+
+func (g *fakeInvoiceGold) Invoice(ctx context.Context, tnxID string) (utils.Any, error) {
+	g.calls++
+	return map[string]interface{}{"invoiceNumber": "INV1", "quantity": "0.1"}, nil
+}
+
+type fakeUserRepo struct {
+	interfaces.UserRepo
+}
+
+func (fakeUserRepo) FindOne(ctx context.Context, user *models.User) (*models.User, error) {
+	name := "Asha"
+	return &models.User{ID: user.ID, Name: &name}, nil
+}
+
+type fakeStorage struct {
+	interfaces.FileStorage
+	files map[string][]byte
+}
+
+func (s *fakeStorage) Put(ctx context.Context, key string, r io.Reader) error {
+	b, err := ioutil.ReadAll(r)
+	s.files[key] = b
+	return err
+}
+
+func TestAugmontInvoiceServiceFetch(t *testing.T) {
+	id, uid, txnID, status := uint64(1), "U1", "T1", models.OrderCompleted
+	user := &models.AugmontUser{ID: &id, UID: &uid}
+	order := func(orderType string) *fakeOrderRepo {
+		return &fakeOrderRepo{order: &models.AugmontOrder{
+			MerchantTxnID:    &txnID,
+			Type:             &orderType,
+			AugmontOrderInfo: models.AugmontOrderInfo{Status: &status},
+		}}
+	}
+
+	t.Run("should store the data and the PDF once", func(t *testing.T) {
+		gold := &fakeInvoiceGold{}
+		invoices := &fakeInvoiceRepo{invoices: map[string]*models.AugmontInvoice{}}
+		storage := &fakeStorage{files: map[string][]byte{}}
+		s := NewAugmontInvoiceService(invoices, order(models.OrderBuy), gold, fakeUserRepo{}, storage)
+
+		for i := 0; i < 2; i++ {
+			invoice, err := s.Fetch(context.Background(), user, txnID)
+			assert.NoError(t, err)
+			assert.Equal(t, "INV1", *invoice.InvoiceNumber)
+		}
+		assert.Equal(t, 1, gold.calls)
+		assert.Contains(t, string(storage.files["invoices/1/T1/invoice.json"]), `"invoiceNumber":"INV1"`)
+		assert.Contains(t, storage.files, "invoices/1/T1/pinch-invoice-T1.pdf")
+	})
+
+	t.Run("should refuse sell orders", func(t *testing.T) {
+		gold := &fakeInvoiceGold{}
+		invoices := &fakeInvoiceRepo{invoices: map[string]*models.AugmontInvoice{}}
+		s := NewAugmontInvoiceService(invoices, order(models.OrderSell), gold, fakeUserRepo{}, nil)
+
+		_, err := s.Fetch(context.Background(), user, txnID)
+		e, ok := domain.AsError(err)
+		assert.True(t, ok)
+		assert.Equal(t, domain.ErrInvalidArgument, e.Type())
+		assert.Equal(t, 0, gold.calls)
+	})
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+)
+
+// checkOrderLimits checks a buy or sell against the limits of its metal,
+// orders by quantity are valued at the locked rate and orders by amount
+// are weighed at it, the errors are reported on the field sent
+func checkOrderLimits(metal, quantity, amount, rate string) error {
+	limits := domain.Config().Limits
+	lockRate, _ := decimal.NewFromString(rate)
+
+	field := "amount"
+	value, _ := decimal.NewFromString(amount)
+	grams := decimal.Zero
+	if amount == "" {
+		field = "quantity"
+		grams, _ = decimal.NewFromString(quantity)
+		value = grams.Mul(lockRate)
+	} else if lockRate.IsPositive() {
+		grams = value.Div(lockRate)
+	}
+
+	limit := func(limits map[string]float64) (decimal.Decimal, bool) {
+		l, ok := limits[metal]
+		return decimal.NewFromFloat(l), ok
+	}
+	if min, ok := limit(limits.MinAmount); ok && value.LessThan(min) {
+		return limitError(metal, field, "min_amount", min)
+	}
+	if max, ok := limit(limits.MaxAmount); ok && value.GreaterThan(max) {
+		return limitError(metal, field, "max_amount", max)
+	}
+	if max, ok := limit(limits.MaxQuantity); ok && grams.GreaterThan(max) {
+		return limitError(metal, field, "max_quantity", max)
+	}
+	return nil
+}
+
+func limitError(metal, field, code string, limit decimal.Decimal) error {
+	param := limit.String()
+	err := errors.Newf("%v order out of limits, %v %v", metal, code, param)
+	return invalidField(err, field, code, param)
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/configtest"
+)
+
+func TestCheckOrderLimits(t *testing.T) {
+	configtest.Use(t)
+	limits := &domain.Config().Limits
+	limits.MinAmount = map[string]float64{"gold": 10, "silver": 10}
+	limits.MaxAmount = map[string]float64{"gold": 200000}
+	limits.MaxQuantity = map[string]float64{"gold": 30, "silver": 2000}
+
+	fieldCode := func(err error) (string, string) {
+		e, ok := domain.AsError(err)
+		if !assert.True(t, ok) || !assert.Len(t, e.Fields(), 1) {
+			return "", ""
+		}
+		return e.Fields()[0].Field, e.Fields()[0].Code
+	}
+
+	t.Run("should accept orders within the limits", func(t *testing.T) {
+		assert.NoError(t, checkOrderLimits("gold", "", "500", "6000"))
+		assert.NoError(t, checkOrderLimits("silver", "1000", "", "80"))
+	})
+
+	t.Run("should value quantity orders at the locked rate", func(t *testing.T) {
+		field, code := fieldCode(checkOrderLimits("gold", "0.001", "", "6000"))
+		assert.Equal(t, "quantity", field)
+		assert.Equal(t, "min_amount", code)
+	})
+
+	t.Run("should apply the limits of the metal", func(t *testing.T) {
+		field, code := fieldCode(checkOrderLimits("silver", "", "240000", "80"))
+		assert.Equal(t, "amount", field)
+		assert.Equal(t, "max_quantity", code)
+
+		_, code = fieldCode(checkOrderLimits("gold", "", "250000", "6000"))
+		assert.Equal(t, "max_amount", code)
+	})
+}
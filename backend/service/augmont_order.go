@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/tax"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+// How long the augmont detail of an order is cached, redeem
+// orders are cached shortly as their shipping status changes
+const (
+	orderDetailTTL  = 24 * time.Hour
+	redeemDetailTTL = 10 * time.Minute
+)
+
+type augmontOrderService struct {
+	order interfaces.AugmontOrderRepo
+	gold  interfaces.AugmontService
+	inMem interfaces.AugmontInMemRepo
+}
+
+// NewAugmontOrderService creates a new AugmontOrderService
+func NewAugmontOrderService(
+	order interfaces.AugmontOrderRepo,
+	gold interfaces.AugmontService,
+	inMem interfaces.AugmontInMemRepo,
+) interfaces.AugmontOrderService {
+	return &augmontOrderService{
+		order: order,
+		gold:  gold,
+		inMem: inMem,
+	}
+}
+
+func (s *augmontOrderService) List(
+	ctx context.Context,
+	user *models.AugmontUser,
+	q *utils.ListQuery,
+) ([]*models.AugmontOrder, *utils.Page, error) {
+	return s.order.ListOrders(ctx, *user.ID, q)
+}
+
+func (s *augmontOrderService) Find(
+	ctx context.Context,
+	user *models.AugmontUser,
+	txnID string,
+) (*models.AugmontOrder, utils.Any, error) {
+	order, err := s.order.FindOrder(ctx, *user.ID, txnID)
+	if err != nil {
+		return nil, nil, err
+	}
+	detail, err := s.detail(ctx, user, order)
+	if err != nil {
+		return nil, nil, err
+	}
+	return order, detail, nil
+}
+
+// detail returns the augmont detail of the order from the cache or
+// from augmont, failures are logged and served without the detail
+func (s *augmontOrderService) detail(
+	ctx context.Context,
+	user *models.AugmontUser,
+	order *models.AugmontOrder,
+) (utils.Any, error) {
+	log := domain.Logger(ctx).WithField("merchantTxnID", *order.MerchantTxnID)
+
+	detail, err := s.inMem.GetOrderDetail(ctx, *order.MerchantTxnID)
+	if err != nil {
+		log.WithError(err).Warn("order detail cache unavailable")
+	}
+	if detail != nil {
+		return detail, nil
+	}
+
+	ttl := orderDetailTTL
+	if *order.Type == models.OrderRedeem {
+		ttl = redeemDetailTTL
+	}
+	detail, err = s.fetchDetail(ctx, user, order)
+	if err != nil {
+		// The client went away, the order is not needed anymore
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		log.WithError(err).Warn("augmont order detail unavailable")
+		return nil, nil
+	}
+
+	if err := s.inMem.SetOrderDetail(ctx, *order.MerchantTxnID, detail, ttl); err != nil {
+		log.WithError(err).Warn("order detail not cached")
+	}
+	return detail, nil
+}
+
+// fetchDetail returns the augmont detail of the order from augmont
+func (s *augmontOrderService) fetchDetail(
+	ctx context.Context,
+	user *models.AugmontUser,
+	order *models.AugmontOrder,
+) (utils.Any, error) {
+	switch *order.Type {
+	case models.OrderBuy:
+		return s.gold.BuyInfo(ctx, *user.UID, *order.MerchantTxnID)
+	case models.OrderSell:
+		return s.gold.SellInfo(ctx, *user.UID, *order.MerchantTxnID)
+	case models.OrderRedeem:
+		return s.gold.RedeemInfo(ctx, *user.UID, *order.MerchantTxnID)
+	}
+	err := errors.Newf("no augmont detail of %q orders", *order.Type)
+	return nil, domain.NewError(err, domain.ErrInvalidArgument)
+}
+
+func (s *augmontOrderService) Backfill(
+	ctx context.Context,
+	user *models.AugmontUser,
+	order *models.AugmontOrder,
+) error {
+	detail, err := s.fetchDetail(ctx, user, order)
+	if err != nil {
+		return err
+	}
+	info := orderInfo(detail, "", "", "", "")
+	fill := func(have **string, sent *string) {
+		if *have == nil {
+			*have = sent
+		}
+	}
+	fill(&order.MetalType, info.MetalType)
+	fill(&order.Quantity, info.Quantity)
+	fill(&order.Amount, info.Amount)
+	fill(&order.Rate, info.Rate)
+	return s.order.FillOrder(ctx, order)
+}
+
+func (s *augmontOrderService) Portfolio(
+	ctx context.Context,
+	user *models.AugmontUser,
+) ([]*utils.MetalHolding, error) {
+	orders, err := s.order.FindOrdersBetween(ctx, *user.ID, time.Time{}, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	held := tax.Holdings(orders)
+
+	rates, err := s.gold.Rates(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		domain.Logger(ctx).WithError(err).Warn("augmont rates unavailable")
+	}
+
+	holdings := make([]*utils.MetalHolding, 0, len(utils.Metals))
+	for _, metal := range utils.Metals {
+		h := held[string(metal)]
+		holding := &utils.MetalHolding{
+			Metal:    metal,
+			Quantity: h.Quantity,
+			Invested: h.Cost,
+		}
+		if rates != nil {
+			if rate := rates.Rate(metal); rate != nil {
+				value := rate.Sell.Mul(h.Quantity).Round(2)
+				holding.SellRate = &rate.Sell
+				holding.Value = &value
+			}
+		}
+		holdings = append(holdings, holding)
+	}
+	return holdings, nil
+}
+
+// orderInfo returns the local part of an order from the augmont
+// result, the requested values are kept if the result lacks them
+func orderInfo(result utils.Any, metal, quantity, amount, rate string) models.AugmontOrderInfo {
+	data := resultData(result)
+	value := func(key, requested string, numeric bool) *string {
+		if v, ok := data[key]; ok && v != nil {
+			s := fmt.Sprint(v)
+			// A malformed value must not fail the insert of a placed order
+			if _, err := strconv.ParseFloat(s, 64); !numeric || err == nil {
+				return &s
+			}
+		}
+		if requested == "" {
+			return nil
+		}
+		return &requested
+	}
+
+	status := models.OrderCompleted
+	return models.AugmontOrderInfo{
+		Status:    &status,
+		MetalType: value("metalType", metal, false),
+		Quantity:  value("quantity", quantity, true),
+		Amount:    value("totalAmount", amount, true),
+		Rate:      value("rate", rate, true),
+	}
+}
+
+// failedOrderInfo returns the local part of an order Augmont refused,
+// with the requested values
+func failedOrderInfo(metal, quantity, amount, rate string) models.AugmontOrderInfo {
+	info := orderInfo(nil, metal, quantity, amount, rate)
+	status := models.OrderFailed
+	info.Status = &status
+	return info
+}
+
+// redeemMetals returns the metals delivered by a redeem order from the
+// grams of each metal of its products, in the order of utils.Metals
+func redeemMetals(weights map[string]decimal.Decimal) []*models.AugmontRedeemMetal {
+	var metals []*models.AugmontRedeemMetal
+	for _, metal := range utils.Metals {
+		grams := weights[string(metal)]
+		if !grams.IsPositive() {
+			continue
+		}
+		metals = append(metals, &models.AugmontRedeemMetal{
+			MetalType: strPtr(string(metal)),
+			Quantity:  strPtr(grams.String()),
+		})
+	}
+	return metals
+}
+
+// resultData returns the data of an augmont result, empty if it has none
+func resultData(result utils.Any) map[string]interface{} {
+	res, _ := result.(map[string]interface{})
+	data, _ := res["data"].(map[string]interface{})
+	return data
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+type fakeOrderRepo struct {
+	interfaces.AugmontOrderRepo
+	order  *models.AugmontOrder
+	orders []*models.AugmontOrder
+	filled []*models.AugmontOrder
+}
+
+func (r *fakeOrderRepo) FillOrder(ctx context.Context, order *models.AugmontOrder) error {
+	r.filled = append(r.filled, order)
+	return nil
+}
+
+func (r *fakeOrderRepo) FindOrdersBetween(ctx context.Context, augmontUserID uint64, from, to time.Time) ([]*models.AugmontOrder, error) {
+	return r.orders, nil
+}
+
+// CreateRedeem adds a row for each metal of the order, like the orders union
+func (r *fakeOrderRepo) CreateRedeem(ctx context.Context, order *models.AugmontRedeemOrder) error {
+	orderType, created := models.OrderRedeem, time.Now()
+	for _, m := range order.Metals {
+		info := order.AugmontOrderInfo
+		info.MetalType, info.Quantity = m.MetalType, m.Quantity
+		r.orders = append(r.orders, &models.AugmontOrder{
+			CreatedAt:        &created,
+			Type:             &orderType,
+			MerchantTxnID:    order.MerchantTxnID,
+			AugmontUserID:    order.AugmontUserID,
+			AugmontOrderInfo: info,
+		})
+	}
+	return nil
+}
+
+func (r *fakeOrderRepo) CreateBuy(ctx context.Context, order *models.AugmontBuyOrder) error {
+	orderType, created := models.OrderBuy, time.Now()
+	r.orders = append(r.orders, &models.AugmontOrder{
+		CreatedAt:        &created,
+		Type:             &orderType,
+		MerchantTxnID:    order.MerchantTxnID,
+		AugmontUserID:    order.AugmontUserID,
+		AugmontOrderInfo: order.AugmontOrderInfo,
+	})
+	return nil
+}
+
+func (r *fakeOrderRepo) FindOrder(ctx context.Context, augmontUserID uint64, txnID string) (*models.AugmontOrder, error) {
+	return r.order, nil
+}
+
+type fakeOrderGold struct {
+	interfaces.AugmontService
+	calls  int
+	detail utils.Any
+	rates  *utils.MetalRates
+	err    error
+}
+
+func (g *fakeOrderGold) Rates(ctx context.Context) (*utils.MetalRates, error) {
+	return g.rates, g.err
+}
+
+func (g *fakeOrderGold) BuyInfo(ctx context.Context, userUniqueID, tnxID string) (utils.Any, error) {
+	g.calls++
+	return g.detail, g.err
+}
+
+type fakeOrderCache struct {
+	interfaces.AugmontInMemRepo
+	details map[string]utils.Any
+}
+
+func (c *fakeOrderCache) GetOrderDetail(ctx context.Context, txnID string) (utils.Any, error) {
+	return c.details[txnID], nil
+}
+
+func (c *fakeOrderCache) SetOrderDetail(ctx context.Context, txnID string, detail utils.Any, ttl time.Duration) error {
+	c.details[txnID] = detail
+	return nil
+}
+
+func TestAugmontOrderServiceFind(t *testing.T) {
+	id, uid, txnID, orderType := uint64(1), "U1", "T1", models.OrderBuy
+	user := &models.AugmontUser{ID: &id, UID: &uid}
+	repo := &fakeOrderRepo{order: &models.AugmontOrder{MerchantTxnID: &txnID, Type: &orderType}}
+
+	t.Run("should fetch the detail once and cache it", func(t *testing.T) {
+		gold := &fakeOrderGold{detail: map[string]interface{}{"invoiceNumber": "INV1"}}
+		s := NewAugmontOrderService(repo, gold, &fakeOrderCache{details: map[string]utils.Any{}})
+
+		for i := 0; i < 2; i++ {
+			order, detail, err := s.Find(context.Background(), user, txnID)
+			assert.NoError(t, err)
+			assert.Equal(t, repo.order, order)
+			assert.Equal(t, gold.detail, detail)
+		}
+		assert.Equal(t, 1, gold.calls)
+	})
+
+	t.Run("should serve the order while augmont is unavailable", func(t *testing.T) {
+		gold := &fakeOrderGold{err: domain.NewError(errors.New("timeout"), domain.ErrUnavailable)}
+		cache := &fakeOrderCache{details: map[string]utils.Any{}}
+		s := NewAugmontOrderService(repo, gold, cache)
+
+		order, detail, err := s.Find(context.Background(), user, txnID)
+		assert.NoError(t, err)
+		assert.Equal(t, repo.order, order)
+		assert.Nil(t, detail)
+		assert.Empty(t, cache.details)
+	})
+}
+
+func TestAugmontOrderServiceBackfill(t *testing.T) {
+	id, uid, txnID, orderType := uint64(1), "U1", "T1", models.OrderBuy
+	user := &models.AugmontUser{ID: &id, UID: &uid}
+
+	t.Run("should fill what the order is missing", func(t *testing.T) {
+		status, amount := models.OrderCompleted, "520"
+		order := &models.AugmontOrder{MerchantTxnID: &txnID, Type: &orderType, AugmontOrderInfo: models.AugmontOrderInfo{
+			Status: &status,
+			Amount: &amount,
+		}}
+		repo := &fakeOrderRepo{}
+		gold := &fakeOrderGold{detail: map[string]interface{}{"data": map[string]interface{}{
+			"metalType":   "gold",
+			"quantity":    "0.1000",
+			"totalAmount": 530.5,
+			"rate":        "5150.00",
+		}}}
+		err := NewAugmontOrderService(repo, gold, nil).Backfill(context.Background(), user, order)
+		assert.NoError(t, err)
+		if assert.Len(t, repo.filled, 1) {
+			assert.Equal(t, "gold", *order.MetalType)
+			assert.Equal(t, "0.1000", *order.Quantity)
+			assert.Equal(t, "520", *order.Amount)
+			assert.Equal(t, "5150.00", *order.Rate)
+		}
+	})
+
+	t.Run("should not fill orders augmont did not send", func(t *testing.T) {
+		repo := &fakeOrderRepo{}
+		gold := &fakeOrderGold{err: domain.NewError(errors.New("timeout"), domain.ErrUnavailable)}
+		order := &models.AugmontOrder{MerchantTxnID: &txnID, Type: &orderType}
+		err := NewAugmontOrderService(repo, gold, nil).Backfill(context.Background(), user, order)
+		assert.True(t, domain.ErrIs(err, domain.ErrUnavailable))
+		assert.Empty(t, repo.filled)
+	})
+}
+
+func TestAugmontOrderServicePortfolio(t *testing.T) {
+	id := uint64(1)
+	user := &models.AugmontUser{ID: &id}
+	created := time.Now()
+	orderType, status, metal, quantity, amount := models.OrderBuy, models.OrderCompleted, "silver", "10", "800"
+	repo := &fakeOrderRepo{orders: []*models.AugmontOrder{{
+		CreatedAt: &created,
+		Type:      &orderType,
+		AugmontOrderInfo: models.AugmontOrderInfo{
+			Status:    &status,
+			MetalType: &metal,
+			Quantity:  &quantity,
+			Amount:    &amount,
+		},
+	}}}
+
+	t.Run("should value every metal at the sell rate", func(t *testing.T) {
+		gold := &fakeOrderGold{rates: &utils.MetalRates{Rates: []*utils.MetalRate{
+			{Metal: utils.Gold, Sell: decimal.NewFromInt(6000)},
+			{Metal: utils.Silver, Sell: decimal.NewFromInt(90)},
+		}}}
+		holdings, err := NewAugmontOrderService(repo, gold, nil).Portfolio(context.Background(), user)
+		assert.NoError(t, err)
+		assert.Len(t, holdings, 2)
+		assert.True(t, holdings[0].Quantity.IsZero())
+		assert.Equal(t, utils.Silver, holdings[1].Metal)
+		assert.Equal(t, "800", holdings[1].Invested.String())
+		assert.Equal(t, "900", holdings[1].Value.String())
+	})
+
+	t.Run("should leave the value out while augmont is unavailable", func(t *testing.T) {
+		gold := &fakeOrderGold{err: domain.NewError(errors.New("timeout"), domain.ErrUnavailable)}
+		holdings, err := NewAugmontOrderService(repo, gold, nil).Portfolio(context.Background(), user)
+		assert.NoError(t, err)
+		assert.Equal(t, "10", holdings[1].Quantity.String())
+		assert.Nil(t, holdings[1].Value)
+	})
+}
+
+func TestOrderInfo(t *testing.T) {
+	t.Run("should prefer the values of the augmont result", func(t *testing.T) {
+		info := orderInfo(map[string]interface{}{
+			"data": map[string]interface{}{
+				"metalType":   "gold",
+				"quantity":    "0.1000",
+				"totalAmount": 530.5,
+				"rate":        "5150.00",
+			},
+		}, "gold", "", "530", "5100")
+		assert.Equal(t, models.OrderCompleted, *info.Status)
+		assert.Equal(t, "0.1000", *info.Quantity)
+		assert.Equal(t, "530.5", *info.Amount)
+		assert.Equal(t, "5150.00", *info.Rate)
+	})
+
+	t.Run("should keep the requested values for malformed results", func(t *testing.T) {
+		info := orderInfo(map[string]interface{}{
+			"data": map[string]interface{}{"totalAmount": "N/A"},
+		}, "silver", "2", "", "80")
+		assert.Equal(t, "silver", *info.MetalType)
+		assert.Equal(t, "2", *info.Quantity)
+		assert.Nil(t, info.Amount)
+		assert.Equal(t, "80", *info.Rate)
+	})
+}
+
+func TestAugmontBuy(t *testing.T) {
+	id := uint64(1)
+	user := &models.AugmontUser{ID: &id, UID: strPtr("U1")}
+	buy := func(body string) (*fakeOrderRepo, *fakeOutboxRepo, error) {
+		client := augmontServer(t, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, body)
+		})
+		orders, outbox := &fakeOrderRepo{}, &fakeOutboxRepo{}
+		s := &augmontService{
+			order:  orders,
+			outbox: outbox,
+			uow:    fakeUnitOfWork{},
+			auth:   fakeAuth{},
+			client: client,
+		}
+		_, err := s.Buy(context.Background(), user, &utils.AugmontBugInfo{
+			LockPrice: "5100", MetalType: "gold", Amount: "510", BlockID: "B1",
+		})
+		return orders, outbox, err
+	}
+
+	t.Run("should save placed buys as completed", func(t *testing.T) {
+		orders, outbox, err := buy(`{"statusCode": 200, "result": {"data": {"quantity": "0.1"}}}`)
+		assert.NoError(t, err)
+		if assert.Len(t, orders.orders, 1) {
+			assert.Equal(t, models.OrderCompleted, *orders.orders[0].Status)
+			assert.Equal(t, "0.1", *orders.orders[0].Quantity)
+		}
+		assert.Len(t, outbox.added, 1)
+	})
+
+	t.Run("should save buys augmont refuses as failed", func(t *testing.T) {
+		orders, outbox, err := buy(`{"statusCode": 422, "message": "block expired"}`)
+		assert.Error(t, err)
+		if assert.Len(t, orders.orders, 1) {
+			o := orders.orders[0]
+			assert.Equal(t, models.OrderFailed, *o.Status)
+			assert.Equal(t, "gold", *o.MetalType)
+			assert.Nil(t, o.Quantity)
+			assert.Equal(t, "510", *o.Amount)
+		}
+		assert.Empty(t, outbox.added)
+	})
+}
+
+func TestRedeemMetals(t *testing.T) {
+	t.Run("should keep the metals of the products", func(t *testing.T) {
+		metals := redeemMetals(map[string]decimal.Decimal{
+			"silver": decimal.NewFromInt(10),
+			"gold":   decimal.NewFromFloat(0.5),
+		})
+		if assert.Len(t, metals, 2) {
+			assert.Equal(t, "gold", *metals[0].MetalType)
+			assert.Equal(t, "0.5", *metals[0].Quantity)
+			assert.Equal(t, "silver", *metals[1].MetalType)
+			assert.Equal(t, "10", *metals[1].Quantity)
+		}
+	})
+
+	t.Run("should skip metals without grams", func(t *testing.T) {
+		metals := redeemMetals(map[string]decimal.Decimal{"silver": decimal.NewFromInt(10), "gold": decimal.Zero})
+		if assert.Len(t, metals, 1) {
+			assert.Equal(t, "silver", *metals[0].MetalType)
+		}
+	})
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+// Products fetched from Augmont per page while syncing
+const productPageSize = 50
+
+type augmontProductService struct {
+	product interfaces.AugmontProductRepo
+	gold    interfaces.AugmontService
+}
+
+// NewAugmontProductService creates a new AugmontProductService
+func NewAugmontProductService(
+	product interfaces.AugmontProductRepo,
+	gold interfaces.AugmontService,
+) interfaces.AugmontProductService {
+	return &augmontProductService{
+		product: product,
+		gold:    gold,
+	}
+}
+
+func (s *augmontProductService) Sync(ctx context.Context) (int, int64, error) {
+	now := time.Now()
+	var products []*models.AugmontProduct
+	for page := 1; ; page++ {
+		result, err := s.gold.Products(ctx, page, productPageSize)
+		if err != nil {
+			return 0, 0, errors.Wrapf(err, "products page %d", page)
+		}
+		items, _ := result.([]interface{})
+		for _, item := range items {
+			data, _ := item.(map[string]interface{})
+			if p := parseProduct(data, now); p != nil {
+				products = append(products, p)
+			}
+		}
+		if len(items) < productPageSize {
+			break
+		}
+	}
+	// An empty catalogue is more likely an Augmont fault than
+	// every product gone, keep the products we have
+	if len(products) == 0 {
+		return 0, 0, domain.NewError(errors.New("augmont sent no products"), domain.ErrUnavailable)
+	}
+
+	if err := s.product.Upsert(ctx, products); err != nil {
+		return 0, 0, err
+	}
+	skus := make([]string, 0, len(products))
+	for _, p := range products {
+		skus = append(skus, *p.SKU)
+	}
+	dropped, err := s.product.Deactivate(ctx, skus)
+	if err != nil {
+		return 0, 0, err
+	}
+	domain.Logger(ctx).
+		WithField("synced", len(products)).
+		WithField("dropped", dropped).
+		Info("product catalogue synced")
+	return len(products), dropped, nil
+}
+
+// parseProduct reads a product of the Augmont catalogue, products
+// without a SKU or weight and inactive ones are skipped
+func parseProduct(data map[string]interface{}, syncedAt time.Time) *models.AugmontProduct {
+	str := func(key string) string {
+		if v, ok := data[key]; ok && v != nil {
+			return strings.TrimSpace(fmt.Sprint(v))
+		}
+		return ""
+	}
+	sku := str("sku")
+	weight, err := decimal.NewFromString(str("productWeight"))
+	if sku == "" || err != nil || !weight.IsPositive() {
+		return nil
+	}
+	if status := str("status"); status != "" && !strings.EqualFold(status, "active") {
+		return nil
+	}
+	metal, ok := utils.ParseMetal(str("metalType"))
+	if !ok {
+		return nil
+	}
+
+	name, purity := str("name"), str("purity")
+	weightStr := weight.String()
+	charges, _ := decimal.NewFromString(str("basePrice"))
+	chargesStr := charges.StringFixed(2)
+	metalStr := string(metal)
+	active := true
+
+	images := models.StringList{}
+	list, _ := data["productImages"].([]interface{})
+	for _, image := range list {
+		image, _ := image.(map[string]interface{})
+		if url, ok := image["url"].(string); ok && url != "" {
+			images = append(images, url)
+		}
+	}
+
+	var stock *int64
+	if s, err := decimal.NewFromString(str("stock")); err == nil {
+		n := s.IntPart()
+		stock = &n
+	}
+
+	return &models.AugmontProduct{
+		SKU:           &sku,
+		Name:          &name,
+		MetalType:     &metalStr,
+		Purity:        &purity,
+		Weight:        &weightStr,
+		MakingCharges: &chargesStr,
+		Images:        images,
+		Stock:         stock,
+		Active:        &active,
+		SyncedAt:      &syncedAt,
+	}
+}
+
+func (s *augmontProductService) List(
+	ctx context.Context,
+	q *utils.ListQuery,
+) ([]*models.AugmontProduct, *utils.Page, error) {
+	return s.product.List(ctx, q)
+}
+
+func (s *augmontProductService) Find(ctx context.Context, sku string) (*models.AugmontProduct, error) {
+	return s.product.FindBySKU(ctx, sku)
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProduct(t *testing.T) {
+	now := time.Now()
+
+	t.Run("should read the catalogue fields", func(t *testing.T) {
+		p := parseProduct(map[string]interface{}{
+			"sku":           "AU999GC01G",
+			"name":          "1 Gram Gold Coin",
+			"metalType":     "Gold",
+			"purity":        "24K",
+			"productWeight": "1.0000",
+			"basePrice":     "350",
+			"stock":         float64(12),
+			"productImages": []interface{}{
+				map[string]interface{}{"url": "https://cdn.example.com/coin.png"},
+			},
+		}, now)
+		if assert.NotNil(t, p) {
+			assert.Equal(t, "gold", *p.MetalType)
+			assert.Equal(t, "1", *p.Weight)
+			assert.Equal(t, "350.00", *p.MakingCharges)
+			assert.Equal(t, int64(12), *p.Stock)
+			assert.Len(t, p.Images, 1)
+		}
+	})
+
+	t.Run("should skip inactive and incomplete products", func(t *testing.T) {
+		assert.Nil(t, parseProduct(map[string]interface{}{
+			"sku": "AU999GC01G", "metalType": "gold", "productWeight": "1", "status": "inactive",
+		}, now))
+		assert.Nil(t, parseProduct(map[string]interface{}{
+			"sku": "AU999GC01G", "metalType": "gold",
+		}, now))
+		assert.Nil(t, parseProduct(map[string]interface{}{
+			"sku": "PT999", "metalType": "platinum", "productWeight": "1",
+		}, now))
+	})
+}
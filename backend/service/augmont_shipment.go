@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+type augmontShipmentService struct {
+	shipment interfaces.AugmontShipmentRepo
+	gold     interfaces.AugmontService
+	notifier interfaces.Notifier
+}
+
+// NewAugmontShipmentService creates a new AugmontShipmentService
+func NewAugmontShipmentService(
+	shipment interfaces.AugmontShipmentRepo,
+	gold interfaces.AugmontService,
+	notifier interfaces.Notifier,
+) interfaces.AugmontShipmentService {
+	return &augmontShipmentService{
+		shipment: shipment,
+		gold:     gold,
+		notifier: notifier,
+	}
+}
+
+// shipmentAliases map the shipment statuses sent by Augmont and the
+// couriers, lower cased with spaces, to ours
+var shipmentAliases = map[string]string{
+	"placed":     models.ShipmentPlaced,
+	"pending":    models.ShipmentPlaced,
+	"processing": models.ShipmentPlaced,
+	"confirmed":  models.ShipmentPlaced,
+	"accepted":   models.ShipmentPlaced,
+
+	"dispatched": models.ShipmentDispatched,
+	"shipped":    models.ShipmentDispatched,
+	"picked up":  models.ShipmentDispatched,
+
+	"in transit":       models.ShipmentInTransit,
+	"out for delivery": models.ShipmentInTransit,
+
+	"delivered": models.ShipmentDelivered,
+
+	"returned":         models.ShipmentReturned,
+	"rto":              models.ShipmentReturned,
+	"rto delivered":    models.ShipmentReturned,
+	"return to origin": models.ShipmentReturned,
+}
+
+// parseShipmentStatus returns our shipment status of an Augmont one
+func parseShipmentStatus(status string) (string, bool) {
+	status = strings.ToLower(strings.TrimSpace(status))
+	status = strings.NewReplacer("_", " ", "-", " ").Replace(status)
+	s, ok := shipmentAliases[status]
+	return s, ok
+}
+
+// shipmentMoves checks the shipment may go from one status to the other,
+// updates seen late never move it back and delivered or returned is final
+func shipmentMoves(from, to string) bool {
+	rank := func(status string) int {
+		for i, s := range models.ShipmentStatuses {
+			if s == status {
+				return i
+			}
+		}
+		return -1
+	}
+	switch from {
+	case models.ShipmentDelivered, models.ShipmentReturned:
+		return false
+	}
+	if to == models.ShipmentReturned {
+		return true
+	}
+	return rank(to) > rank(from)
+}
+
+// shipmentUpdate is a shipment status seen by a poll or a callback
+type shipmentUpdate struct {
+	status         string
+	courier        string
+	trackingNumber string
+	location       string
+	at             time.Time
+	source         string
+}
+
+// apply moves the shipment of the order to the status of the update and
+// notifies the user, returns if the shipment moved
+func (s *augmontShipmentService) apply(
+	ctx context.Context,
+	order *models.AugmontRedeemOrder,
+	update *shipmentUpdate,
+) (bool, error) {
+	to, ok := parseShipmentStatus(update.status)
+	if !ok {
+		return false, domain.NewError(errors.Newf("unknown shipment status %q", update.status), domain.ErrInvalidArgument, "unknown shipment status")
+	}
+	from := order.ShipmentStatus
+	current := models.ShipmentPlaced
+	if from != nil {
+		current = *from
+	}
+	if !shipmentMoves(current, to) {
+		return false, nil
+	}
+
+	if update.courier != "" {
+		order.Courier = &update.courier
+	}
+	if update.trackingNumber != "" {
+		order.TrackingNumber = &update.trackingNumber
+	}
+	event := &models.AugmontShipmentEvent{
+		Status: &to,
+		At:     &update.at,
+		Source: &update.source,
+	}
+	if update.location != "" {
+		event.Location = &update.location
+	}
+	moved, err := s.shipment.UpdateShipment(ctx, order, from, event)
+	if err != nil || !moved {
+		return false, err
+	}
+	order.ShipmentStatus = &to
+
+	domain.Logger(ctx).WithFields(log.Fields{
+		"txnID": deref(order.MerchantTxnID),
+		"from":  current,
+		"to":    to,
+	}).Info("shipment moved")
+	s.notify(ctx, order, to)
+	return true, nil
+}
+
+// notify tells the user the shipment moved, a notification not
+// sent does not undo the update
+func (s *augmontShipmentService) notify(ctx context.Context, order *models.AugmontRedeemOrder, status string) {
+	if order.AugmontUser == nil || order.AugmontUser.UserID == nil {
+		return
+	}
+	err := s.notifier.Notify(ctx, *order.AugmontUser.UserID, "shipment_"+status, map[string]string{
+		"txnID":          deref(order.MerchantTxnID),
+		"courier":        deref(order.Courier),
+		"trackingNumber": deref(order.TrackingNumber),
+	})
+	if err != nil {
+		domain.Logger(ctx).WithError(err).Warn("shipment notification not sent")
+	}
+}
+
+// detailString returns the first of the keys set in the Augmont order detail
+func detailString(data map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if v, ok := data[key]; ok && v != nil {
+			if s := strings.TrimSpace(fmt.Sprint(v)); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+func (s *augmontShipmentService) Poll(ctx context.Context) (int, error) {
+	orders, err := s.shipment.FindOpenShipments(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	moved := 0
+	for _, order := range orders {
+		if order.AugmontUser == nil || order.AugmontUser.UID == nil {
+			continue
+		}
+		logger := domain.Logger(ctx).WithField("txnID", deref(order.MerchantTxnID))
+		detail, err := s.gold.RedeemInfo(ctx, *order.AugmontUser.UID, *order.MerchantTxnID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return moved, ctx.Err()
+			}
+			// One order failing does not hold up the others
+			logger.WithError(err).Warn("redeem order detail not fetched")
+			continue
+		}
+		data, _ := detail.(map[string]interface{})
+		status := detailString(data, "shipmentStatus", "deliveryStatus", "orderStatus")
+		if status == "" {
+			continue
+		}
+		ok, err := s.apply(ctx, order, &shipmentUpdate{
+			status:         status,
+			courier:        detailString(data, "logisticName", "courierName"),
+			trackingNumber: detailString(data, "awbNo", "trackingNumber"),
+			at:             time.Now(),
+			source:         models.ShipmentSourcePoll,
+		})
+		if err != nil {
+			logger.WithError(err).Warn("shipment not updated")
+			continue
+		}
+		if ok {
+			moved++
+		}
+	}
+	return moved, nil
+}
+
+func (s *augmontShipmentService) Callback(ctx context.Context, info *utils.AugmontShipmentCallback) error {
+	order, err := s.shipment.FindRedeem(ctx, info.MerchantTxnID)
+	if err != nil {
+		return err
+	}
+	if order == nil {
+		return domain.NewError(errors.Newf("redeem order %v not found", info.MerchantTxnID), domain.ErrNotFound, "order not found")
+	}
+
+	at := time.Now()
+	if t, err := time.Parse(time.RFC3339, info.UpdatedAt); err == nil {
+		at = t
+	}
+	_, err = s.apply(ctx, order, &shipmentUpdate{
+		status:         info.Status,
+		courier:        info.Courier,
+		trackingNumber: info.TrackingNumber,
+		location:       info.Location,
+		at:             at,
+		source:         models.ShipmentSourceCallback,
+	})
+	return err
+}
+
+func (s *augmontShipmentService) Timeline(
+	ctx context.Context,
+	user *models.AugmontUser,
+	txnID string,
+) (*models.ShipmentTimeline, error) {
+	order, err := s.shipment.FindRedeem(ctx, txnID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil || *order.AugmontUserID != *user.ID {
+		return nil, domain.NewError(errors.Newf("redeem order %v not found", txnID), domain.ErrNotFound, "order not found")
+	}
+
+	events, err := s.shipment.FindEvents(ctx, *order.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Placing the order starts the timeline, it is not stored as an event
+	placed := models.ShipmentPlaced
+	timeline := &models.ShipmentTimeline{
+		MerchantTxnID:  order.MerchantTxnID,
+		Status:         order.ShipmentStatus,
+		Courier:        order.Courier,
+		TrackingNumber: order.TrackingNumber,
+		Events: append([]*models.AugmontShipmentEvent{{
+			Status: &placed,
+			At:     order.CreatedAt,
+		}}, events...),
+	}
+	if timeline.Status == nil {
+		timeline.Status = &placed
+	}
+	return timeline, nil
+}
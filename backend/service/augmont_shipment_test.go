@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+type fakeShipmentRepo struct {
+	interfaces.AugmontShipmentRepo
+	order  *models.AugmontRedeemOrder
+	events []*models.AugmontShipmentEvent
+}
+
+func (r *fakeShipmentRepo) FindRedeem(ctx context.Context, txnID string) (*models.AugmontRedeemOrder, error) {
+	if r.order == nil || *r.order.MerchantTxnID != txnID {
+		return nil, nil
+	}
+	return r.order, nil
+}
+
+func (r *fakeShipmentRepo) FindOpenShipments(ctx context.Context) ([]*models.AugmontRedeemOrder, error) {
+	return []*models.AugmontRedeemOrder{r.order}, nil
+}
+
+func (r *fakeShipmentRepo) UpdateShipment(ctx context.Context, order *models.AugmontRedeemOrder, from *string, event *models.AugmontShipmentEvent) (bool, error) {
+	// The status was moved by someone else
+	if deref(from) != deref(r.order.ShipmentStatus) {
+		return false, nil
+	}
+	r.events = append(r.events, event)
+	return true, nil
+}
+
+func (r *fakeShipmentRepo) FindEvents(ctx context.Context, redeemOrderID uint64) ([]*models.AugmontShipmentEvent, error) {
+	return r.events, nil
+}
+
+type fakeShipmentGold struct {
+	interfaces.AugmontService
+	detail utils.Any
+}
+
+func (g *fakeShipmentGold) RedeemInfo(ctx context.Context, userUniqueID, tnxID string) (utils.Any, error) {
+	return g.detail, nil
+}
+
+type fakeNotifier struct {
+	sent   []string
+	events []uint64
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, userID uint64, name string, args map[string]string) error {
+	n.sent = append(n.sent, name)
+	return nil
+}
+
+func (n *fakeNotifier) NotifyEvent(ctx context.Context, eventID, userID uint64, name string, args map[string]string) error {
+	n.events = append(n.events, eventID)
+	return n.Notify(ctx, userID, name, args)
+}
+
+func redeemOrder(status string) *models.AugmontRedeemOrder {
+	id, augmontUserID, userID, uid, txnID := uint64(10), uint64(1), uint64(2), "U1", "R1"
+	created := time.Now().Add(-48 * time.Hour)
+	order := &models.AugmontRedeemOrder{
+		ID:            &id,
+		CreatedAt:     &created,
+		MerchantTxnID: &txnID,
+		AugmontUserID: &augmontUserID,
+		AugmontUser:   &models.AugmontUser{ID: &augmontUserID, UserID: &userID, UID: &uid},
+	}
+	if status != "" {
+		order.ShipmentStatus = &status
+	}
+	return order
+}
+
+func TestShipmentStatus(t *testing.T) {
+	t.Run("should map augmont statuses", func(t *testing.T) {
+		for status, want := range map[string]string{
+			"Dispatched":       models.ShipmentDispatched,
+			"IN_TRANSIT":       models.ShipmentInTransit,
+			"out-for-delivery": models.ShipmentInTransit,
+			"RTO Delivered":    models.ShipmentReturned,
+		} {
+			got, ok := parseShipmentStatus(status)
+			assert.True(t, ok, status)
+			assert.Equal(t, want, got, status)
+		}
+		_, ok := parseShipmentStatus("lost")
+		assert.False(t, ok)
+	})
+
+	t.Run("should only move forward", func(t *testing.T) {
+		assert.True(t, shipmentMoves(models.ShipmentPlaced, models.ShipmentInTransit))
+		assert.False(t, shipmentMoves(models.ShipmentInTransit, models.ShipmentDispatched))
+		assert.False(t, shipmentMoves(models.ShipmentDispatched, models.ShipmentDispatched))
+		assert.True(t, shipmentMoves(models.ShipmentInTransit, models.ShipmentReturned))
+		assert.False(t, shipmentMoves(models.ShipmentDelivered, models.ShipmentReturned))
+	})
+}
+
+func TestAugmontShipmentService(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should move polled shipments and notify once", func(t *testing.T) {
+		repo := &fakeShipmentRepo{order: redeemOrder(models.ShipmentPlaced)}
+		gold := &fakeShipmentGold{detail: map[string]interface{}{
+			"orderStatus":  "Dispatched",
+			"logisticName": "BlueDart",
+			"awbNo":        "AWB1",
+		}}
+		notifier := &fakeNotifier{}
+		s := NewAugmontShipmentService(repo, gold, notifier)
+
+		for i := 0; i < 2; i++ {
+			_, err := s.Poll(ctx)
+			assert.NoError(t, err)
+		}
+		assert.Len(t, repo.events, 1)
+		assert.Equal(t, []string{"shipment_dispatched"}, notifier.sent)
+		assert.Equal(t, "AWB1", *repo.order.TrackingNumber)
+	})
+
+	t.Run("should ignore late callbacks", func(t *testing.T) {
+		repo := &fakeShipmentRepo{order: redeemOrder(models.ShipmentDelivered)}
+		notifier := &fakeNotifier{}
+		s := NewAugmontShipmentService(repo, nil, notifier)
+
+		err := s.Callback(ctx, &utils.AugmontShipmentCallback{MerchantTxnID: "R1", Status: "in transit"})
+		assert.NoError(t, err)
+		assert.Empty(t, repo.events)
+		assert.Empty(t, notifier.sent)
+	})
+
+	t.Run("should reject unknown callbacks", func(t *testing.T) {
+		s := NewAugmontShipmentService(&fakeShipmentRepo{order: redeemOrder("")}, nil, &fakeNotifier{})
+
+		err := s.Callback(ctx, &utils.AugmontShipmentCallback{MerchantTxnID: "R2", Status: "delivered"})
+		e, _ := domain.AsError(err)
+		assert.Equal(t, domain.ErrNotFound, e.Type())
+
+		err = s.Callback(ctx, &utils.AugmontShipmentCallback{MerchantTxnID: "R1", Status: "lost"})
+		e, _ = domain.AsError(err)
+		assert.Equal(t, domain.ErrInvalidArgument, e.Type())
+	})
+
+	t.Run("should start the timeline when the order was placed", func(t *testing.T) {
+		repo := &fakeShipmentRepo{order: redeemOrder("")}
+		s := NewAugmontShipmentService(repo, nil, &fakeNotifier{})
+
+		err := s.Callback(ctx, &utils.AugmontShipmentCallback{MerchantTxnID: "R1", Status: "shipped", UpdatedAt: "2026-10-01T10:00:00+05:30"})
+		assert.NoError(t, err)
+
+		timeline, err := s.Timeline(ctx, repo.order.AugmontUser, "R1")
+		assert.NoError(t, err)
+		assert.Equal(t, models.ShipmentDispatched, *timeline.Status)
+		if assert.Len(t, timeline.Events, 2) {
+			assert.Equal(t, models.ShipmentPlaced, *timeline.Events[0].Status)
+			assert.Equal(t, 2026, timeline.Events[1].At.Year())
+		}
+
+		other := uint64(5)
+		_, err = s.Timeline(ctx, &models.AugmontUser{ID: &other}, "R1")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/document"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/statement"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+// maxStatementRange is the longest range of a statement
+const maxStatementRange = 366 * 24 * time.Hour
+
+// statementWriters render the statements by format
+var statementWriters = map[string]func(io.Writer, *statement.Statement) error{
+	models.StatementCSV: statement.WriteCSV,
+	models.StatementPDF: statement.WritePDF,
+}
+
+type augmontStatementService struct {
+	statement interfaces.AugmontStatementRepo
+	order     interfaces.AugmontOrderRepo
+	user      interfaces.UserRepo
+	storage   interfaces.FileStorage
+
+	// Statements are generated in the background
+	background *background
+}
+
+// NewAugmontStatementService creates a new AugmontStatementService,
+// the statements being generated are awaited on shutdown
+func NewAugmontStatementService(
+	lifecycle *domain.Lifecycle,
+	statementRepo interfaces.AugmontStatementRepo,
+	order interfaces.AugmontOrderRepo,
+	user interfaces.UserRepo,
+	storage interfaces.FileStorage,
+) interfaces.AugmontStatementService {
+	return &augmontStatementService{
+		statement:  statementRepo,
+		order:      order,
+		user:       user,
+		storage:    storage,
+		background: newBackground(lifecycle, "statements"),
+	}
+}
+
+func (s *augmontStatementService) Request(
+	ctx context.Context,
+	user *models.AugmontUser,
+	from, to time.Time,
+	format string,
+) (*models.AugmontStatement, error) {
+	if _, ok := statementWriters[format]; !ok {
+		return nil, domain.NewError(errors.Newf("unknown statement format %q", format), domain.ErrInvalidArgument, "unknown statement format")
+	}
+	if to.Before(from) || to.Sub(from) > maxStatementRange {
+		return nil, domain.NewError(errors.Newf("invalid statement range %v to %v", from, to), domain.ErrInvalidArgument, "statements cover at most a year")
+	}
+
+	status := models.StatementPending
+	st := &models.AugmontStatement{
+		AugmontUserID: user.ID,
+		From:          &from,
+		To:            &to,
+		Format:        &format,
+		Status:        &status,
+	}
+	if err := s.statement.Create(ctx, st); err != nil {
+		return nil, err
+	}
+
+	ctx = domain.ContextWithLogFields(ctx, log.Fields{"statementID": *st.ID})
+	s.background.Go(ctx, func(ctx context.Context) {
+		s.generate(ctx, user, st)
+	})
+
+	return st, nil
+}
+
+func (s *augmontStatementService) Find(
+	ctx context.Context,
+	user *models.AugmontUser,
+	id uint64,
+) (*models.AugmontStatement, string, error) {
+	st, err := s.statement.FindOne(ctx, &models.AugmontStatement{
+		ID:            &id,
+		AugmontUserID: user.ID,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if *st.Status != models.StatementReady {
+		return st, "", nil
+	}
+
+	url, err := s.storage.SignedURL(ctx, *st.FileKey, domain.Config().Storage.URLExpiry)
+	if err != nil {
+		return nil, "", err
+	}
+	return st, url, nil
+}
+
+// generate renders and stores the statement, failures mark it failed
+func (s *augmontStatementService) generate(
+	ctx context.Context,
+	user *models.AugmontUser,
+	st *models.AugmontStatement,
+) {
+	log := domain.Logger(ctx)
+	start := time.Now()
+
+	key, err := s.render(ctx, user, st)
+	status := models.StatementReady
+	if err != nil {
+		log.WithError(err).Error("statement generation failed")
+		status = models.StatementFailed
+	}
+
+	// Record the result even if generation was cancelled
+	updateCtx, cancel := context.WithTimeout(domain.ContextWithLogger(context.Background(), log), 5*time.Second)
+	defer cancel()
+	update := &models.AugmontStatement{ID: st.ID, Status: &status}
+	if key != "" {
+		update.FileKey = &key
+	}
+	if err := s.statement.Update(updateCtx, update); err != nil {
+		log.WithError(err).Error("statement status not saved")
+		return
+	}
+	log.WithField("took", time.Since(start).String()).WithField("status", status).Info("statement generated")
+}
+
+// render builds the statement and returns its file key
+func (s *augmontStatementService) render(
+	ctx context.Context,
+	user *models.AugmontUser,
+	st *models.AugmontStatement,
+) (string, error) {
+	pinchUser, err := s.user.FindOne(ctx, &models.User{ID: user.UserID})
+	if err != nil {
+		return "", errors.Wrap(err, "find user")
+	}
+
+	// Dates are inclusive, the range ends with the last day
+	from := st.From.In(utils.IST)
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, utils.IST)
+	to := st.To.In(utils.IST)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, utils.IST).AddDate(0, 0, 1).Add(-time.Nanosecond)
+
+	// Orders before the range make the opening balances
+	orders, err := s.order.FindOrdersBetween(ctx, *user.ID, time.Time{}, end)
+	if err != nil {
+		return "", errors.Wrap(err, "find orders")
+	}
+
+	holder := document.Holder{
+		Name:      deref(pinchUser.Name),
+		Mobile:    deref(pinchUser.Mobile),
+		AccountID: deref(user.UID),
+	}
+	built := statement.Build(holder, orders, from, end, time.Now())
+
+	buf := &bytes.Buffer{}
+	if err := statementWriters[*st.Format](buf, built); err != nil {
+		return "", errors.Wrap(err, "render statement")
+	}
+
+	key := fmt.Sprintf("statements/%d/%d/pinch-statement-%s-%s.%s",
+		*user.ID, *st.ID,
+		from.Format("02012006"), to.Format("02012006"),
+		*st.Format,
+	)
+	if err := s.storage.Put(ctx, key, buf); err != nil {
+		return "", errors.Wrap(err, "store statement")
+	}
+	return key, nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func derefID(id *uint64) uint64 {
+	if id == nil {
+		return 0
+	}
+	return *id
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/tax"
+)
+
+type augmontTaxService struct {
+	order  interfaces.AugmontOrderRepo
+	engine *tax.Engine
+}
+
+// NewAugmontTaxService creates a new AugmontTaxService
+func NewAugmontTaxService(order interfaces.AugmontOrderRepo) interfaces.AugmontTaxService {
+	return &augmontTaxService{
+		order:  order,
+		engine: tax.NewEngine(domain.Config().Tax.CII),
+	}
+}
+
+func (s *augmontTaxService) Report(
+	ctx context.Context,
+	user *models.AugmontUser,
+	fy string,
+) (*tax.Report, error) {
+	year, err := tax.ParseFY(fy)
+	if err != nil {
+		return nil, domain.NewError(err, domain.ErrInvalidArgument, "invalid financial year")
+	}
+
+	// Sells are matched to buys of any earlier year
+	orders, err := s.order.FindOrdersBetween(ctx, *user.ID, time.Time{}, year.End)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := s.engine.Report(orders, year)
+	if err != nil {
+		// A missing index is a deploy without TAX_CII for the new year
+		return nil, domain.NewError(err, domain.ErrUnavailable, "the tax report of this year is not available yet")
+	}
+	return report, nil
+}
@@ -2,56 +2,57 @@ package service
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
-	"log"
 	"mime/multipart"
 	"net/http"
-	"net/url"
 	"strconv"
 	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/mitchellh/mapstructure"
-	"github.com/nleeper/goment"
+	"github.com/shopspring/decimal"
 
 	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/events"
 	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/jobs"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/metrics"
 	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
 	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
 )
 
 // AumontService provides augmont merchant api functionality
 type augmontService struct {
-	user  interfaces.AugmontUserRepo
-	order interfaces.AugmontOrderRepo
-	inMem interfaces.AugmontInMemRepo
-}
-
-type AugmontLogInResp struct {
-	StatusCode int    `json:"statusCode"`
-	Message    string `json:"message"`
-
-	Result struct {
-		Data struct {
-			MerchantID int    `json:"merchantId"`
-			Token      string `json:"accessToken"`
-			ExpireAt   string `json:"expireAt"`
-		} `json:"data"`
-	} `json:"result"`
+	user   interfaces.AugmontUserRepo
+	order  interfaces.AugmontOrderRepo
+	outbox interfaces.OutboxRepo
+	uow    interfaces.UnitOfWork
+	auth   interfaces.AugmontAuthService
+	queue  interfaces.JobQueue
+
+	client *http.Client
 }
 
 // Create New Augmond Service
 func NewAugmondService(
 	user interfaces.AugmontUserRepo,
 	order interfaces.AugmontOrderRepo,
-	inMem interfaces.AugmontInMemRepo,
+	outbox interfaces.OutboxRepo,
+	uow interfaces.UnitOfWork,
+	auth interfaces.AugmontAuthService,
+	queue interfaces.JobQueue,
 ) interfaces.AugmontService {
 	return &augmontService{
-		user:  user,
-		order: order,
-		inMem: inMem,
+		user:   user,
+		order:  order,
+		outbox: outbox,
+		uow:    uow,
+		auth:   auth,
+		queue:  queue,
+
+		client: newAugmontClient(),
 	}
 }
 
@@ -65,66 +66,10 @@ func (augmontService) newTnxID() string {
 	return utils.NewUniqueString(TnxIDMaxLen)
 }
 
-// Login authenticates user with augmont
-func (s *augmontService) logIn() (*AugmontLogInResp, error) {
-	// get config
-	email := domain.Config().Augmont.Email
-	password := domain.Config().Augmont.Password
-	host := domain.Config().Augmont.Host
-	// parse augmont login augUrl
-
-	augUrl := host + "/merchant/v1/auth/login"
-
-	resp, err := http.PostForm(augUrl, url.Values{
-		"email":    {email},
-		"password": {password},
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	defer resp.Body.Close()
-	respData := AugmontLogInResp{}
-	err = json.NewDecoder(resp.Body).Decode(&respData)
-	if err != nil {
-		return nil, err
-	}
-	if respData.StatusCode != 200 {
-		err = fmt.Errorf("%v", respData.Message)
-		return nil, domain.NewError(err, domain.ErrInvalidArgument)
-	}
-	return &respData, nil
-}
-
-// AuthToken returns authenication token for augmont
-func (s *augmontService) AuhtToken() (string, error) {
-	// Retive the stored token
-	token, err := s.inMem.GetToken()
-	if err != nil {
-		return "", err
-	}
-
-	// If token is not expired, return it
-	if token != "" {
-		return token, nil
-	}
-
-	// If token is expired, generate new token
-	data, err := s.logIn()
-	if err != nil {
-		return "", err
-	}
-	token = data.Result.Data.Token
-	gom, _ := goment.New(data.Result.Data.ExpireAt, "YYYY-MM-DD HH-mm-ss")
-	expireAt := gom.ToTime()
-	s.inMem.SetToken(token, expireAt)
-
-	return token, nil
-}
-
 // CreateUser creates a new customer account
 // with augmont and create augmont user in db
 func (s *augmontService) CreateUser(
+	ctx context.Context,
 	userInfo *utils.AugmontUserInfo,
 	user *models.AugmontUser,
 ) error {
@@ -185,14 +130,14 @@ func (s *augmontService) CreateUser(
 		}
 	}
 	// Create New Request with payload
-	req, err := http.NewRequest(method, augUrl, payload)
+	req, err := http.NewRequestWithContext(ctx, method, augUrl, payload)
 	if err != nil {
 		return err
 	}
 
 	// Add Request Headers
 	{
-		token, err := s.AuhtToken()
+		token, err := s.auth.AuthToken(ctx)
 		if err != nil {
 			return err
 		}
@@ -202,14 +147,24 @@ func (s *augmontService) CreateUser(
 	}
 
 	// Make Request
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := s.client.Do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
+	// Decode Response body
+	data := &utils.AugmontResponse{}
+	err = decodeAugmont(resp, data)
+	if err != nil {
+		return err
+	}
+	if data.IsError() {
+		return augmontResponseError(data)
+	}
+
 	// If success, create user in db
-	err = s.user.CreateUser(user)
+	err = s.user.CreateUser(ctx, user)
 	if err != nil {
 		return err
 	}
@@ -219,12 +174,13 @@ func (s *augmontService) CreateUser(
 
 // UpdateUser updates user info in augmont with uniqueID
 func (s *augmontService) UpdateUser(
+	ctx context.Context,
 	userInfo *utils.AugmontUserInfo,
 ) error {
 
 	// get user data from augmont, replace empty fields with userInfo
 	{
-		augUser, err := s.GetUserInfo(userInfo.UniqueID)
+		augUser, err := s.GetUserInfo(ctx, userInfo.UniqueID)
 		if err != nil {
 			return errors.WithMessage(err, "failed to get user data from augmont")
 		}
@@ -244,13 +200,13 @@ func (s *augmontService) UpdateUser(
 	payload := strings.NewReader(infoDict.ToString())
 
 	// Create New Request with payload
-	req, err := http.NewRequest(method, augUrl, payload)
+	req, err := http.NewRequestWithContext(ctx, method, augUrl, payload)
 	if err != nil {
 		return err
 	}
 	// Add Request Headers
 	{
-		token, err := s.AuhtToken()
+		token, err := s.auth.AuthToken(ctx)
 		if err != nil {
 			return err
 		}
@@ -258,8 +214,7 @@ func (s *augmontService) UpdateUser(
 		req.Header.Add("Content-Type", "application/json")
 	}
 	// Make Request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := s.client.Do(req)
 	if err != nil {
 		return err
 	}
@@ -267,21 +222,21 @@ func (s *augmontService) UpdateUser(
 
 	// Decode Response body
 	data := make(map[string]interface{})
-	err = json.NewDecoder(resp.Body).Decode(&data)
+	err = decodeAugmont(resp, &data)
 	if err != nil {
 		return err
 	}
 
 	// If not success, return error messsage
 	if data["statusCode"] != float64(200) {
-		dict := utils.Dict(data["errors"].(map[string]interface{}))
-		return domain.NewError(err, domain.ErrInvalidArgument, dict.ToString())
+		return augmontDataError(data)
 	}
 	return nil
 }
 
 // GetUserInfo returns user info from augmont
 func (s *augmontService) GetUserInfo(
+	ctx context.Context,
 	uniqueID string,
 ) (
 	*utils.AugmontUserInfo,
@@ -291,11 +246,11 @@ func (s *augmontService) GetUserInfo(
 	url := host + "/merchant/v1/users/" + uniqueID
 
 	// Create Get Request
-	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
 	// Add Request Headers
 	{
-		token, err := s.AuhtToken()
+		token, err := s.auth.AuthToken(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -303,8 +258,7 @@ func (s *augmontService) GetUserInfo(
 		req.Header.Add("Accept", "application/json")
 	}
 	// Make Request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := s.client.Do(req)
 	if err != nil {
 		return nil, domain.NewError(err, domain.ErrInternalError)
 	}
@@ -312,12 +266,13 @@ func (s *augmontService) GetUserInfo(
 
 	// Decode Response body
 	data := make(map[string]interface{})
-	json.NewDecoder(resp.Body).Decode(&data)
+	if err := decodeAugmont(resp, &data); err != nil {
+		return nil, err
+	}
 	// Check if the  request is success
 
 	if data["statusCode"] != float64(200) {
-		err = fmt.Errorf("%v", data["message"])
-		return nil, domain.NewError(err, domain.ErrInvalidArgument)
+		return nil, augmontDataError(data)
 	}
 
 	// Decode userInfo from response result
@@ -332,6 +287,7 @@ func (s *augmontService) GetUserInfo(
 }
 
 func (s *augmontService) PostUserKyc(
+	ctx context.Context,
 	name, pan, dob string,
 	user *models.AugmontUser,
 	file *utils.File,
@@ -367,13 +323,13 @@ func (s *augmontService) PostUserKyc(
 	}
 
 	// Create New Request with payload
-	req, err := http.NewRequest(method, url, payload)
+	req, err := http.NewRequestWithContext(ctx, method, url, payload)
 	if err != nil {
 		return nil, err
 	}
 	// Add Request Headers
 	{
-		token, err := s.AuhtToken()
+		token, err := s.auth.AuthToken(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -382,8 +338,7 @@ func (s *augmontService) PostUserKyc(
 		req.Header.Set("Content-Type", writer.FormDataContentType())
 	}
 	// Make Request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := s.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -391,22 +346,32 @@ func (s *augmontService) PostUserKyc(
 
 	// Decode Response body
 	data := &utils.AugmontResponse{}
-
-	json.NewDecoder(resp.Body).Decode(&data)
-	if data.IsError() {
-		return nil, data.Error()
+	err = decodeAugmont(resp, data)
+	if err == nil {
+		err = augmontResponseError(data)
+	}
+	metrics.KYCSubmissions.WithLabelValues(metrics.Outcome(err)).Inc()
+	if err != nil {
+		return nil, err
 	}
 
 	status := "pending"
-	s.user.UpdateUser(&models.AugmontUser{
-		ID:        user.UserID,
+	s.user.UpdateUser(ctx, &models.AugmontUser{
+		ID:        user.ID,
 		KYCStatus: &status,
 	})
 
+	// Checked in the background until Augmont decides
+	_, err = s.queue.Enqueue(ctx, &jobs.RefreshKYC{AugmontUserID: *user.ID}, domain.Config().Augmont.KYCCheckDelay)
+	if err != nil {
+		domain.Logger(ctx).WithError(err).Warn("kyc check not queued")
+	}
+
 	return data.Result, nil
 }
 
 func (s *augmontService) UpdateUserKycStatus(
+	ctx context.Context,
 	user *models.AugmontUser,
 ) error {
 
@@ -420,14 +385,14 @@ func (s *augmontService) UpdateUserKycStatus(
 	method := "GET"
 
 	// Create Get Request
-	req, err := http.NewRequest(method, url, nil)
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
 		return err
 	}
 
 	// Add Request Headers
 	{
-		token, err := s.AuhtToken()
+		token, err := s.auth.AuthToken(ctx)
 		if err != nil {
 			return err
 		}
@@ -436,8 +401,7 @@ func (s *augmontService) UpdateUserKycStatus(
 		req.Header.Add("Content-Type", "application/json")
 	}
 	// Make Request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := s.client.Do(req)
 	if err != nil {
 		return err
 	}
@@ -445,25 +409,44 @@ func (s *augmontService) UpdateUserKycStatus(
 
 	// Decode Response body
 	data := make(map[string]interface{})
-	json.NewDecoder(resp.Body).Decode(&data)
+	if err := decodeAugmont(resp, &data); err != nil {
+		return err
+	}
 	if data["statusCode"] != float64(200) {
-		err = fmt.Errorf("%v", data["message"])
-		return domain.NewError(err, domain.ErrInvalidArgument)
+		return augmontDataError(data)
 	}
 
-	// Update user kyc status
-	status := "approved"
-	err = s.user.UpdateUser(&models.AugmontUser{
-		ID:        user.UserID,
-		KYCStatus: &status,
+	// Update user kyc status, responses without one approve it as before
+	status := models.KYCApproved
+	if result, ok := data["result"].(map[string]interface{}); ok {
+		if d, ok := result["data"].(map[string]interface{}); ok {
+			if v := strings.ToLower(detailString(d, "kycStatus", "status")); v != "" {
+				status = v
+			}
+		}
+	}
+	if status != models.KYCApproved && status != models.KYCRejected {
+		return nil
+	}
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		err := s.user.UpdateUser(ctx, &models.AugmontUser{
+			ID:        user.ID,
+			KYCStatus: &status,
+		})
+		if err != nil {
+			return err
+		}
+		return s.outbox.Add(ctx, kycEvent(user, status))
 	})
 	if err != nil {
 		return err
 	}
+	user.KYCStatus = &status
 	return nil
 }
 
 func (s *augmontService) CreateUserBank(
+	ctx context.Context,
 	user *models.AugmontUser,
 	bankInfo *utils.AugmontUserBankInfo,
 ) error {
@@ -480,11 +463,10 @@ func (s *augmontService) CreateUserBank(
 	agUrl := host + "/merchant/v1/users/" + uniqueID + "/banks"
 
 	info := utils.GetNonEmptyFields(bankInfo)
-	log.Println(info.ToUrlString())
 	payload := strings.NewReader(info.ToUrlString())
 
 	// Create New Request
-	req, err := http.NewRequest(http.MethodPost, agUrl, payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, agUrl, payload)
 
 	if err != nil {
 		return err
@@ -492,7 +474,7 @@ func (s *augmontService) CreateUserBank(
 
 	// Add Request Headers
 	{
-		token, err := s.AuhtToken()
+		token, err := s.auth.AuthToken(ctx)
 		if err != nil {
 			return err
 		}
@@ -502,8 +484,7 @@ func (s *augmontService) CreateUserBank(
 	}
 
 	// Make Request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := s.client.Do(req)
 	if err != nil {
 		return err
 	}
@@ -511,10 +492,11 @@ func (s *augmontService) CreateUserBank(
 
 	// Decode Response body
 	data := make(map[string]interface{})
-	json.NewDecoder(resp.Body).Decode(&data)
+	if err := decodeAugmont(resp, &data); err != nil {
+		return err
+	}
 	if data["statusCode"] != float64(200) {
-		err = fmt.Errorf("%v", data["errors"])
-		return domain.NewError(err, domain.ErrInvalidArgument)
+		return augmontDataError(data)
 	}
 
 	// Get user Bank Info
@@ -527,12 +509,11 @@ func (s *augmontService) CreateUserBank(
 		UserBankID:    &bank.UserBankID,
 		AugmontUserID: user.ID,
 	}
-	s.user.CreateBank(&userBank)
-
-	return nil
+	return s.user.CreateBank(ctx, &userBank)
 }
 
 func (s *augmontService) UpdateUserBank(
+	ctx context.Context,
 	user *models.AugmontUser,
 	bankInfo *utils.AugmontUserBankInfo,
 ) error {
@@ -548,14 +529,14 @@ func (s *augmontService) UpdateUserBank(
 	payload := strings.NewReader(info.ToUrlString())
 
 	// Create New Request
-	req, err := http.NewRequest(method, url, payload)
+	req, err := http.NewRequestWithContext(ctx, method, url, payload)
 	if err != nil {
 		return err
 	}
 
 	// Add Request Headers
 	{
-		token, err := s.AuhtToken()
+		token, err := s.auth.AuthToken(ctx)
 		if err != nil {
 			return err
 		}
@@ -565,8 +546,7 @@ func (s *augmontService) UpdateUserBank(
 	}
 
 	// Make Request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := s.client.Do(req)
 	if err != nil {
 		return err
 	}
@@ -574,17 +554,18 @@ func (s *augmontService) UpdateUserBank(
 
 	// Decode Response body
 	data := make(map[string]interface{})
-	json.NewDecoder(resp.Body).Decode(&data)
+	if err := decodeAugmont(resp, &data); err != nil {
+		return err
+	}
 	if data["statusCode"] != float64(200) {
-		errs := utils.Dict(data["errors"].(map[string]interface{}))
-		err = fmt.Errorf("%v", errs.ToString())
-		return domain.NewError(err, domain.ErrInvalidArgument)
+		return augmontDataError(data)
 	}
 
 	return nil
 }
 
 func (s *augmontService) DeleteUserBank(
+	ctx context.Context,
 	user *models.AugmontUser,
 	bankInfo *utils.AugmontUserBankInfo,
 ) error {
@@ -598,14 +579,14 @@ func (s *augmontService) DeleteUserBank(
 	method := "DELETE"
 
 	// Create New Request
-	req, err := http.NewRequest(method, url, nil)
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
 		return err
 	}
 
 	// Add Request Headers
 	{
-		token, err := s.AuhtToken()
+		token, err := s.auth.AuthToken(ctx)
 		if err != nil {
 			return err
 		}
@@ -614,8 +595,7 @@ func (s *augmontService) DeleteUserBank(
 	}
 
 	// Make Request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := s.client.Do(req)
 	if err != nil {
 		return err
 	}
@@ -623,14 +603,15 @@ func (s *augmontService) DeleteUserBank(
 
 	// Decode Response body
 	data := make(map[string]interface{})
-	json.NewDecoder(resp.Body).Decode(&data)
+	if err := decodeAugmont(resp, &data); err != nil {
+		return err
+	}
 	if data["statusCode"] != float64(200) {
-		err = fmt.Errorf("%v", data["message"])
-		return domain.NewError(err, domain.ErrInvalidArgument)
+		return augmontDataError(data)
 	}
 
 	// Delete user bank info
-	s.user.DeleteBank(&models.AugmontUserBank{
+	s.user.DeleteBank(ctx, &models.AugmontUserBank{
 		AugmontUserID: user.ID,
 		UserBankID:    &bankInfo.UserBankID,
 	})
@@ -639,6 +620,7 @@ func (s *augmontService) DeleteUserBank(
 }
 
 func (s *augmontService) GetUserBanks(
+	ctx context.Context,
 	user *models.AugmontUser,
 ) (
 	[]*utils.AugmontUserBankInfo,
@@ -653,14 +635,14 @@ func (s *augmontService) GetUserBanks(
 	method := "GET"
 
 	// Create New Request
-	req, err := http.NewRequest(method, url, nil)
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	// Add Request Headers
 	{
-		token, err := s.AuhtToken()
+		token, err := s.auth.AuthToken(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -670,8 +652,7 @@ func (s *augmontService) GetUserBanks(
 	}
 
 	// Make Request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := s.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -679,11 +660,12 @@ func (s *augmontService) GetUserBanks(
 
 	// Decode Response body
 	data := make(map[string]interface{})
-	json.NewDecoder(resp.Body).Decode(&data)
+	if err := decodeAugmont(resp, &data); err != nil {
+		return nil, err
+	}
 	// handle error in response
 	if data["statusCode"] != float64(200) {
-		err = fmt.Errorf("%v", data["message"])
-		return nil, domain.NewError(err, domain.ErrInvalidArgument)
+		return nil, augmontDataError(data)
 	}
 
 	// parse response bank info
@@ -704,6 +686,7 @@ func (s *augmontService) GetUserBanks(
 }
 
 func (s *augmontService) CreateUserAddress(
+	ctx context.Context,
 	user *models.AugmontUser,
 	addressInfo *utils.AugmontUserAddressInfo,
 ) error {
@@ -718,22 +701,22 @@ func (s *augmontService) CreateUserAddress(
 	payload := strings.NewReader(info.ToUrlString())
 
 	// Create New Request
-	client := &http.Client{}
-	req, err := http.NewRequest(method, url, payload)
+	req, err := http.NewRequestWithContext(ctx, method, url, payload)
 	if err != nil {
 		return err
 	}
 
 	// Add Request Headers
 	{
-		token, err := s.AuhtToken()
+		token, err := s.auth.AuthToken(ctx)
 		if err != nil {
 			return err
 		}
 		req.Header.Add("Authorization", "Bearer "+token)
 		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	}
-	res, err := client.Do(req)
+	// Make Request
+	res, err := s.client.Do(req)
 	if err != nil {
 		return err
 	}
@@ -741,19 +724,19 @@ func (s *augmontService) CreateUserAddress(
 
 	// Decode Response body
 	data := make(map[string]interface{})
-	json.NewDecoder(res.Body).Decode(&data)
+	if err := decodeAugmont(res, &data); err != nil {
+		return err
+	}
 	// handle error in response
 	if data["statusCode"] != float64(200) {
-		errs := utils.Dict(data["errors"].(map[string]interface{}))
-		err = fmt.Errorf("%v", errs.ToString())
-		return domain.NewError(err, domain.ErrInvalidArgument)
+		return augmontDataError(data)
 	}
 
 	// Save user address info
 	result := data["result"].(map[string]interface{})
 	address := utils.AugmontUserAddressInfo{}
 	mapstructure.Decode(result, &address)
-	err = s.user.CreateAddress(&models.AugmontUserAddress{
+	err = s.user.CreateAddress(ctx, &models.AugmontUserAddress{
 		AugmontUserID: user.ID,
 		UserAddressID: &address.UserAddressID,
 	})
@@ -762,6 +745,7 @@ func (s *augmontService) CreateUserAddress(
 }
 
 func (s *augmontService) DeleteUserAddress(
+	ctx context.Context,
 	user *models.AugmontUser,
 	addressInfo *utils.AugmontUserAddressInfo,
 ) error {
@@ -775,14 +759,14 @@ func (s *augmontService) DeleteUserAddress(
 	method := "DELETE"
 
 	// Create New Request
-	req, err := http.NewRequest(method, url, nil)
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
 		return err
 	}
 
 	// Add Request Headers
 	{
-		token, err := s.AuhtToken()
+		token, err := s.auth.AuthToken(ctx)
 		if err != nil {
 			return err
 		}
@@ -792,8 +776,7 @@ func (s *augmontService) DeleteUserAddress(
 	}
 
 	// Make Request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := s.client.Do(req)
 	if err != nil {
 		return err
 	}
@@ -801,14 +784,15 @@ func (s *augmontService) DeleteUserAddress(
 
 	// Decode Response body
 	data := make(map[string]interface{})
-	json.NewDecoder(resp.Body).Decode(&data)
+	if err := decodeAugmont(resp, &data); err != nil {
+		return err
+	}
 	if data["statusCode"] != float64(200) {
-		err = fmt.Errorf("%v", data["message"])
-		return domain.NewError(err, domain.ErrInvalidArgument)
+		return augmontDataError(data)
 	}
 
 	// Delete user address info
-	err = s.user.DeleteAddress(&models.AugmontUserAddress{
+	err = s.user.DeleteAddress(ctx, &models.AugmontUserAddress{
 		AugmontUserID: user.ID,
 		UserAddressID: &addressInfo.UserAddressID,
 	})
@@ -817,6 +801,7 @@ func (s *augmontService) DeleteUserAddress(
 }
 
 func (s *augmontService) GetUserAddresses(
+	ctx context.Context,
 	user *models.AugmontUser,
 ) (
 	[]*utils.AugmontUserAddressInfo,
@@ -831,14 +816,14 @@ func (s *augmontService) GetUserAddresses(
 	method := "GET"
 
 	// Create New Request
-	req, err := http.NewRequest(method, url, nil)
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	// Add Request Headers
 	{
-		token, err := s.AuhtToken()
+		token, err := s.auth.AuthToken(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -847,8 +832,8 @@ func (s *augmontService) GetUserAddresses(
 		req.Header.Add("Content-Type", "application/json")
 	}
 
-	client := http.Client{}
-	resp, err := client.Do(req)
+	// Make Request
+	resp, err := s.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -857,12 +842,12 @@ func (s *augmontService) GetUserAddresses(
 
 	// Decode Response body
 	data := make(map[string]interface{})
-	json.NewDecoder(resp.Body).Decode(&data)
+	if err := decodeAugmont(resp, &data); err != nil {
+		return nil, err
+	}
 	// handle error in response
 	if data["statusCode"] != float64(200) {
-		errs := utils.Dict(data["errors"].(map[string]interface{}))
-		err = fmt.Errorf("%v", errs.ToString())
-		return nil, domain.NewError(err, domain.ErrInvalidArgument)
+		return nil, augmontDataError(data)
 	}
 
 	// parse response bank info
@@ -882,9 +867,14 @@ func (s *augmontService) GetUserAddresses(
 }
 
 func (s *augmontService) Buy(
+	ctx context.Context,
 	user *models.AugmontUser,
 	buyInfo *utils.AugmontBugInfo,
 ) (utils.Any, error) {
+	if err := checkOrderLimits(buyInfo.MetalType, buyInfo.Quantity, buyInfo.Amount, buyInfo.LockPrice); err != nil {
+		return nil, err
+	}
+
 	// Prepare request body
 	url := fmt.Sprintf("%v/merchant/v1/buy",
 		domain.Config().Augmont.Host,
@@ -918,14 +908,14 @@ func (s *augmontService) Buy(
 	}
 
 	// Create New Request
-	req, err := http.NewRequest(method, url, payload)
+	req, err := http.NewRequestWithContext(ctx, method, url, payload)
 	if err != nil {
 		return nil, err
 	}
 
 	// Add Request Headers
 	{
-		token, err := s.AuhtToken()
+		token, err := s.auth.AuthToken(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -935,8 +925,7 @@ func (s *augmontService) Buy(
 	}
 
 	// Make Request
-	client := &http.Client{}
-	res, err := client.Do(req)
+	res, err := s.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -944,30 +933,52 @@ func (s *augmontService) Buy(
 
 	// Decode Response body
 	data := utils.AugmontResponse{}
-	json.NewDecoder(res.Body).Decode(&data)
+	if err := decodeAugmont(res, &data); err != nil {
+		return nil, err
+	}
 
-	// handle error in response
+	// handle error in response, the refused order is kept to be rerun
 	if data.IsError() {
-		return nil, data.Error()
+		info := failedOrderInfo(buyInfo.MetalType, buyInfo.Quantity, buyInfo.Amount, buyInfo.LockPrice)
+		err := s.order.CreateBuy(ctx, &models.AugmontBuyOrder{
+			AugmontUserID:    user.ID,
+			MerchantTxnID:    &buyInfo.MerchantTnxID,
+			AugmontOrderInfo: info,
+		})
+		if err != nil {
+			domain.Logger(ctx).WithError(err).Warn("failed buy not saved")
+		}
+		return nil, augmontResponseError(&data)
 	}
 
-	// Update buy orders table
-	err = s.order.CreateBuy(&models.AugmontBuyOrder{
-		AugmontUserID: user.ID,
-		MerchantTxnID: &buyInfo.MerchantTnxID,
+	metrics.OrdersCreated.WithLabelValues("buy", metalLabel(buyInfo.MetalType)).Inc()
+
+	// Update buy orders table with the event of the buy
+	info := orderInfo(data.Result,
+		buyInfo.MetalType, buyInfo.Quantity, buyInfo.Amount, buyInfo.LockPrice)
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		err := s.order.CreateBuy(ctx, &models.AugmontBuyOrder{
+			AugmontUserID:    user.ID,
+			MerchantTxnID:    &buyInfo.MerchantTnxID,
+			AugmontOrderInfo: info,
+		})
+		if err != nil {
+			return err
+		}
+		return s.outbox.Add(ctx, &events.BuyCompleted{Order: orderEvent(user, buyInfo.MerchantTnxID, info)})
 	})
 
 	return data.Result, err
 }
 
 func (s *augmontService) getOrder(
+	ctx context.Context,
 	url string,
 ) (utils.Any, error) {
 	// Prepare request
 	method := "GET"
 
-	client := &http.Client{}
-	req, err := http.NewRequest(method, url, nil)
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 
 	if err != nil {
 		return nil, err
@@ -975,7 +986,7 @@ func (s *augmontService) getOrder(
 
 	// Add Request Headers
 	{
-		token, err := s.AuhtToken()
+		token, err := s.auth.AuthToken(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -983,7 +994,8 @@ func (s *augmontService) getOrder(
 		req.Header.Add("Content-Type", "application/json")
 		req.Header.Add("Authorization", " Bearer "+token)
 	}
-	res, err := client.Do(req)
+	// Make Request
+	res, err := s.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -991,11 +1003,12 @@ func (s *augmontService) getOrder(
 
 	// Decode Response body
 	data := utils.Dict{}
-	json.NewDecoder(res.Body).Decode(&data)
+	if err := decodeAugmont(res, &data); err != nil {
+		return nil, err
+	}
 
 	if data["statusCode"] != float64(200) {
-		err = fmt.Errorf("%v", data["message"])
-		return nil, domain.NewError(err, domain.ErrInvalidArgument)
+		return nil, augmontDataError(data)
 	}
 
 	// Get embedder type
@@ -1005,6 +1018,7 @@ func (s *augmontService) getOrder(
 }
 
 func (s *augmontService) BuyInfo(
+	ctx context.Context,
 	userUniqueID,
 	tnxID string,
 ) (utils.Any, error) {
@@ -1014,24 +1028,28 @@ func (s *augmontService) BuyInfo(
 		tnxID,
 	)
 
-	result, err := s.getOrder(url)
+	result, err := s.getOrder(ctx, url)
 	return result, err
 }
 
-func (s *augmontService) BuyList(userUniqueID string) (utils.Any, error) {
+func (s *augmontService) BuyList(ctx context.Context, userUniqueID string) (utils.Any, error) {
 	url := fmt.Sprintf("%v/merchant/v1/%v/buy",
 		domain.Config().Augmont.Host,
 		userUniqueID,
 	)
 
-	result, err := s.getOrder(url)
+	result, err := s.getOrder(ctx, url)
 	return result, err
 }
 
 func (s *augmontService) Sell(
+	ctx context.Context,
 	user *models.AugmontUser,
 	sellInfo *utils.AugmontSellInfo,
 ) (utils.Any, error) {
+	if err := checkOrderLimits(sellInfo.MetalType, sellInfo.Quantity, sellInfo.Amount, sellInfo.LockPrice); err != nil {
+		return nil, err
+	}
 
 	// Generate New Transaction ID
 	{
@@ -1064,14 +1082,14 @@ func (s *augmontService) Sell(
 	}
 
 	// Create New Request
-	req, err := http.NewRequest(method, url, payload)
+	req, err := http.NewRequestWithContext(ctx, method, url, payload)
 	if err != nil {
 		return nil, err
 	}
 
 	// Add Request Headers
 	{
-		token, err := s.AuhtToken()
+		token, err := s.auth.AuthToken(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -1082,8 +1100,7 @@ func (s *augmontService) Sell(
 	}
 
 	// Make Request
-	client := &http.Client{}
-	res, err := client.Do(req)
+	res, err := s.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -1091,23 +1108,70 @@ func (s *augmontService) Sell(
 
 	// Decode Response body
 	data := utils.AugmontResponse{}
-	json.NewDecoder(res.Body).Decode(&data)
+	if err := decodeAugmont(res, &data); err != nil {
+		return nil, err
+	}
 
-	// handle error in response
+	// handle error in response, the refused order is kept to be rerun
 	if data.IsError() {
-		return nil, data.Error()
+		info := failedOrderInfo(sellInfo.MetalType, sellInfo.Quantity, sellInfo.Amount, sellInfo.LockPrice)
+		err := s.order.CreateSell(ctx, &models.AugmontSellOrder{
+			AugmontUserID:    user.ID,
+			MerchantTxnID:    &sellInfo.MerchantTnxID,
+			AugmontOrderInfo: info,
+		})
+		if err != nil {
+			domain.Logger(ctx).WithError(err).Warn("failed sell not saved")
+		}
+		return nil, augmontResponseError(&data)
 	}
 
-	// Update sell orders table
-	err = s.order.CreateSell(&models.AugmontSellOrder{
-		AugmontUserID: user.ID,
-		MerchantTxnID: &sellInfo.MerchantTnxID,
+	metrics.OrdersCreated.WithLabelValues("sell", metalLabel(sellInfo.MetalType)).Inc()
+
+	// Update sell orders table with the event of the sell
+	info := orderInfo(data.Result,
+		sellInfo.MetalType, sellInfo.Quantity, sellInfo.Amount, sellInfo.LockPrice)
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		err := s.order.CreateSell(ctx, &models.AugmontSellOrder{
+			AugmontUserID:    user.ID,
+			MerchantTxnID:    &sellInfo.MerchantTnxID,
+			AugmontOrderInfo: info,
+		})
+		if err != nil {
+			return err
+		}
+		return s.outbox.Add(ctx, &events.SellCompleted{Order: orderEvent(user, sellInfo.MerchantTnxID, info)})
 	})
 
 	return data.Result, err
 }
 
+// orderEvent is the order of the order events
+func orderEvent(user *models.AugmontUser, txnID string, info models.AugmontOrderInfo) events.Order {
+	return events.Order{
+		UserID:        derefID(user.UserID),
+		AugmontUserID: derefID(user.ID),
+		MerchantTxnID: txnID,
+		MetalType:     deref(info.MetalType),
+		Quantity:      deref(info.Quantity),
+		Amount:        deref(info.Amount),
+	}
+}
+
+// kycEvent returns the event of the KYC status of the user
+func kycEvent(user *models.AugmontUser, status string) events.Event {
+	kyc := events.KYC{
+		UserID:        derefID(user.UserID),
+		AugmontUserID: derefID(user.ID),
+	}
+	if status == models.KYCRejected {
+		return &events.KYCRejected{KYC: kyc}
+	}
+	return &events.KYCApproved{KYC: kyc}
+}
+
 func (s *augmontService) SellInfo(
+	ctx context.Context,
 	userUniqueID,
 	tnxID string,
 ) (utils.Any, error) {
@@ -1116,7 +1180,7 @@ func (s *augmontService) SellInfo(
 		tnxID,
 		userUniqueID,
 	)
-	resp, err := s.getOrder(url)
+	resp, err := s.getOrder(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -1125,6 +1189,7 @@ func (s *augmontService) SellInfo(
 }
 
 func (s *augmontService) SellList(
+	ctx context.Context,
 	userUniqueID string,
 ) (utils.Any, error) {
 
@@ -1132,7 +1197,7 @@ func (s *augmontService) SellList(
 		domain.Config().Augmont.Host,
 		userUniqueID,
 	)
-	resp, err := s.getOrder(url)
+	resp, err := s.getOrder(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -1141,8 +1206,10 @@ func (s *augmontService) SellList(
 }
 
 func (s *augmontService) Redeem(
+	ctx context.Context,
 	user *models.AugmontUser,
 	redeemInfo *utils.AugmontRedeemInfo,
+	weights map[string]decimal.Decimal,
 ) (utils.Any, error) {
 
 	// Generate New Transaction ID
@@ -1162,27 +1229,31 @@ func (s *augmontService) Redeem(
 	{
 		order := utils.GetNonEmptyFields(redeemInfo)
 		for key, value := range order {
-			if value == "" {
+			// Products are written as indexed fields below
+			value, ok := value.(string)
+			if !ok || value == "" {
 				continue
 			}
-			if err := writer.WriteField(key, value.(string)); err != nil {
+			if err := writer.WriteField(key, value); err != nil {
 				return nil, err
 			}
 		}
+		if err := redeemInfo.Write(writer); err != nil {
+			return nil, err
+		}
 		err := writer.Close()
 		if err != nil {
 			return nil, err
 		}
 	}
 	// Create New Request
-	client := &http.Client{}
-	req, err := http.NewRequest(method, url, payload)
+	req, err := http.NewRequestWithContext(ctx, method, url, payload)
 	if err != nil {
 		return nil, err
 	}
 	// Add Request Headers
 	{
-		token, err := s.AuhtToken()
+		token, err := s.auth.AuthToken(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -1191,30 +1262,59 @@ func (s *augmontService) Redeem(
 		req.Header.Set("Content-Type", writer.FormDataContentType())
 	}
 	// Make Request
-	res, err := client.Do(req)
+	res, err := s.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer res.Body.Close()
 
 	data := utils.AugmontResponse{}
-	json.NewDecoder(res.Body).Decode(&data)
+	if err := decodeAugmont(res, &data); err != nil {
+		return nil, err
+	}
 
 	// handle error in response
 	if data.IsError() {
-		return nil, data.Error()
+		return nil, augmontResponseError(&data)
 	}
 
-	// Update sell orders table
-	err = s.order.CreateRedeem(&models.AugmontRedeemOrder{
-		AugmontUserID: user.ID,
-		MerchantTxnID: &redeemInfo.MerchantTnxID,
+	// Update redeem orders table, the shipment is tracked from here
+	placed := models.ShipmentPlaced
+	order := &models.AugmontRedeemOrder{
+		AugmontUserID:    user.ID,
+		MerchantTxnID:    &redeemInfo.MerchantTnxID,
+		AugmontOrderInfo: orderInfo(data.Result, "", "", "", ""),
+		ShipmentStatus:   &placed,
+		Metals:           redeemMetals(weights),
+	}
+	// The products decide the metal, the order keeps the first
+	if len(order.Metals) > 0 {
+		order.MetalType, order.Quantity = order.Metals[0].MetalType, order.Metals[0].Quantity
+	}
+	for _, m := range order.Metals {
+		metrics.OrdersCreated.WithLabelValues("redeem", *m.MetalType).Inc()
+	}
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.order.CreateRedeem(ctx, order); err != nil {
+			return err
+		}
+		return s.outbox.Add(ctx, redeemEvent(user, order))
 	})
 
 	return data.Result, err
 }
 
+// redeemEvent returns the event of the redeem order of the user
+func redeemEvent(user *models.AugmontUser, order *models.AugmontRedeemOrder) *events.RedeemCompleted {
+	ev := &events.RedeemCompleted{Order: orderEvent(user, *order.MerchantTxnID, order.AugmontOrderInfo)}
+	for _, m := range order.Metals {
+		ev.Metals = append(ev.Metals, events.Metal{MetalType: *m.MetalType, Quantity: *m.Quantity})
+	}
+	return ev
+}
+
 func (s *augmontService) RedeemInfo(
+	ctx context.Context,
 	userUniqueID,
 	tnxID string,
 ) (utils.Any, error) {
@@ -1223,7 +1323,7 @@ func (s *augmontService) RedeemInfo(
 		tnxID,
 		userUniqueID,
 	)
-	resp, err := s.getOrder(url)
+	resp, err := s.getOrder(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -1231,16 +1331,162 @@ func (s *augmontService) RedeemInfo(
 	return resp, err
 }
 
-func (s *augmontService) RedeemList(userUniqueID string) (utils.Any, error) {
+func (s *augmontService) RedeemList(ctx context.Context, userUniqueID string) (utils.Any, error) {
 
 	url := fmt.Sprintf("%v/merchant/v1/%v/order",
 		domain.Config().Augmont.Host,
 		userUniqueID,
 	)
-	resp, err := s.getOrder(url)
+	resp, err := s.getOrder(ctx, url)
 	if err != nil {
 		return nil, err
 	}
 
 	return resp, err
 }
+
+func (s *augmontService) Invoice(ctx context.Context, tnxID string) (utils.Any, error) {
+	url := fmt.Sprintf("%v/merchant/v1/invoice/%v",
+		domain.Config().Augmont.Host,
+		tnxID,
+	)
+	return s.getOrder(ctx, url)
+}
+
+// ratePrefixes are the prefixes of the rates of each metal in augmont rates
+var ratePrefixes = map[utils.Metal]string{
+	utils.Gold:   "g",
+	utils.Silver: "s",
+}
+
+func (s *augmontService) Rates(ctx context.Context) (*utils.MetalRates, error) {
+	url := fmt.Sprintf("%v/merchant/v1/rates",
+		domain.Config().Augmont.Host,
+	)
+	result, err := s.getOrder(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	data, _ := result.(map[string]interface{})
+	quoted, _ := data["rates"].(map[string]interface{})
+	rate := func(key string) decimal.Decimal {
+		d, _ := decimal.NewFromString(fmt.Sprint(quoted[key]))
+		return d
+	}
+	rates := &utils.MetalRates{BlockID: fmt.Sprint(data["blockId"])}
+	for _, metal := range utils.Metals {
+		prefix := ratePrefixes[metal]
+		if _, ok := quoted[prefix+"Buy"]; !ok {
+			continue
+		}
+		rates.Rates = append(rates.Rates, &utils.MetalRate{
+			Metal:  metal,
+			Buy:    rate(prefix + "Buy"),
+			BuyGST: rate(prefix + "BuyGst"),
+			Sell:   rate(prefix + "Sell"),
+		})
+	}
+	if len(rates.Rates) == 0 {
+		return nil, domain.NewError(errors.New("augmont sent no rates"), domain.ErrUnavailable)
+	}
+	return rates, nil
+}
+
+func (s *augmontService) Products(ctx context.Context, page, count int) (utils.Any, error) {
+	url := fmt.Sprintf("%v/merchant/v1/products?page=%d&count=%d",
+		domain.Config().Augmont.Host,
+		page,
+		count,
+	)
+	return s.getOrder(ctx, url)
+}
+
+func (s *augmontService) Serviceable(ctx context.Context, pincode string) (bool, error) {
+	url := fmt.Sprintf("%v/merchant/v1/pincode/%v",
+		domain.Config().Augmont.Host,
+		pincode,
+	)
+	_, err := s.getOrder(ctx, url)
+	if err == nil {
+		return true, nil
+	}
+	// Augmont rejects the pincodes it does not deliver to
+	if e, ok := domain.AsError(err); ok &&
+		(e.Type() == domain.ErrNotFound || e.Type() == domain.ErrInvalidArgument) {
+		return false, nil
+	}
+	return false, err
+}
+
+func (s *augmontService) Transfer(
+	ctx context.Context,
+	info *utils.AugmontTransferInfo,
+) (utils.Any, error) {
+
+	// Generate New Transaction ID, retries keep theirs
+	if info.MerchantTnxID == "" {
+		info.MerchantTnxID = s.newTnxID()
+	}
+
+	// Prepare request body
+	url := fmt.Sprintf("%v/merchant/v1/transfer",
+		domain.Config().Augmont.Host,
+	)
+	method := "POST"
+
+	payload := &bytes.Buffer{}
+	writer := multipart.NewWriter(payload)
+	{
+		fields := utils.GetNonEmptyFields(info)
+		for key, value := range fields {
+			if value == "" {
+				continue
+			}
+			if err := writer.WriteField(key, value.(string)); err != nil {
+				return nil, err
+			}
+		}
+		err := writer.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Create New Request
+	req, err := http.NewRequestWithContext(ctx, method, url, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add Request Headers
+	{
+		token, err := s.auth.AuthToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Add("Accept", "application/json")
+		req.Header.Add("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+	}
+
+	// Make Request
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	// Decode Response body
+	data := utils.AugmontResponse{}
+	if err := decodeAugmont(res, &data); err != nil {
+		return nil, err
+	}
+
+	// handle error in response
+	if data.IsError() {
+		return nil, augmontResponseError(&data)
+	}
+
+	return data.Result, nil
+}
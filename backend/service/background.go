@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+)
+
+// background runs the work started by requests after they are done, on
+// its own context, the work is awaited on shutdown and cancelled if
+// it does not finish in time
+type background struct {
+	base    context.Context
+	cancel  context.CancelFunc
+	running sync.WaitGroup
+}
+
+// newBackground registers the stop hook of the work under the name
+func newBackground(lifecycle *domain.Lifecycle, name string) *background {
+	base, cancel := context.WithCancel(context.Background())
+	b := &background{
+		base:   base,
+		cancel: cancel,
+	}
+	lifecycle.Append(domain.Hook{
+		Name:   name,
+		OnStop: b.stop,
+	})
+	return b
+}
+
+// Go runs the work with the logger of the request context
+func (b *background) Go(ctx context.Context, work func(ctx context.Context)) {
+	bgCtx := domain.ContextWithLogger(b.base, domain.Logger(ctx))
+	b.running.Add(1)
+	go func() {
+		defer b.running.Done()
+		work(bgCtx)
+	}()
+}
+
+func (b *background) stop(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		b.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		b.cancel()
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	}
+}
+
+// startWorker runs the work until shutdown, unlike background work the
+// worker is cancelled first and then awaited
+func startWorker(ctx context.Context, lifecycle *domain.Lifecycle, name string, work func(ctx context.Context)) {
+	workerCtx, cancel := context.WithCancel(domain.ContextWithLogger(context.Background(), domain.Logger(ctx)))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		work(workerCtx)
+	}()
+	lifecycle.Append(domain.Hook{
+		Name: name,
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+}
+
+// workerName names the process among the replicas
+func workerName() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%v-%d", host, os.Getpid())
+}
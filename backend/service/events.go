@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/events"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/jobs"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+// Outbox events published at once by the relay
+const relayBatch = 100
+
+type eventRelay struct {
+	outbox interfaces.OutboxRepo
+	bus    interfaces.EventBus
+}
+
+// NewEventRelay creates a new EventRelay, an event is published again
+// if the relay stops before marking it published
+func NewEventRelay(outbox interfaces.OutboxRepo, bus interfaces.EventBus) interfaces.EventRelay {
+	return &eventRelay{
+		outbox: outbox,
+		bus:    bus,
+	}
+}
+
+func (r *eventRelay) RelayOnce(ctx context.Context) (int, error) {
+	relayed := 0
+	for {
+		n, err := r.outbox.Relay(ctx, relayBatch, func(evs []*models.OutboxEvent) error {
+			return r.bus.Publish(ctx, evs)
+		})
+		relayed += n
+		if err != nil || n < relayBatch {
+			return relayed, err
+		}
+	}
+}
+
+func (r *eventRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(domain.Config().Events.RelayInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := r.RelayOnce(ctx); err != nil && ctx.Err() == nil {
+			domain.Logger(ctx).WithError(err).Warn("events not relayed")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+type notificationConsumer struct {
+	notifier interfaces.EventNotifier
+}
+
+// NewNotificationConsumer creates the consumer group notifying users of
+// the events of their account, once however often an event is delivered
+func NewNotificationConsumer(notifier interfaces.EventNotifier) interfaces.EventConsumer {
+	return &notificationConsumer{
+		notifier: notifier,
+	}
+}
+
+func (c *notificationConsumer) Group() string {
+	return "notifications"
+}
+
+func (c *notificationConsumer) Handle(ctx context.Context, e *events.Envelope) error {
+	var (
+		userID uint64
+		args   map[string]string
+	)
+	switch ev := e.Event.(type) {
+	case *events.BuyCompleted:
+		userID, args = ev.UserID, orderArgs(ev.Order)
+	case *events.SellCompleted:
+		userID, args = ev.UserID, orderArgs(ev.Order)
+	case *events.KYCApproved:
+		userID = ev.UserID
+	case *events.KYCRejected:
+		userID = ev.UserID
+	default:
+		return nil
+	}
+	if userID == 0 {
+		return nil
+	}
+	// Event types are named as the notifications they send
+	return c.notifier.NotifyEvent(ctx, e.ID, userID, e.Type, args)
+}
+
+type invoiceConsumer struct {
+	queue interfaces.JobQueue
+}
+
+// NewInvoiceConsumer creates the consumer group queueing the invoice of
+// each buy and redeem, however the order was placed
+func NewInvoiceConsumer(queue interfaces.JobQueue) interfaces.EventConsumer {
+	return &invoiceConsumer{
+		queue: queue,
+	}
+}
+
+func (c *invoiceConsumer) Group() string {
+	return "invoices"
+}
+
+func (c *invoiceConsumer) Handle(ctx context.Context, e *events.Envelope) error {
+	var order events.Order
+	switch ev := e.Event.(type) {
+	case *events.BuyCompleted:
+		order = ev.Order
+	case *events.RedeemCompleted:
+		order = ev.Order
+	default:
+		return nil
+	}
+	// A job queued twice stores the invoice once
+	_, err := c.queue.Enqueue(ctx, &jobs.FetchInvoice{
+		AugmontUserID: order.AugmontUserID,
+		MerchantTxnID: order.MerchantTxnID,
+	}, 0)
+	return err
+}
+
+// orderArgs are the args of the notifications of an order
+func orderArgs(o events.Order) map[string]string {
+	return map[string]string{
+		"metal":    o.MetalType,
+		"quantity": o.Quantity,
+		"amount":   o.Amount,
+	}
+}
+
+// StartEventWorkers runs the outbox relay and the event consumers until
+// shutdown
+func StartEventWorkers(
+	lifecycle *domain.Lifecycle,
+	relay interfaces.EventRelay,
+	bus interfaces.EventBus,
+	notifier interfaces.EventNotifier,
+	queue interfaces.JobQueue,
+) {
+	ctx := context.Background()
+	startWorker(ctx, lifecycle, "event relay", relay.Run)
+
+	consumers := []interfaces.EventConsumer{
+		NewNotificationConsumer(notifier),
+		NewInvoiceConsumer(queue),
+	}
+	name := workerName()
+	for _, consumer := range consumers {
+		consumer := consumer
+		startWorker(ctx, lifecycle, "events "+consumer.Group(), func(ctx context.Context) {
+			ctx = domain.ContextWithLogFields(ctx, log.Fields{"group": consumer.Group()})
+			if err := bus.Consume(ctx, consumer.Group(), name, consumer.Handle); err != nil {
+				domain.Logger(ctx).WithError(err).Error("event consumer stopped")
+			}
+		})
+	}
+}
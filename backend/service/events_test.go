@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/events"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/jobs"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+type fakeOutboxRepo struct {
+	interfaces.OutboxRepo
+	pending []*models.OutboxEvent
+	added   []events.Event
+}
+
+func (r *fakeOutboxRepo) Add(ctx context.Context, evs ...events.Event) error {
+	r.added = append(r.added, evs...)
+	return nil
+}
+
+func (r *fakeOutboxRepo) Relay(ctx context.Context, limit int, publish func(events []*models.OutboxEvent) error) (int, error) {
+	batch := r.pending
+	if len(batch) > limit {
+		batch = batch[:limit]
+	}
+	if len(batch) == 0 {
+		return 0, nil
+	}
+	if err := publish(batch); err != nil {
+		return 0, err
+	}
+	r.pending = r.pending[len(batch):]
+	return len(batch), nil
+}
+
+// fakeUnitOfWork runs the work without a transaction
+type fakeUnitOfWork struct{}
+
+func (fakeUnitOfWork) Do(ctx context.Context, work func(ctx context.Context) error) error {
+	return work(ctx)
+}
+
+type fakeEventBus struct {
+	interfaces.EventBus
+	published []*models.OutboxEvent
+	fail      bool
+}
+
+func (b *fakeEventBus) Publish(ctx context.Context, evs []*models.OutboxEvent) error {
+	if b.fail {
+		return errors.New("redis down")
+	}
+	b.published = append(b.published, evs...)
+	return nil
+}
+
+func TestEventRelay(t *testing.T) {
+	ctx := context.Background()
+	outbox := func(n int) *fakeOutboxRepo {
+		r := &fakeOutboxRepo{}
+		for i := 0; i < n; i++ {
+			id := uint64(i + 1)
+			r.pending = append(r.pending, &models.OutboxEvent{ID: &id})
+		}
+		return r
+	}
+
+	t.Run("should publish the outbox in batches", func(t *testing.T) {
+		repo, bus := outbox(relayBatch+1), &fakeEventBus{}
+		relayed, err := NewEventRelay(repo, bus).RelayOnce(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, relayBatch+1, relayed)
+		assert.Len(t, bus.published, relayBatch+1)
+		assert.Empty(t, repo.pending)
+	})
+
+	t.Run("should keep the events the bus did not take", func(t *testing.T) {
+		repo, bus := outbox(3), &fakeEventBus{fail: true}
+		relayed, err := NewEventRelay(repo, bus).RelayOnce(ctx)
+		assert.Error(t, err)
+		assert.Equal(t, 0, relayed)
+		assert.Len(t, repo.pending, 3)
+	})
+}
+
+func TestNotificationConsumer(t *testing.T) {
+	ctx := context.Background()
+	envelope := func(e events.Event) *events.Envelope {
+		return &events.Envelope{ID: 1, Type: e.EventType(), Event: e}
+	}
+
+	t.Run("should notify the user of the event", func(t *testing.T) {
+		notifier := &fakeNotifier{}
+		c := NewNotificationConsumer(notifier)
+		assert.NoError(t, c.Handle(ctx, envelope(&events.BuyCompleted{Order: events.Order{UserID: 2}})))
+		assert.NoError(t, c.Handle(ctx, envelope(&events.KYCRejected{KYC: events.KYC{UserID: 2}})))
+		assert.Equal(t, []string{"buy_completed", "kyc_rejected"}, notifier.sent)
+		assert.Equal(t, []uint64{1, 1}, notifier.events)
+	})
+
+	t.Run("should skip events without a user", func(t *testing.T) {
+		notifier := &fakeNotifier{}
+		c := NewNotificationConsumer(notifier)
+		assert.NoError(t, c.Handle(ctx, envelope(&events.KYCApproved{})))
+		assert.Empty(t, notifier.sent)
+	})
+}
+
+// fakeQueue records the jobs queued
+type fakeQueue struct {
+	interfaces.JobQueue
+	queued []jobs.Job
+	err    error
+}
+
+func (q *fakeQueue) Enqueue(ctx context.Context, job jobs.Job, delay time.Duration) (*models.Job, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	q.queued = append(q.queued, job)
+	return &models.Job{}, nil
+}
+
+func TestInvoiceConsumer(t *testing.T) {
+	ctx := context.Background()
+	envelope := func(e events.Event) *events.Envelope {
+		return &events.Envelope{ID: 1, Type: e.EventType(), Event: e}
+	}
+	order := events.Order{UserID: 2, AugmontUserID: 3, MerchantTxnID: "T1"}
+
+	t.Run("should queue the invoice of buys and redeems", func(t *testing.T) {
+		queue := &fakeQueue{}
+		c := NewInvoiceConsumer(queue)
+		assert.NoError(t, c.Handle(ctx, envelope(&events.BuyCompleted{Order: order})))
+		assert.NoError(t, c.Handle(ctx, envelope(&events.RedeemCompleted{Order: order})))
+		assert.NoError(t, c.Handle(ctx, envelope(&events.SellCompleted{Order: order})))
+		assert.Equal(t, []jobs.Job{
+			&jobs.FetchInvoice{AugmontUserID: 3, MerchantTxnID: "T1"},
+			&jobs.FetchInvoice{AugmontUserID: 3, MerchantTxnID: "T1"},
+		}, queue.queued)
+	})
+
+	t.Run("should fail the event when the job is not queued", func(t *testing.T) {
+		c := NewInvoiceConsumer(&fakeQueue{err: errors.New("redis down")})
+		assert.Error(t, c.Handle(ctx, envelope(&events.BuyCompleted{Order: order})))
+	})
+}
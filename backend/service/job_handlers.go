@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/jobs"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+// permanentIf marks the failures retrying will not fix as permanent,
+// the ones of the request itself rather than of Augmont or the network
+func permanentIf(err error) error {
+	if domain.ErrIs(err, domain.ErrInvalidArgument) || domain.ErrIs(err, domain.ErrNotFound) {
+		return jobs.Permanent(err)
+	}
+	return err
+}
+
+func wrongJob(job jobs.Job) error {
+	return jobs.Permanent(errors.Newf("unexpected job %T", job))
+}
+
+type invoiceJobHandler struct {
+	invoices interfaces.AugmontInvoiceService
+	user     interfaces.AugmontUserRepo
+}
+
+// NewInvoiceJobHandler creates the handler storing the invoices of new orders
+func NewInvoiceJobHandler(invoices interfaces.AugmontInvoiceService, user interfaces.AugmontUserRepo) JobHandlerResult {
+	return JobHandlerResult{Handler: &invoiceJobHandler{
+		invoices: invoices,
+		user:     user,
+	}}
+}
+
+func (h *invoiceJobHandler) JobType() string {
+	return jobs.TypeFetchInvoice
+}
+
+func (h *invoiceJobHandler) Handle(ctx context.Context, job jobs.Job) error {
+	j, ok := job.(*jobs.FetchInvoice)
+	if !ok {
+		return wrongJob(job)
+	}
+	user, err := h.user.FindUser(ctx, &models.AugmontUser{ID: &j.AugmontUserID})
+	if err != nil {
+		return errors.Wrap(err, "find augmont user")
+	}
+	_, err = h.invoices.Fetch(ctx, user, j.MerchantTxnID)
+	return permanentIf(err)
+}
+
+// errKYCPending retries the KYC check until Augmont decides
+var errKYCPending = errors.New("kyc still pending")
+
+type kycJobHandler struct {
+	gold interfaces.AugmontService
+	user interfaces.AugmontUserRepo
+}
+
+// NewKYCJobHandler creates the handler checking the submitted KYCs
+func NewKYCJobHandler(gold interfaces.AugmontService, user interfaces.AugmontUserRepo) JobHandlerResult {
+	return JobHandlerResult{Handler: &kycJobHandler{
+		gold: gold,
+		user: user,
+	}}
+}
+
+func (h *kycJobHandler) JobType() string {
+	return jobs.TypeRefreshKYC
+}
+
+func (h *kycJobHandler) Handle(ctx context.Context, job jobs.Job) error {
+	j, ok := job.(*jobs.RefreshKYC)
+	if !ok {
+		return wrongJob(job)
+	}
+	user, err := h.user.FindUser(ctx, &models.AugmontUser{ID: &j.AugmontUserID})
+	if err != nil {
+		return errors.Wrap(err, "find augmont user")
+	}
+	// Decided meanwhile, e.g. by the user checking the status
+	if user.KYCStatus != nil && *user.KYCStatus != models.KYCPending {
+		return nil
+	}
+	if err := h.gold.UpdateUserKycStatus(ctx, user); err != nil {
+		return permanentIf(err)
+	}
+	if user.KYCStatus == nil || *user.KYCStatus == models.KYCPending {
+		return errKYCPending
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"go.uber.org/dig"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/jobs"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+const (
+	// Failed jobs wait twice as long after each attempt, up to the max
+	jobBackoff    = 10 * time.Second
+	maxJobBackoff = time.Hour
+)
+
+type jobQueue struct {
+	repo interfaces.JobRepo
+}
+
+// NewJobQueue creates a new JobQueue
+func NewJobQueue(repo interfaces.JobRepo) interfaces.JobQueue {
+	return &jobQueue{
+		repo: repo,
+	}
+}
+
+func (q *jobQueue) Enqueue(ctx context.Context, job jobs.Job, delay time.Duration) (*models.Job, error) {
+	payload, err := jobs.Encode(job)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	j := &models.Job{
+		ID:          uuid.NewString(),
+		Type:        job.JobType(),
+		Queue:       job.Queue(),
+		Payload:     payload,
+		MaxAttempts: domain.Config().Jobs.MaxAttempts,
+		EnqueuedAt:  now,
+		RunAt:       now.Add(delay),
+	}
+	if err := q.repo.Enqueue(ctx, j); err != nil {
+		return nil, errors.Wrapf(err, "enqueue %v job", j.Type)
+	}
+	return j, nil
+}
+
+func (q *jobQueue) ListDead(ctx context.Context, queue string, limit int) ([]*models.Job, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	return q.repo.ListDead(ctx, queue, limit)
+}
+
+func (q *jobQueue) Requeue(ctx context.Context, queue, id string) error {
+	return q.repo.Requeue(ctx, queue, id)
+}
+
+// JobHandlerResult provides a job handler to the job workers
+type JobHandlerResult struct {
+	dig.Out
+
+	Handler interfaces.JobHandler `group:"job_handlers"`
+}
+
+// JobHandlers are the job handlers provided to the container
+type JobHandlers struct {
+	dig.In
+
+	Handlers []interfaces.JobHandler `group:"job_handlers"`
+}
+
+// jobWorker runs the jobs of the queues with their handlers
+type jobWorker struct {
+	repo     interfaces.JobRepo
+	handlers map[string]interfaces.JobHandler
+}
+
+func newJobWorker(repo interfaces.JobRepo, handlers []interfaces.JobHandler) *jobWorker {
+	w := &jobWorker{
+		repo:     repo,
+		handlers: make(map[string]interfaces.JobHandler, len(handlers)),
+	}
+	for _, h := range handlers {
+		w.handlers[h.JobType()] = h
+	}
+	return w
+}
+
+// queues returns the queues of the jobs with a handler
+func (w *jobWorker) queues() []string {
+	seen := map[string]bool{}
+	var queues []string
+	for jobType := range w.handlers {
+		if queue, ok := jobs.QueueOf(jobType); ok && !seen[queue] {
+			seen[queue] = true
+			queues = append(queues, queue)
+		}
+	}
+	sort.Strings(queues)
+	return queues
+}
+
+// poll runs the jobs of the queue one at a time until ctx is done
+func (w *jobWorker) poll(ctx context.Context, queue string) {
+	conf := domain.Config().Jobs
+	for ctx.Err() == nil {
+		ran, err := w.runNext(ctx, queue)
+		if err != nil && ctx.Err() == nil {
+			domain.Logger(ctx).WithError(err).Warn("jobs not run")
+		}
+		if ran {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(conf.PollInterval):
+		}
+	}
+}
+
+// runNext runs the next due job of the queue, returns if there was one
+func (w *jobWorker) runNext(ctx context.Context, queue string) (bool, error) {
+	lease := domain.Config().Jobs.Lease
+	job, err := w.repo.Dequeue(ctx, queue, lease)
+	if err != nil || job == nil {
+		return false, err
+	}
+
+	logger := domain.Logger(ctx).WithFields(log.Fields{
+		"jobID":    job.ID,
+		"jobType":  job.Type,
+		"attempts": job.Attempts,
+	})
+	// Stop before the lease ends and another worker takes the job
+	jobCtx, cancel := context.WithTimeout(domain.ContextWithLogger(ctx, logger), lease)
+	err = w.handle(jobCtx, job)
+	cancel()
+
+	switch {
+	case err == nil:
+		logger.Info("job done")
+		return true, w.repo.Complete(ctx, job)
+	case ctx.Err() != nil:
+		// Stopped mid-way, the job is ready again once the lease ends
+		return true, nil
+	}
+
+	job.LastError = err.Error()
+	if jobs.IsPermanent(err) || job.Attempts >= job.MaxAttempts {
+		logger.WithError(err).Error("job dead")
+		return true, w.repo.Bury(ctx, job)
+	}
+	job.RunAt = time.Now().Add(backoff(job.Attempts, jobBackoff, maxJobBackoff))
+	logger.WithError(err).WithField("runAt", job.RunAt).Warn("job will be retried")
+	return true, w.repo.Retry(ctx, job)
+}
+
+func (w *jobWorker) handle(ctx context.Context, job *models.Job) error {
+	handler, ok := w.handlers[job.Type]
+	if !ok {
+		return jobs.Permanent(errors.Newf("no handler of %v jobs", job.Type))
+	}
+	j, err := jobs.Decode(job.Type, job.Payload)
+	if err != nil {
+		return jobs.Permanent(err)
+	}
+	return handler.Handle(ctx, j)
+}
+
+// StartJobWorkers runs the jobs of every queue with a handler until
+// shutdown, with the workers of each queue set in the config
+func StartJobWorkers(lifecycle *domain.Lifecycle, repo interfaces.JobRepo, handlers JobHandlers) {
+	w := newJobWorker(repo, handlers.Handlers)
+	ctx := context.Background()
+	for _, queue := range w.queues() {
+		workers, ok := domain.Config().Jobs.Concurrency[queue]
+		if !ok || workers < 1 {
+			workers = 1
+		}
+		queue := queue
+		for i := 0; i < workers; i++ {
+			startWorker(ctx, lifecycle, "jobs "+queue, func(ctx context.Context) {
+				w.poll(domain.ContextWithLogFields(ctx, log.Fields{"queue": queue}), queue)
+			})
+		}
+	}
+}
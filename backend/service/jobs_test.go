@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/configtest"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/jobs"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+type fakeJobRepo struct {
+	interfaces.JobRepo
+	ready     []*models.Job
+	retried   []*models.Job
+	dead      []*models.Job
+	completed []*models.Job
+}
+
+func (r *fakeJobRepo) Enqueue(ctx context.Context, job *models.Job) error {
+	r.ready = append(r.ready, job)
+	return nil
+}
+
+func (r *fakeJobRepo) Dequeue(ctx context.Context, queue string, lease time.Duration) (*models.Job, error) {
+	for i, job := range r.ready {
+		if job.Queue == queue && !job.RunAt.After(time.Now()) {
+			r.ready = append(r.ready[:i], r.ready[i+1:]...)
+			job.Attempts++
+			return job, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeJobRepo) Complete(ctx context.Context, job *models.Job) error {
+	r.completed = append(r.completed, job)
+	return nil
+}
+
+func (r *fakeJobRepo) Retry(ctx context.Context, job *models.Job) error {
+	r.retried = append(r.retried, job)
+	return nil
+}
+
+func (r *fakeJobRepo) Bury(ctx context.Context, job *models.Job) error {
+	r.dead = append(r.dead, job)
+	return nil
+}
+
+type fakeJobHandler struct {
+	jobType string
+	err     error
+	handled []jobs.Job
+}
+
+func (h *fakeJobHandler) JobType() string {
+	return h.jobType
+}
+
+func (h *fakeJobHandler) Handle(ctx context.Context, job jobs.Job) error {
+	h.handled = append(h.handled, job)
+	return h.err
+}
+
+func setJobsConfig(t *testing.T) {
+	configtest.Use(t)
+	conf := &domain.Config().Jobs
+	conf.MaxAttempts = 3
+	conf.Lease = time.Minute
+}
+
+func TestJobWorker(t *testing.T) {
+	setJobsConfig(t)
+	ctx := context.Background()
+	setup := func(handlerErr error) (*fakeJobRepo, *fakeJobHandler, *jobWorker) {
+		repo := &fakeJobRepo{}
+		handler := &fakeJobHandler{jobType: jobs.TypeFetchInvoice, err: handlerErr}
+		_, err := NewJobQueue(repo).Enqueue(ctx, &jobs.FetchInvoice{AugmontUserID: 1, MerchantTxnID: "B1"}, 0)
+		assert.NoError(t, err)
+		return repo, handler, newJobWorker(repo, []interfaces.JobHandler{handler})
+	}
+
+	t.Run("should complete the jobs handled", func(t *testing.T) {
+		repo, handler, w := setup(nil)
+		assert.Equal(t, []string{jobs.QueueInvoices}, w.queues())
+
+		ran, err := w.runNext(ctx, jobs.QueueInvoices)
+		assert.NoError(t, err)
+		assert.True(t, ran)
+		assert.Equal(t, []jobs.Job{&jobs.FetchInvoice{AugmontUserID: 1, MerchantTxnID: "B1"}}, handler.handled)
+		assert.Len(t, repo.completed, 1)
+
+		ran, err = w.runNext(ctx, jobs.QueueInvoices)
+		assert.NoError(t, err)
+		assert.False(t, ran)
+	})
+
+	t.Run("should retry failed jobs with a growing wait", func(t *testing.T) {
+		repo, _, w := setup(errors.New("augmont down"))
+
+		before := time.Now()
+		_, err := w.runNext(ctx, jobs.QueueInvoices)
+		assert.NoError(t, err)
+		if !assert.Len(t, repo.retried, 1) {
+			return
+		}
+		job := repo.retried[0]
+		assert.Equal(t, 1, job.Attempts)
+		assert.Equal(t, "augmont down", job.LastError)
+		assert.True(t, !job.RunAt.Before(before.Add(jobBackoff)))
+		assert.Equal(t, 4*jobBackoff, backoff(3, jobBackoff, maxJobBackoff))
+	})
+
+	t.Run("should bury jobs out of attempts", func(t *testing.T) {
+		repo, _, w := setup(errors.New("augmont down"))
+		repo.ready[0].Attempts = 2
+
+		_, err := w.runNext(ctx, jobs.QueueInvoices)
+		assert.NoError(t, err)
+		assert.Empty(t, repo.retried)
+		assert.Len(t, repo.dead, 1)
+	})
+
+	t.Run("should bury permanent failures at once", func(t *testing.T) {
+		repo, _, w := setup(jobs.Permanent(errors.New("order not found")))
+
+		_, err := w.runNext(ctx, jobs.QueueInvoices)
+		assert.NoError(t, err)
+		assert.Len(t, repo.dead, 1)
+		assert.Equal(t, 1, repo.dead[0].Attempts)
+	})
+
+	t.Run("should bury jobs without a handler", func(t *testing.T) {
+		repo, _, _ := setup(nil)
+		w := newJobWorker(repo, nil)
+
+		_, err := w.runNext(ctx, jobs.QueueInvoices)
+		assert.NoError(t, err)
+		assert.Len(t, repo.dead, 1)
+	})
+}
+
+type fakeKYCGold struct {
+	interfaces.AugmontService
+	status string
+}
+
+func (g *fakeKYCGold) UpdateUserKycStatus(ctx context.Context, user *models.AugmontUser) error {
+	if g.status != models.KYCPending {
+		user.KYCStatus = &g.status
+	}
+	return nil
+}
+
+type fakeKYCUsers struct {
+	interfaces.AugmontUserRepo
+	status string
+}
+
+func (r *fakeKYCUsers) FindUser(ctx context.Context, user *models.AugmontUser) (*models.AugmontUser, error) {
+	return &models.AugmontUser{ID: user.ID, KYCStatus: &r.status}, nil
+}
+
+func TestKYCJobHandler(t *testing.T) {
+	ctx := context.Background()
+	job := &jobs.RefreshKYC{AugmontUserID: 1}
+
+	t.Run("should retry until augmont decides", func(t *testing.T) {
+		h := NewKYCJobHandler(&fakeKYCGold{status: models.KYCPending}, &fakeKYCUsers{status: models.KYCPending}).Handler
+		assert.ErrorIs(t, h.Handle(ctx, job), errKYCPending)
+
+		h = NewKYCJobHandler(&fakeKYCGold{status: models.KYCApproved}, &fakeKYCUsers{status: models.KYCPending}).Handler
+		assert.NoError(t, h.Handle(ctx, job))
+	})
+
+	t.Run("should skip decided KYCs", func(t *testing.T) {
+		h := NewKYCJobHandler(nil, &fakeKYCUsers{status: models.KYCRejected}).Handler
+		assert.NoError(t, h.Handle(ctx, job))
+	})
+}
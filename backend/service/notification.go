@@ -0,0 +1,406 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/i18n"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+const (
+	// Notifications claimed per round of a dispatch
+	dispatchBatch = 50
+	// Notifications sending for longer were lost by a stopped server
+	sendingTimeout = 5 * time.Minute
+	// Retries wait twice as long after each failure, up to the max
+	retryBackoff    = time.Minute
+	maxRetryBackoff = time.Hour
+)
+
+type notificationService struct {
+	notification interfaces.NotificationRepo
+	user         interfaces.UserRepo
+	providers    map[string]interfaces.NotificationProvider
+
+	// Default quiet hours, HH:MM in IST
+	quietStart, quietEnd string
+
+	// Notifications due on creation are sent in the background
+	background *background
+	now        func() time.Time
+}
+
+// NewNotificationService creates a new NotificationService sending on
+// the channels of the providers, the notifications being sent are
+// awaited on shutdown
+func NewNotificationService(
+	lifecycle *domain.Lifecycle,
+	notification interfaces.NotificationRepo,
+	user interfaces.UserRepo,
+	providers []interfaces.NotificationProvider,
+) (interfaces.NotificationService, error) {
+	quiet := strings.Split(domain.Config().Notify.QuietHours, "-")
+	if len(quiet) != 2 || !utils.IsClock(quiet[0]) || !utils.IsClock(quiet[1]) {
+		return nil, errors.Newf("quiet hours %q are not HH:MM-HH:MM", domain.Config().Notify.QuietHours)
+	}
+
+	s := &notificationService{
+		notification: notification,
+		user:         user,
+		providers:    make(map[string]interfaces.NotificationProvider),
+		quietStart:   quiet[0],
+		quietEnd:     quiet[1],
+		background:   newBackground(lifecycle, "notifications"),
+		now:          time.Now,
+	}
+	for _, p := range providers {
+		s.providers[p.Channel()] = p
+	}
+	return s, nil
+}
+
+// NewNotifier provides the NotificationService as the Notifier of the
+// services raising notifications
+func NewNotifier(s interfaces.NotificationService) interfaces.Notifier {
+	return s
+}
+
+// NewEventNotifier provides the NotificationService as the EventNotifier
+// of the event consumers
+func NewEventNotifier(s interfaces.NotificationService) interfaces.EventNotifier {
+	return s
+}
+
+func (s *notificationService) Notify(ctx context.Context, userID uint64, name string, args map[string]string) error {
+	return s.notify(ctx, nil, userID, name, args)
+}
+
+func (s *notificationService) NotifyEvent(ctx context.Context, eventID, userID uint64, name string, args map[string]string) error {
+	return s.notify(ctx, &eventID, userID, name, args)
+}
+
+// notify creates the notifications of the user on each channel, a
+// notification of the event already created is not created again
+func (s *notificationService) notify(ctx context.Context, eventID *uint64, userID uint64, name string, args map[string]string) error {
+	user, err := s.user.FindOne(ctx, &models.User{ID: &userID})
+	if err != nil {
+		return errors.Wrap(err, "find user")
+	}
+	locale := i18n.DefaultLocale
+	if user.Locale != nil && i18n.IsSupported(*user.Locale) {
+		locale = *user.Locale
+	}
+	if metal, ok := args["metal"]; ok {
+		args = localizeMetal(locale, args, metal)
+	}
+
+	title, ok := i18n.T(locale, "notification."+name+".title", args)
+	if !ok {
+		return errors.Newf("no notification %q", name)
+	}
+	body, _ := i18n.T(locale, "notification."+name+".body", args)
+
+	pref, err := s.Preferences(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	var notifications, due []*models.Notification
+	for _, channel := range models.Channels {
+		recipient := channelRecipient(channel, user, pref)
+		if s.providers[channel] == nil || !pref.Enabled(channel) || recipient == "" {
+			continue
+		}
+		n := &models.Notification{
+			UserID:    &userID,
+			Name:      &name,
+			Channel:   strPtr(channel),
+			Recipient: &recipient,
+			Title:     &title,
+			Body:      &body,
+			Status:    strPtr(models.NotificationPending),
+			Attempts:  new(int),
+			SendAfter: timePtr(s.sendAfter(channel, pref, now)),
+			EventID:   eventID,
+		}
+		// Claimed on creation so a dispatch running meanwhile skips it
+		if !n.SendAfter.After(now) {
+			n.Status = strPtr(models.NotificationSending)
+			due = append(due, n)
+		}
+		notifications = append(notifications, n)
+	}
+	if err := s.notification.Create(ctx, notifications); err != nil {
+		return errors.Wrap(err, "create notifications")
+	}
+	// Skipped ones were created by an earlier delivery of the event
+	created := due[:0]
+	for _, n := range due {
+		if n.ID != nil {
+			created = append(created, n)
+		}
+	}
+	due = created
+
+	if len(due) > 0 {
+		s.background.Go(ctx, func(ctx context.Context) {
+			for _, n := range due {
+				s.send(ctx, n, pref)
+			}
+		})
+	}
+	return nil
+}
+
+// localizeMetal returns the args with the metal named in the locale
+func localizeMetal(locale string, args map[string]string, metal string) map[string]string {
+	localized := make(map[string]string, len(args))
+	for k, v := range args {
+		localized[k] = v
+	}
+	if name, ok := i18n.T(locale, "metal."+metal, nil); ok {
+		localized["metal"] = name
+	}
+	return localized
+}
+
+// channelRecipient returns where the notification of the channel goes,
+// empty when the user has not given one
+func channelRecipient(channel string, user *models.User, pref *models.NotificationPreference) string {
+	switch channel {
+	case models.ChannelSMS:
+		return deref(user.Mobile)
+	case models.ChannelEmail:
+		return deref(pref.EmailAddress)
+	case models.ChannelPush:
+		return deref(pref.PushToken)
+	}
+	return ""
+}
+
+// sendAfter returns when a notification of the channel may be sent,
+// SMS and push wait for the quiet hours of the user to end
+func (s *notificationService) sendAfter(channel string, pref *models.NotificationPreference, at time.Time) time.Time {
+	if channel == models.ChannelEmail {
+		return at
+	}
+	start, end := s.quietStart, s.quietEnd
+	if pref.QuietStart != nil && pref.QuietEnd != nil {
+		start, end = *pref.QuietStart, *pref.QuietEnd
+	}
+	return quietUntil(at, start, end)
+}
+
+// quietUntil returns the end of the quiet hours from start to end, in IST,
+// if they include at, else at, the hours wrap past midnight when the
+// start is after the end
+func quietUntil(at time.Time, start, end string) time.Time {
+	from, ok1 := utils.ClockMinutes(start)
+	to, ok2 := utils.ClockMinutes(end)
+	if !ok1 || !ok2 || from == to {
+		return at
+	}
+
+	local := at.In(utils.IST)
+	minute := local.Hour()*60 + local.Minute()
+	quiet := from <= minute && minute < to
+	if from > to {
+		quiet = minute >= from || minute < to
+	}
+	if !quiet {
+		return at
+	}
+
+	until := time.Date(local.Year(), local.Month(), local.Day(), to/60, to%60, 0, 0, utils.IST)
+	if !until.After(local) {
+		until = until.AddDate(0, 0, 1)
+	}
+	return until
+}
+
+// retryAfter returns the wait before the next attempt of a notification
+// that failed the number of attempts
+func retryAfter(attempts int) time.Duration {
+	return backoff(attempts, retryBackoff, maxRetryBackoff)
+}
+
+// backoff returns the wait after the number of failed attempts, starting
+// from base and doubling up to max
+func backoff(attempts int, base, max time.Duration) time.Duration {
+	wait := base
+	for i := 1; i < attempts && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		wait = max
+	}
+	return wait
+}
+
+// send delivers the claimed notification and saves how it went, failures
+// are retried later until the notification runs out of attempts
+func (s *notificationService) send(ctx context.Context, n *models.Notification, pref *models.NotificationPreference) bool {
+	logger := domain.Logger(ctx).WithFields(log.Fields{
+		"notificationID": derefID(n.ID),
+		"channel":        deref(n.Channel),
+	})
+
+	var err error
+	if provider := s.providers[deref(n.Channel)]; provider != nil {
+		err = provider.Send(ctx, n)
+	} else {
+		err = errors.Newf("no %v notification provider", deref(n.Channel))
+	}
+
+	attempts := 1
+	if n.Attempts != nil {
+		attempts = *n.Attempts + 1
+	}
+	n.Attempts = &attempts
+	now := s.now()
+	switch {
+	case err == nil:
+		n.Status = strPtr(models.NotificationSent)
+		n.SentAt = &now
+		n.LastError = nil
+	case attempts >= domain.Config().Notify.MaxAttempts:
+		n.Status = strPtr(models.NotificationFailed)
+		n.LastError = strPtr(err.Error())
+		logger.WithError(err).Warn("notification failed")
+	default:
+		n.Status = strPtr(models.NotificationPending)
+		n.LastError = strPtr(err.Error())
+		n.SendAfter = timePtr(s.sendAfter(deref(n.Channel), pref, now.Add(retryAfter(attempts))))
+		logger.WithError(err).Info("notification will be retried")
+	}
+
+	if uerr := s.notification.Update(ctx, n); uerr != nil {
+		// Left sending, the notification is claimed again once stale
+		logger.WithError(uerr).Warn("notification delivery not saved")
+	}
+	return err == nil
+}
+
+func (s *notificationService) Dispatch(ctx context.Context) (int, error) {
+	sent := 0
+	prefs := make(map[uint64]*models.NotificationPreference)
+	for {
+		now := s.now()
+		notifications, err := s.notification.ClaimDue(ctx, now, now.Add(-sendingTimeout), dispatchBatch)
+		if err != nil {
+			return sent, err
+		}
+		for _, n := range notifications {
+			pref, ok := prefs[*n.UserID]
+			if !ok {
+				if pref, err = s.Preferences(ctx, *n.UserID); err != nil {
+					return sent, err
+				}
+				prefs[*n.UserID] = pref
+			}
+			if s.send(ctx, n, pref) {
+				sent++
+			}
+		}
+		if len(notifications) < dispatchBatch {
+			return sent, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return sent, err
+		}
+	}
+}
+
+func (s *notificationService) Preferences(ctx context.Context, userID uint64) (*models.NotificationPreference, error) {
+	pref, err := s.notification.FindPreference(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if pref == nil {
+		enabled := true
+		pref = &models.NotificationPreference{
+			UserID: &userID,
+			SMS:    &enabled,
+			Email:  &enabled,
+			Push:   &enabled,
+		}
+	}
+	return pref, nil
+}
+
+func (s *notificationService) SavePreferences(
+	ctx context.Context,
+	userID uint64,
+	info *utils.NotificationPreferenceInfo,
+) (*models.NotificationPreference, error) {
+	pref, err := s.Preferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.SMS != nil {
+		pref.SMS = info.SMS
+	}
+	if info.Email != nil {
+		pref.Email = info.Email
+	}
+	if info.Push != nil {
+		pref.Push = info.Push
+	}
+	if info.EmailAddress != nil {
+		pref.EmailAddress = emptyToNil(*info.EmailAddress)
+	}
+	if info.PushToken != nil {
+		pref.PushToken = emptyToNil(*info.PushToken)
+	}
+	if info.QuietStart != nil {
+		pref.QuietStart = emptyToNil(*info.QuietStart)
+	}
+	if info.QuietEnd != nil {
+		pref.QuietEnd = emptyToNil(*info.QuietEnd)
+	}
+
+	switch {
+	case pref.QuietStart != nil && pref.QuietEnd == nil:
+		return nil, invalidField(errors.New("quiet hours without an end"), "quietEnd", "required", "")
+	case pref.QuietStart == nil && pref.QuietEnd != nil:
+		return nil, invalidField(errors.New("quiet hours without a start"), "quietStart", "required", "")
+	}
+
+	if err := s.notification.SavePreference(ctx, pref); err != nil {
+		return nil, err
+	}
+	return pref, nil
+}
+
+func (s *notificationService) List(
+	ctx context.Context,
+	userID uint64,
+	q *utils.ListQuery,
+) ([]*models.Notification, *utils.Page, error) {
+	return s.notification.List(ctx, userID, q)
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func emptyToNil(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+)
+
+// NewNotificationProviders creates the provider of each channel set in
+// the config, SMS, email and push gateways are added here as providers
+func NewNotificationProviders() ([]interfaces.NotificationProvider, error) {
+	conf := domain.Config().Notify
+
+	channels := make([]string, 0, len(conf.Providers))
+	for channel := range conf.Providers {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+
+	var providers []interfaces.NotificationProvider
+	for _, channel := range channels {
+		if !isChannel(channel) {
+			return nil, errors.Newf("unknown notification channel %q", channel)
+		}
+		switch name := conf.Providers[channel]; name {
+		case "log":
+			providers = append(providers, &logProvider{channel: channel})
+		case "file":
+			providers = append(providers, &fileProvider{
+				channel: channel,
+				path:    filepath.Join(conf.Dir, channel+".jsonl"),
+			})
+		default:
+			return nil, errors.Newf("unknown %v notification provider %q", channel, name)
+		}
+	}
+	return providers, nil
+}
+
+func isChannel(channel string) bool {
+	for _, c := range models.Channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// logProvider only logs the notifications, for development
+type logProvider struct {
+	channel string
+}
+
+func (p *logProvider) Channel() string {
+	return p.channel
+}
+
+func (p *logProvider) Send(ctx context.Context, n *models.Notification) error {
+	domain.Logger(ctx).WithFields(log.Fields{
+		"channel":      p.channel,
+		"userID":       derefID(n.UserID),
+		"notification": deref(n.Name),
+		"title":        deref(n.Title),
+		"body":         deref(n.Body),
+	}).Info("notification")
+	return nil
+}
+
+// fileProvider appends the notifications to a JSON lines file,
+// for development and tests to read what was sent
+type fileProvider struct {
+	channel string
+	path    string
+
+	mu sync.Mutex
+}
+
+func (p *fileProvider) Channel() string {
+	return p.channel
+}
+
+func (p *fileProvider) Send(ctx context.Context, n *models.Notification) error {
+	line, err := json.Marshal(map[string]interface{}{
+		"id":        derefID(n.ID),
+		"userId":    derefID(n.UserID),
+		"name":      deref(n.Name),
+		"recipient": deref(n.Recipient),
+		"title":     deref(n.Title),
+		"body":      deref(n.Body),
+		"sentAt":    time.Now().Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(p.path), 0o755); err != nil {
+		return errors.Wrap(err, "create notification dir")
+	}
+	f, err := os.OpenFile(p.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return errors.Wrap(err, "open notification file")
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return errors.Wrap(err, "write notification")
+	}
+	return f.Close()
+}
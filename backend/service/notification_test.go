@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/configtest"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+type fakeNotificationRepo struct {
+	interfaces.NotificationRepo
+
+	mu            sync.Mutex
+	pref          *models.NotificationPreference
+	notifications []*models.Notification
+}
+
+func (r *fakeNotificationRepo) FindPreference(ctx context.Context, userID uint64) (*models.NotificationPreference, error) {
+	return r.pref, nil
+}
+
+func (r *fakeNotificationRepo) SavePreference(ctx context.Context, pref *models.NotificationPreference) error {
+	r.pref = pref
+	return nil
+}
+
+func (r *fakeNotificationRepo) Create(ctx context.Context, notifications []*models.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, n := range notifications {
+		if r.saved(n.EventID, *n.Channel) {
+			continue
+		}
+		id := uint64(len(r.notifications) + 1)
+		n.ID = &id
+		r.notifications = append(r.notifications, n)
+	}
+	return nil
+}
+
+// saved returns if a notification of the event is saved on the channel
+func (r *fakeNotificationRepo) saved(eventID *uint64, channel string) bool {
+	for _, n := range r.notifications {
+		if eventID != nil && n.EventID != nil && *n.EventID == *eventID && *n.Channel == channel {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *fakeNotificationRepo) Update(ctx context.Context, n *models.Notification) error {
+	return nil
+}
+
+func (r *fakeNotificationRepo) ClaimDue(ctx context.Context, now, stale time.Time, limit int) ([]*models.Notification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var due []*models.Notification
+	for _, n := range r.notifications {
+		if *n.Status == models.NotificationPending && !n.SendAfter.After(now) && len(due) < limit {
+			n.Status = strPtr(models.NotificationSending)
+			due = append(due, n)
+		}
+	}
+	return due, nil
+}
+
+// byChannel returns the notification of the channel, nil if there is none
+func (r *fakeNotificationRepo) byChannel(channel string) *models.Notification {
+	for _, n := range r.notifications {
+		if *n.Channel == channel {
+			return n
+		}
+	}
+	return nil
+}
+
+type fakeProvider struct {
+	channel string
+	fail    bool
+
+	mu   sync.Mutex
+	sent []string
+}
+
+func (p *fakeProvider) Channel() string {
+	return p.channel
+}
+
+func (p *fakeProvider) Send(ctx context.Context, n *models.Notification) error {
+	if p.fail {
+		return errors.New("gateway down")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sent = append(p.sent, *n.Recipient+": "+*n.Body)
+	return nil
+}
+
+type fakeNotifyUsers struct {
+	interfaces.UserRepo
+}
+
+func (fakeNotifyUsers) FindOne(ctx context.Context, user *models.User) (*models.User, error) {
+	mobile, locale := "9876543210", "en"
+	return &models.User{ID: user.ID, Mobile: &mobile, Locale: &locale}, nil
+}
+
+func setNotifyConfig(t *testing.T) {
+	configtest.Use(t)
+	notify := &domain.Config().Notify
+	notify.QuietHours = "22:00-08:00"
+	notify.MaxAttempts = 3
+}
+
+func TestQuietUntil(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 3, 10, hour, minute, 0, 0, utils.IST)
+	}
+	cases := []struct {
+		name       string
+		at         time.Time
+		start, end string
+		want       time.Time
+	}{
+		{"before quiet hours", at(21, 59), "22:00", "08:00", at(21, 59)},
+		{"quiet before midnight", at(23, 30), "22:00", "08:00", at(8, 0).AddDate(0, 0, 1)},
+		{"quiet after midnight", at(6, 15), "22:00", "08:00", at(8, 0)},
+		{"end of quiet hours", at(8, 0), "22:00", "08:00", at(8, 0)},
+		{"quiet within a day", at(13, 0), "12:00", "14:00", at(14, 0)},
+		{"no quiet hours", at(23, 0), "00:00", "00:00", at(23, 0)},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.True(t, c.want.Equal(quietUntil(c.at, c.start, c.end)))
+		})
+	}
+	t.Run("should compare in IST", func(t *testing.T) {
+		// 23:30 IST
+		utc := time.Date(2026, 3, 10, 18, 0, 0, 0, time.UTC)
+		assert.True(t, at(8, 0).AddDate(0, 0, 1).Equal(quietUntil(utc, "22:00", "08:00")))
+	})
+}
+
+func TestRetryAfter(t *testing.T) {
+	assert.Equal(t, time.Minute, retryAfter(1))
+	assert.Equal(t, 4*time.Minute, retryAfter(3))
+	assert.Equal(t, time.Hour, retryAfter(20))
+}
+
+func TestNotificationService(t *testing.T) {
+	setNotifyConfig(t)
+	ctx := context.Background()
+	userID := uint64(7)
+	email, token := "asha@example.com", "device-1"
+
+	setup := func(now time.Time, providers ...*fakeProvider) (*fakeNotificationRepo, *domain.Lifecycle, *notificationService) {
+		repo := &fakeNotificationRepo{
+			pref: &models.NotificationPreference{UserID: &userID, EmailAddress: &email, PushToken: &token},
+		}
+		lifecycle := domain.NewLifecycle()
+		var ps []interfaces.NotificationProvider
+		for _, p := range providers {
+			ps = append(ps, p)
+		}
+		s, err := NewNotificationService(lifecycle, repo, fakeNotifyUsers{}, ps)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		ns := s.(*notificationService)
+		ns.now = func() time.Time { return now }
+		return repo, lifecycle, ns
+	}
+	noon := time.Date(2026, 3, 10, 12, 0, 0, 0, utils.IST)
+	night := time.Date(2026, 3, 10, 23, 0, 0, 0, utils.IST)
+
+	t.Run("should send on every enabled channel in the user locale", func(t *testing.T) {
+		sms, mail := &fakeProvider{channel: models.ChannelSMS}, &fakeProvider{channel: models.ChannelEmail}
+		repo, lifecycle, s := setup(noon, sms, mail)
+
+		err := s.Notify(ctx, userID, "buy_completed", map[string]string{"quantity": "1.5", "metal": "gold", "amount": "9000"})
+		assert.NoError(t, err)
+		assert.NoError(t, lifecycle.Stop(ctx))
+
+		// No push provider, so no push notification
+		assert.Len(t, repo.notifications, 2)
+		assert.Equal(t, []string{"9876543210: You bought 1.5 g of gold for ₹9000."}, sms.sent)
+		assert.Equal(t, []string{"asha@example.com: You bought 1.5 g of gold for ₹9000."}, mail.sent)
+		assert.Equal(t, models.NotificationSent, *repo.byChannel(models.ChannelSMS).Status)
+	})
+
+	t.Run("should send the notifications of an event once", func(t *testing.T) {
+		sms := &fakeProvider{channel: models.ChannelSMS}
+		repo, lifecycle, s := setup(noon, sms)
+
+		args := map[string]string{"quantity": "1.5", "metal": "gold", "amount": "9000"}
+		assert.NoError(t, s.NotifyEvent(ctx, 41, userID, "buy_completed", args))
+		assert.NoError(t, s.NotifyEvent(ctx, 41, userID, "buy_completed", args))
+		assert.NoError(t, s.NotifyEvent(ctx, 42, userID, "buy_completed", args))
+		assert.NoError(t, lifecycle.Stop(ctx))
+
+		assert.Len(t, repo.notifications, 2)
+		assert.Len(t, sms.sent, 2)
+	})
+
+	t.Run("should skip the channels the user turned off", func(t *testing.T) {
+		sms, push := &fakeProvider{channel: models.ChannelSMS}, &fakeProvider{channel: models.ChannelPush}
+		repo, lifecycle, s := setup(noon, sms, push)
+		off := false
+		repo.pref.SMS = &off
+
+		assert.NoError(t, s.Notify(ctx, userID, "kyc_approved", nil))
+		assert.NoError(t, lifecycle.Stop(ctx))
+		assert.Empty(t, sms.sent)
+		assert.Len(t, push.sent, 1)
+	})
+
+	t.Run("should hold sms and push until the quiet hours end", func(t *testing.T) {
+		sms, mail := &fakeProvider{channel: models.ChannelSMS}, &fakeProvider{channel: models.ChannelEmail}
+		repo, lifecycle, s := setup(night, sms, mail)
+
+		assert.NoError(t, s.Notify(ctx, userID, "kyc_approved", nil))
+		assert.NoError(t, lifecycle.Stop(ctx))
+		assert.Empty(t, sms.sent)
+		assert.Len(t, mail.sent, 1)
+
+		held := repo.byChannel(models.ChannelSMS)
+		assert.Equal(t, models.NotificationPending, *held.Status)
+		assert.True(t, time.Date(2026, 3, 11, 8, 0, 0, 0, utils.IST).Equal(*held.SendAfter))
+
+		sent, err := s.Dispatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, sent)
+
+		s.now = func() time.Time { return *held.SendAfter }
+		sent, err = s.Dispatch(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		assert.Len(t, sms.sent, 1)
+	})
+
+	t.Run("should retry failed sends until out of attempts", func(t *testing.T) {
+		mail := &fakeProvider{channel: models.ChannelEmail, fail: true}
+		repo, lifecycle, s := setup(noon, mail)
+
+		assert.NoError(t, s.Notify(ctx, userID, "kyc_approved", nil))
+		assert.NoError(t, lifecycle.Stop(ctx))
+		n := repo.byChannel(models.ChannelEmail)
+		assert.Equal(t, models.NotificationPending, *n.Status)
+		assert.Equal(t, 1, *n.Attempts)
+		assert.Equal(t, "gateway down", *n.LastError)
+		assert.True(t, noon.Add(time.Minute).Equal(*n.SendAfter))
+
+		for i := 0; i < 2; i++ {
+			s.now = func() time.Time { return *n.SendAfter }
+			_, err := s.Dispatch(ctx)
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, models.NotificationFailed, *n.Status)
+		assert.Equal(t, 3, *n.Attempts)
+	})
+
+	t.Run("should fail on unknown notifications", func(t *testing.T) {
+		_, _, s := setup(noon)
+		assert.Error(t, s.Notify(ctx, userID, "no_such_notification", nil))
+	})
+
+	t.Run("should keep the preferences left out", func(t *testing.T) {
+		repo, _, s := setup(noon)
+		off, start, end, empty := false, "23:00", "07:00", ""
+
+		pref, err := s.SavePreferences(ctx, userID, &utils.NotificationPreferenceInfo{
+			Push: &off, QuietStart: &start, QuietEnd: &end, PushToken: &empty,
+		})
+		assert.NoError(t, err)
+		assert.False(t, pref.Enabled(models.ChannelPush))
+		assert.True(t, pref.Enabled(models.ChannelSMS))
+		assert.Equal(t, email, *repo.pref.EmailAddress)
+		assert.Nil(t, repo.pref.PushToken)
+		assert.Equal(t, "23:00", *repo.pref.QuietStart)
+	})
+
+	t.Run("should reject quiet hours without an end", func(t *testing.T) {
+		_, _, s := setup(noon)
+		start := "23:00"
+		_, err := s.SavePreferences(ctx, userID, &utils.NotificationPreferenceInfo{QuietStart: &start})
+		assert.True(t, domain.ErrIs(err, domain.ErrInvalidArgument))
+		assert.Equal(t, "required", fieldCode(t, err))
+	})
+}
+
+func TestNewNotificationProviders(t *testing.T) {
+	setNotifyConfig(t)
+	notify := &domain.Config().Notify
+
+	t.Run("should write the file provider as JSON lines", func(t *testing.T) {
+		notify.Dir = t.TempDir()
+		notify.Providers = map[string]string{"email": "file", "sms": "log"}
+		providers, err := NewNotificationProviders()
+		if !assert.NoError(t, err) || !assert.Len(t, providers, 2) {
+			return
+		}
+		assert.Equal(t, models.ChannelEmail, providers[0].Channel())
+
+		id, userID, recipient, body := uint64(1), uint64(7), "asha@example.com", "Your KYC is approved"
+		n := &models.Notification{ID: &id, UserID: &userID, Recipient: &recipient, Body: &body}
+		assert.NoError(t, providers[0].Send(context.Background(), n))
+		assert.NoError(t, providers[0].Send(context.Background(), n))
+
+		b, err := ioutil.ReadFile(filepath.Join(notify.Dir, "email.jsonl"))
+		assert.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+		assert.Len(t, lines, 2)
+		var line map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), &line))
+		assert.Equal(t, recipient, line["recipient"])
+	})
+
+	t.Run("should reject unknown providers and channels", func(t *testing.T) {
+		notify.Providers = map[string]string{"sms": "carrier-pigeon"}
+		_, err := NewNotificationProviders()
+		assert.Error(t, err)
+
+		notify.Providers = map[string]string{"fax": "log"}
+		_, err = NewNotificationProviders()
+		assert.Error(t, err)
+	})
+}
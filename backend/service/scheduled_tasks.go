@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+)
+
+// NewProductSyncTask syncs the redeem product catalogue every night
+func NewProductSyncTask(products interfaces.AugmontProductService) ScheduledTaskResult {
+	return ScheduledTaskResult{Task: &scheduledTask{
+		name: "products-sync",
+		spec: "30 3 * * *",
+		run: func(ctx context.Context) error {
+			synced, dropped, err := products.Sync(ctx)
+			if err != nil {
+				return err
+			}
+			domain.Logger(ctx).WithFields(log.Fields{"synced": synced, "dropped": dropped}).Info("products synced")
+			return nil
+		},
+	}}
+}
+
+// NewShipmentPollTask moves the shipments of redeem orders along
+func NewShipmentPollTask(shipments interfaces.AugmontShipmentService) ScheduledTaskResult {
+	return ScheduledTaskResult{Task: &scheduledTask{
+		name: "shipments-poll",
+		spec: "*/15 * * * *",
+		run: func(ctx context.Context) error {
+			moved, err := shipments.Poll(ctx)
+			if err != nil {
+				return err
+			}
+			domain.Logger(ctx).WithField("moved", moved).Info("shipments polled")
+			return nil
+		},
+	}}
+}
+
+// NewNotificationDispatchTask sends the notifications waiting for
+// quiet hours or a retry
+func NewNotificationDispatchTask(notifications interfaces.NotificationService) ScheduledTaskResult {
+	return ScheduledTaskResult{Task: &scheduledTask{
+		name: "notifications-dispatch",
+		spec: "* * * * *",
+		run: func(ctx context.Context) error {
+			sent, err := notifications.Dispatch(ctx)
+			if err != nil {
+				return err
+			}
+			domain.Logger(ctx).WithField("sent", sent).Info("notifications dispatched")
+			return nil
+		},
+	}}
+}
+
+// NewGiftClaimSweepTask completes or returns to escrow the gift claims
+// left claiming by an unanswered transfer or a crash
+func NewGiftClaimSweepTask(gifts interfaces.AugmontGiftService) ScheduledTaskResult {
+	return ScheduledTaskResult{Task: &scheduledTask{
+		name: "gift-claims-sweep",
+		spec: "*/10 * * * *",
+		run: func(ctx context.Context) error {
+			completed, err := gifts.SweepClaims(ctx)
+			if err != nil {
+				return err
+			}
+			domain.Logger(ctx).WithField("completed", completed).Info("gift claims swept")
+			return nil
+		},
+	}}
+}
+
+// NewGiftSendSweepTask completes or fails the gifts left sending by an
+// unanswered transfer or a crash
+func NewGiftSendSweepTask(gifts interfaces.AugmontGiftService) ScheduledTaskResult {
+	return ScheduledTaskResult{Task: &scheduledTask{
+		name: "gift-sends-sweep",
+		spec: "*/10 * * * *",
+		run: func(ctx context.Context) error {
+			completed, err := gifts.SweepSends(ctx)
+			if err != nil {
+				return err
+			}
+			domain.Logger(ctx).WithField("completed", completed).Info("gift sends swept")
+			return nil
+		},
+	}}
+}
@@ -0,0 +1,382 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	log "github.com/sirupsen/logrus"
+	"go.uber.org/dig"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/cron"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+// How often the scheduler renews the lease and looks for due tasks
+const schedulerTick = time.Second
+
+// ScheduledTaskResult provides a task to the scheduler
+type ScheduledTaskResult struct {
+	dig.Out
+
+	Task interfaces.ScheduledTask `group:"scheduled_tasks"`
+}
+
+// ScheduledTasks are the scheduled tasks provided to the container
+type ScheduledTasks struct {
+	dig.In
+
+	Tasks []interfaces.ScheduledTask `group:"scheduled_tasks"`
+}
+
+// scheduledTask is a task running a func
+type scheduledTask struct {
+	name string
+	spec string
+	run  func(ctx context.Context) error
+}
+
+func (t *scheduledTask) Name() string {
+	return t.name
+}
+
+func (t *scheduledTask) Spec() string {
+	return t.spec
+}
+
+func (t *scheduledTask) Run(ctx context.Context) error {
+	return t.run(ctx)
+}
+
+// taskSpec returns the cron expression of the task, the config
+// replaces the default of the task
+func taskSpec(task interfaces.ScheduledTask) string {
+	if spec, ok := domain.Config().Scheduler.Specs[task.Name()]; ok {
+		return spec
+	}
+	return task.Spec()
+}
+
+// parseSpec parses the cron expression, nil when the task is off
+func parseSpec(spec string) (*cron.Schedule, error) {
+	if spec == models.ScheduleOff {
+		return nil, nil
+	}
+	return cron.Parse(spec)
+}
+
+// entry is a task with its schedule while the replica leads
+type entry struct {
+	task     interfaces.ScheduledTask
+	schedule *models.Schedule
+	cron     *cron.Schedule
+	running  bool
+}
+
+// scheduler runs the scheduled tasks on the replica holding the lease,
+// a task never runs twice at once in any replica, runs missed while no
+// replica led run once when the next leader takes over
+type scheduler struct {
+	repo   interfaces.ScheduleRepo
+	lease  interfaces.LeaderLease
+	tasks  map[string]interfaces.ScheduledTask
+	holder string
+
+	mu sync.Mutex
+	// Set while the replica leads
+	entries    map[string]*entry
+	leadCtx    context.Context
+	cancelLead context.CancelFunc
+	running    sync.WaitGroup
+}
+
+func newScheduler(repo interfaces.ScheduleRepo, lease interfaces.LeaderLease, tasks []interfaces.ScheduledTask) *scheduler {
+	s := &scheduler{
+		repo:   repo,
+		lease:  lease,
+		tasks:  make(map[string]interfaces.ScheduledTask, len(tasks)),
+		holder: workerName(),
+	}
+	for _, t := range tasks {
+		s.tasks[t.Name()] = t
+	}
+	return s
+}
+
+// NewSchedulerService creates a new SchedulerService
+func NewSchedulerService(repo interfaces.ScheduleRepo, lease interfaces.LeaderLease, tasks ScheduledTasks) interfaces.SchedulerService {
+	return newScheduler(repo, lease, tasks.Tasks)
+}
+
+func (s *scheduler) List(ctx context.Context) ([]*models.Schedule, string, error) {
+	schedules, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	leader, err := s.lease.Holder(ctx)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "find scheduler leader")
+	}
+	return schedules, leader, nil
+}
+
+func (s *scheduler) ListRuns(ctx context.Context, name string, q *utils.ListQuery) ([]*models.ScheduleRun, *utils.Page, error) {
+	if name != "" {
+		q.Where("name", utils.FilterEq, name)
+	}
+	return s.repo.ListRuns(ctx, q)
+}
+
+func (s *scheduler) Run(ctx context.Context, name string) (*models.ScheduleRun, error) {
+	task, ok := s.tasks[name]
+	if !ok {
+		err := errors.Newf("unknown scheduled task %q", name)
+		return nil, domain.NewError(err, domain.ErrNotFound)
+	}
+	schedules, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Runs outside the schedule keep the next run
+	var schedule *models.Schedule
+	for _, sc := range schedules {
+		if *sc.Name == name {
+			schedule = sc
+		}
+	}
+	// Not scheduled by a leader yet, the next leader sets the next run
+	if schedule == nil {
+		schedule = &models.Schedule{Name: &name, Spec: strPtr(taskSpec(task))}
+		if err := s.repo.Save(ctx, schedule); err != nil {
+			return nil, err
+		}
+	}
+	return s.runTask(ctx, task, schedule)
+}
+
+// runTask runs the task as the last run of the schedule and saves its
+// outcome, only failures to start the run are returned, a run of the
+// task going on in any replica is a conflict
+func (s *scheduler) runTask(ctx context.Context, task interfaces.ScheduledTask, schedule *models.Schedule) (*models.ScheduleRun, error) {
+	lease := domain.Config().Scheduler.Lease
+	started := time.Now()
+	run := &models.ScheduleRun{
+		Name:       strPtr(task.Name()),
+		Host:       strPtr(s.holder),
+		StartedAt:  &started,
+		Outcome:    strPtr(models.ScheduleRunning),
+		LeaseUntil: timePtr(started.Add(lease)),
+	}
+	schedule.LastRunAt, schedule.LastOutcome, schedule.LastError = &started, run.Outcome, nil
+	ok, err := s.repo.StartRun(ctx, schedule, run)
+	if err != nil {
+		return nil, errors.Wrapf(err, "start %v run", task.Name())
+	}
+	if !ok {
+		e, _ := domain.AsError(domain.NewError(errors.Newf("%v is running", task.Name()), domain.ErrConflict))
+		return nil, e.WithCode("schedule.running", "The task is already running")
+	}
+
+	logger := domain.Logger(ctx).WithFields(log.Fields{"task": task.Name(), "runID": derefID(run.ID)})
+	renewCtx, stopRenew := context.WithCancel(ctx)
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		s.renewRun(domain.ContextWithLogger(renewCtx, logger), *run.ID, lease)
+	}()
+	err = task.Run(domain.ContextWithLogger(ctx, logger))
+	stopRenew()
+	<-renewed
+
+	finished := time.Now()
+	run.FinishedAt, run.Outcome = &finished, strPtr(models.ScheduleSucceeded)
+	logger = logger.WithField("duration", finished.Sub(started).String())
+	if err != nil {
+		run.Outcome, run.Error = strPtr(models.ScheduleFailed), strPtr(err.Error())
+		logger.WithError(err).Warn("scheduled run failed")
+	} else {
+		logger.Info("scheduled run done")
+	}
+	schedule.LastOutcome, schedule.LastError = run.Outcome, run.Error
+
+	// Saved even when the run was cancelled
+	saveCtx := domain.ContextWithLogger(context.Background(), logger)
+	if err := s.repo.FinishRun(saveCtx, schedule, run); err != nil {
+		logger.WithError(err).Error("scheduled run outcome not saved")
+	}
+	return run, nil
+}
+
+// renewRun extends the lease of the run until ctx is done
+func (s *scheduler) renewRun(ctx context.Context, id uint64, lease time.Duration) {
+	ticker := time.NewTicker(lease / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			run := &models.ScheduleRun{ID: &id, LeaseUntil: timePtr(now.Add(lease))}
+			if err := s.repo.RenewRun(ctx, run); err != nil && ctx.Err() == nil {
+				domain.Logger(ctx).WithError(err).Warn("scheduled run lease not renewed")
+			}
+		}
+	}
+}
+
+// run leads while it holds the lease until ctx is done, then waits for
+// the running tasks and releases the lease
+func (s *scheduler) run(ctx context.Context) {
+	ticker := time.NewTicker(schedulerTick)
+	defer ticker.Stop()
+	for {
+		s.tick(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			s.stepDown()
+			s.running.Wait()
+			if err := s.lease.Release(context.Background(), s.holder); err != nil {
+				domain.Logger(ctx).WithError(err).Warn("scheduler lease not released")
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick renews the lease and starts the due tasks while the replica leads
+func (s *scheduler) tick(ctx context.Context, now time.Time) {
+	logger := domain.Logger(ctx)
+	leader, err := s.lease.Acquire(ctx, s.holder, domain.Config().Scheduler.Lease)
+	if err != nil && ctx.Err() == nil {
+		logger.WithError(err).Warn("scheduler lease not renewed")
+	}
+	// The lease may still be held, but it ends before it can be renewed
+	if err != nil || !leader {
+		if s.leading() {
+			logger.Warn("scheduler leadership lost")
+			s.stepDown()
+		}
+		return
+	}
+	if !s.leading() {
+		if err := s.lead(ctx, now); err != nil {
+			logger.WithError(err).Warn("scheduler not started")
+			return
+		}
+		logger.WithField("holder", s.holder).Info("scheduler leading")
+	}
+	s.startDue(now)
+}
+
+func (s *scheduler) leading() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.entries != nil
+}
+
+// lead loads the schedules of the tasks, saving the ones new or with a
+// new spec, runs no replica renews any more are over
+func (s *scheduler) lead(ctx context.Context, now time.Time) error {
+	if err := s.repo.InterruptRuns(ctx); err != nil {
+		return errors.Wrap(err, "interrupt runs")
+	}
+	schedules, err := s.repo.List(ctx)
+	if err != nil {
+		return errors.Wrap(err, "list schedules")
+	}
+	saved := make(map[string]*models.Schedule, len(schedules))
+	for _, sc := range schedules {
+		saved[*sc.Name] = sc
+	}
+
+	entries := make(map[string]*entry, len(s.tasks))
+	for name, task := range s.tasks {
+		spec := taskSpec(task)
+		c, err := parseSpec(spec)
+		if err != nil {
+			domain.Logger(ctx).WithError(err).WithField("task", name).Error("scheduled task not scheduled")
+			continue
+		}
+		schedule, ok := saved[name]
+		if !ok || deref(schedule.Spec) != spec || (c != nil && schedule.NextRunAt == nil) {
+			if !ok {
+				schedule = &models.Schedule{Name: strPtr(name)}
+			}
+			schedule.Spec, schedule.NextRunAt = strPtr(spec), nil
+			if c != nil {
+				schedule.NextRunAt = timePtr(c.Next(now.In(utils.IST)))
+			}
+			if err := s.repo.Save(ctx, schedule); err != nil {
+				return errors.Wrapf(err, "save %v schedule", name)
+			}
+		}
+		entries[name] = &entry{task: task, schedule: schedule, cron: c}
+	}
+
+	leadCtx, cancel := context.WithCancel(ctx)
+	s.mu.Lock()
+	s.entries, s.leadCtx, s.cancelLead = entries, leadCtx, cancel
+	s.mu.Unlock()
+	return nil
+}
+
+// stepDown cancels the running tasks
+func (s *scheduler) stepDown() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancelLead != nil {
+		s.cancelLead()
+	}
+	s.entries, s.leadCtx, s.cancelLead = nil, nil, nil
+}
+
+// startDue starts the tasks due by now that are not running
+func (s *scheduler) startDue(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.entries {
+		if e.running || e.cron == nil || e.schedule.NextRunAt == nil || e.schedule.NextRunAt.After(now) {
+			continue
+		}
+		// Runs a copy, the task stays due if the run fails to start
+		schedule := *e.schedule
+		schedule.NextRunAt = timePtr(e.cron.Next(now.In(utils.IST)))
+
+		e, ctx := e, s.leadCtx
+		e.running = true
+		s.running.Add(1)
+		go func() {
+			defer s.running.Done()
+			_, err := s.runTask(ctx, e.task, &schedule)
+
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			e.running = false
+			// A run started elsewhere keeps the task due until it is over
+			if err != nil {
+				if !domain.ErrIs(err, domain.ErrConflict) {
+					domain.Logger(ctx).WithError(err).Warn("scheduled run not started")
+				}
+				return
+			}
+			e.schedule = &schedule
+		}()
+	}
+}
+
+// StartScheduler runs the scheduled tasks on the elected replica until
+// shutdown
+func StartScheduler(
+	lifecycle *domain.Lifecycle,
+	repo interfaces.ScheduleRepo,
+	lease interfaces.LeaderLease,
+	tasks ScheduledTasks,
+) {
+	s := newScheduler(repo, lease, tasks.Tasks)
+	startWorker(context.Background(), lifecycle, "scheduler", s.run)
+}
@@ -0,0 +1,356 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/configtest"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
+)
+
+type fakeLease struct {
+	mu     sync.Mutex
+	holder string
+}
+
+func (l *fakeLease) Acquire(ctx context.Context, holder string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.holder == "" {
+		l.holder = holder
+	}
+	return l.holder == holder, nil
+}
+
+func (l *fakeLease) Release(ctx context.Context, holder string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.holder == holder {
+		l.holder = ""
+	}
+	return nil
+}
+
+func (l *fakeLease) Holder(ctx context.Context) (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.holder, nil
+}
+
+type fakeScheduleRepo struct {
+	interfaces.ScheduleRepo
+	mu          sync.Mutex
+	schedules   map[string]models.Schedule
+	runs        []models.ScheduleRun
+	interrupted int
+}
+
+func newFakeScheduleRepo(schedules ...*models.Schedule) *fakeScheduleRepo {
+	r := &fakeScheduleRepo{schedules: map[string]models.Schedule{}}
+	for _, s := range schedules {
+		r.schedules[*s.Name] = *s
+	}
+	return r
+}
+
+func (r *fakeScheduleRepo) List(ctx context.Context) ([]*models.Schedule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var schedules []*models.Schedule
+	for _, s := range r.schedules {
+		s := s
+		schedules = append(schedules, &s)
+	}
+	return schedules, nil
+}
+
+func (r *fakeScheduleRepo) Save(ctx context.Context, s *models.Schedule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.schedules[*s.Name] = *s
+	return nil
+}
+
+func (r *fakeScheduleRepo) StartRun(ctx context.Context, s *models.Schedule, run *models.ScheduleRun) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, other := range r.runs {
+		if *other.Name == *s.Name && *other.Outcome == models.ScheduleRunning {
+			return false, nil
+		}
+	}
+	id := uint64(len(r.runs) + 1)
+	run.ID = &id
+	r.runs = append(r.runs, *run)
+	r.schedules[*s.Name] = *s
+	return true, nil
+}
+
+func (r *fakeScheduleRepo) RenewRun(ctx context.Context, run *models.ScheduleRun) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.runs[*run.ID-1].LeaseUntil = run.LeaseUntil
+	return nil
+}
+
+func (r *fakeScheduleRepo) FinishRun(ctx context.Context, s *models.Schedule, run *models.ScheduleRun) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.runs[*run.ID-1] = *run
+	r.schedules[*s.Name] = *s
+	return nil
+}
+
+func (r *fakeScheduleRepo) InterruptRuns(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interrupted++
+	return nil
+}
+
+func (r *fakeScheduleRepo) schedule(name string) models.Schedule {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.schedules[name]
+}
+
+func (r *fakeScheduleRepo) finished() []models.ScheduleRun {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]models.ScheduleRun(nil), r.runs...)
+}
+
+// countingTask counts its runs, blocking until released when wait is set
+type countingTask struct {
+	name, spec string
+	err        error
+	wait       chan struct{}
+
+	mu   sync.Mutex
+	runs int
+}
+
+func (t *countingTask) Name() string { return t.name }
+func (t *countingTask) Spec() string { return t.spec }
+
+func (t *countingTask) Run(ctx context.Context) error {
+	t.mu.Lock()
+	t.runs++
+	t.mu.Unlock()
+	if t.wait != nil {
+		select {
+		case <-t.wait:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return t.err
+}
+
+func (t *countingTask) count() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.runs
+}
+
+func setSchedulerConfig(t *testing.T, specs map[string]string) {
+	configtest.Use(t)
+	conf := &domain.Config().Scheduler
+	conf.Specs = specs
+	conf.Lease = time.Minute
+}
+
+func TestScheduler(t *testing.T) {
+	ctx := context.Background()
+	// A Tuesday, 10:00 IST
+	now := time.Date(2026, 3, 10, 10, 0, 0, 0, utils.IST)
+
+	t.Run("should run the due tasks on the leader only", func(t *testing.T) {
+		setSchedulerConfig(t, nil)
+		lease, repo := &fakeLease{}, newFakeScheduleRepo()
+		task := &countingTask{name: "sync", spec: "*/15 * * * *"}
+		leader := newScheduler(repo, lease, []interfaces.ScheduledTask{task})
+		leader.holder = "a"
+		follower := newScheduler(repo, lease, []interfaces.ScheduledTask{task})
+		follower.holder = "b"
+
+		leader.tick(ctx, now)
+		follower.tick(ctx, now)
+		assert.True(t, leader.leading())
+		assert.False(t, follower.leading())
+		assert.Equal(t, 1, repo.interrupted)
+		assert.Equal(t, now.Add(15*time.Minute), *repo.schedule("sync").NextRunAt)
+
+		leader.tick(ctx, now.Add(15*time.Minute))
+		follower.tick(ctx, now.Add(15*time.Minute))
+		leader.running.Wait()
+		assert.Equal(t, 1, task.count())
+		s := repo.schedule("sync")
+		assert.Equal(t, models.ScheduleSucceeded, deref(s.LastOutcome))
+		assert.Equal(t, now.Add(30*time.Minute), *s.NextRunAt)
+		runs := repo.finished()
+		if assert.Len(t, runs, 1) {
+			assert.Equal(t, "a", *runs[0].Host)
+			assert.NotNil(t, runs[0].FinishedAt)
+		}
+	})
+
+	t.Run("should save the failures of the runs", func(t *testing.T) {
+		setSchedulerConfig(t, nil)
+		repo := newFakeScheduleRepo()
+		task := &countingTask{name: "sync", spec: "@hourly", err: errors.New("augmont down")}
+		s := newScheduler(repo, &fakeLease{}, []interfaces.ScheduledTask{task})
+
+		s.tick(ctx, now)
+		s.tick(ctx, now.Add(time.Hour))
+		s.running.Wait()
+		runs := repo.finished()
+		if assert.Len(t, runs, 1) {
+			assert.Equal(t, models.ScheduleFailed, *runs[0].Outcome)
+			assert.Equal(t, "augmont down", *runs[0].Error)
+		}
+		assert.Equal(t, "augmont down", deref(repo.schedule("sync").LastError))
+	})
+
+	t.Run("should run missed runs once on takeover", func(t *testing.T) {
+		setSchedulerConfig(t, nil)
+		missed := now.Add(-3 * time.Hour)
+		repo := newFakeScheduleRepo(&models.Schedule{Name: strPtr("sync"), Spec: strPtr("@hourly"), NextRunAt: &missed})
+		task := &countingTask{name: "sync", spec: "@hourly"}
+		s := newScheduler(repo, &fakeLease{}, []interfaces.ScheduledTask{task})
+
+		s.tick(ctx, now)
+		s.running.Wait()
+		s.tick(ctx, now.Add(time.Second))
+		s.running.Wait()
+		assert.Equal(t, 1, task.count())
+		assert.Equal(t, now.Add(time.Hour), *repo.schedule("sync").NextRunAt)
+	})
+
+	t.Run("should take the specs of the config", func(t *testing.T) {
+		setSchedulerConfig(t, map[string]string{"sync": "off", "poll": "0 12 * * *"})
+		next := now.Add(time.Hour)
+		repo := newFakeScheduleRepo(&models.Schedule{Name: strPtr("sync"), Spec: strPtr("@hourly"), NextRunAt: &next})
+		sync := &countingTask{name: "sync", spec: "@hourly"}
+		poll := &countingTask{name: "poll", spec: "@hourly"}
+		s := newScheduler(repo, &fakeLease{}, []interfaces.ScheduledTask{sync, poll})
+
+		s.tick(ctx, now)
+		assert.Equal(t, "off", deref(repo.schedule("sync").Spec))
+		assert.Nil(t, repo.schedule("sync").NextRunAt)
+		assert.Equal(t, now.Add(2*time.Hour), *repo.schedule("poll").NextRunAt)
+
+		s.tick(ctx, now.Add(48*time.Hour))
+		s.running.Wait()
+		assert.Equal(t, 0, sync.count())
+		assert.Equal(t, 1, poll.count())
+	})
+
+	t.Run("should not run a task twice at once", func(t *testing.T) {
+		setSchedulerConfig(t, nil)
+		repo := newFakeScheduleRepo()
+		task := &countingTask{name: "sync", spec: "* * * * *", wait: make(chan struct{})}
+		s := newScheduler(repo, &fakeLease{}, []interfaces.ScheduledTask{task})
+
+		s.tick(ctx, now)
+		s.tick(ctx, now.Add(time.Minute))
+		s.tick(ctx, now.Add(2*time.Minute))
+		close(task.wait)
+		s.running.Wait()
+		assert.Equal(t, 1, task.count())
+	})
+
+	t.Run("should cancel the runs when the lease is lost", func(t *testing.T) {
+		setSchedulerConfig(t, nil)
+		lease, repo := &fakeLease{}, newFakeScheduleRepo()
+		task := &countingTask{name: "sync", spec: "* * * * *", wait: make(chan struct{})}
+		s := newScheduler(repo, lease, []interfaces.ScheduledTask{task})
+
+		s.tick(ctx, now)
+		s.tick(ctx, now.Add(time.Minute))
+		lease.holder = "other"
+		s.tick(ctx, now.Add(time.Minute+time.Second))
+		s.running.Wait()
+		assert.False(t, s.leading())
+		runs := repo.finished()
+		if assert.Len(t, runs, 1) {
+			assert.Equal(t, models.ScheduleFailed, *runs[0].Outcome)
+		}
+	})
+
+	t.Run("should refuse a manual run while the task runs", func(t *testing.T) {
+		setSchedulerConfig(t, nil)
+		repo := newFakeScheduleRepo()
+		task := &countingTask{name: "sync", spec: "* * * * *", wait: make(chan struct{})}
+		leader := newScheduler(repo, &fakeLease{}, []interfaces.ScheduledTask{task})
+		other := newScheduler(repo, &fakeLease{holder: "leader"}, []interfaces.ScheduledTask{task})
+
+		leader.tick(ctx, now)
+		leader.tick(ctx, now.Add(time.Minute))
+		assert.Eventually(t, func() bool { return task.count() == 1 }, time.Second, time.Millisecond)
+		_, err := other.Run(ctx, "sync")
+		assert.True(t, domain.ErrIs(err, domain.ErrConflict))
+		close(task.wait)
+		leader.running.Wait()
+
+		run, err := other.Run(ctx, "sync")
+		assert.NoError(t, err)
+		assert.Equal(t, models.ScheduleSucceeded, *run.Outcome)
+		assert.Equal(t, 2, task.count())
+	})
+
+	t.Run("should keep a task due while it runs elsewhere", func(t *testing.T) {
+		setSchedulerConfig(t, nil)
+		repo := newFakeScheduleRepo()
+		task := &countingTask{name: "sync", spec: "* * * * *"}
+		s := newScheduler(repo, &fakeLease{}, []interfaces.ScheduledTask{task})
+		s.tick(ctx, now)
+		started := now
+		repo.runs = append(repo.runs, models.ScheduleRun{Name: strPtr("sync"), StartedAt: &started, Outcome: strPtr(models.ScheduleRunning)})
+
+		s.tick(ctx, now.Add(time.Minute))
+		s.running.Wait()
+		assert.Equal(t, 0, task.count())
+
+		repo.runs[0].Outcome = strPtr(models.ScheduleSucceeded)
+		s.tick(ctx, now.Add(time.Minute+time.Second))
+		s.running.Wait()
+		assert.Equal(t, 1, task.count())
+	})
+
+	t.Run("should renew the lease of long runs", func(t *testing.T) {
+		setSchedulerConfig(t, nil)
+		domain.Config().Scheduler.Lease = 30 * time.Millisecond
+		repo := newFakeScheduleRepo()
+		task := &countingTask{name: "sync", spec: "@hourly", wait: make(chan struct{})}
+		s := newScheduler(repo, &fakeLease{}, []interfaces.ScheduledTask{task})
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			s.Run(ctx, "sync")
+		}()
+		assert.Eventually(t, func() bool { return task.count() == 1 }, time.Second, time.Millisecond)
+		first := *repo.finished()[0].LeaseUntil
+		assert.Eventually(t, func() bool {
+			return repo.finished()[0].LeaseUntil.After(first)
+		}, time.Second, time.Millisecond)
+		close(task.wait)
+		<-done
+	})
+
+	t.Run("should not run unknown tasks", func(t *testing.T) {
+		setSchedulerConfig(t, nil)
+		s := newScheduler(newFakeScheduleRepo(), &fakeLease{}, nil)
+		_, err := s.Run(ctx, "sync")
+		assert.True(t, domain.ErrIs(err, domain.ErrNotFound))
+	})
+}
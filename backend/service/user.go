@@ -1,8 +1,15 @@
 package service
 
 import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/i18n"
 	"github.com/EQUISEED-WEALTH/pinch/backend/domain/interfaces"
 	"github.com/EQUISEED-WEALTH/pinch/backend/domain/models"
+	"github.com/EQUISEED-WEALTH/pinch/backend/domain/utils"
 )
 
 type userService struct {
@@ -16,22 +23,44 @@ func NewUserService(userRepo interfaces.UserRepo) interfaces.UserService {
 	}
 }
 
-func (s *userService) Create(user *models.User) error {
-	return s.userRepo.Create(user)
+func (s *userService) Create(ctx context.Context, user *models.User) error {
+	if err := validateLocale(user); err != nil {
+		return err
+	}
+	return s.userRepo.Create(ctx, user)
 }
 
-func (s *userService) Update(user *models.User) error {
-	return s.userRepo.Update(user)
+func (s *userService) Update(ctx context.Context, user *models.User) error {
+	if err := validateLocale(user); err != nil {
+		return err
+	}
+	return s.userRepo.Update(ctx, user)
 }
 
-func (s *userService) Delete(user *models.User) error {
-	return s.userRepo.Delete(user)
+func (s *userService) Delete(ctx context.Context, user *models.User) error {
+	return s.userRepo.Delete(ctx, user)
 }
 
-func (s *userService) FindOne(user *models.User) (*models.User, error) {
-	return s.userRepo.FindOne(user)
+func (s *userService) FindOne(ctx context.Context, user *models.User) (*models.User, error) {
+	return s.userRepo.FindOne(ctx, user)
 }
 
-func (s *userService) FindAll() ([]*models.User, error) {
-	return s.userRepo.FindMany(nil)
+func (s *userService) List(ctx context.Context, q *utils.ListQuery) ([]*models.User, *utils.Page, error) {
+	return s.userRepo.List(ctx, q)
+}
+
+// validateLocale checks the preferred language has a message catalogue
+func validateLocale(user *models.User) error {
+	if user.Locale == nil || i18n.IsSupported(*user.Locale) {
+		return nil
+	}
+	err := errors.Newf("unsupported locale %q", *user.Locale)
+	msg, _ := i18n.T(i18n.DefaultLocale, "validation.locale", nil)
+	e, _ := domain.AsError(domain.NewError(err, domain.ErrInvalidArgument))
+	return e.WithCode("validation_failed", "Please correct the highlighted fields").
+		WithFields(domain.FieldError{
+			Field:   "locale",
+			Code:    "locale",
+			Message: msg,
+		})
 }